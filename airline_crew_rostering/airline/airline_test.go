@@ -1,71 +1,132 @@
-package airline_test
-
-import (
-	"testing"
-	"time"
-
-	"go-airline-crew-rostering/airline"
-)
-
-func TestAirline(t *testing.T) {
-	// Simple test for airline package
-	pilot := new(airline.Pilot)
-	al := new(airline.Airline)
-	start1 := time.Date(2011, 11, 1, 3, 20, 0, 0, time.UTC)
-	start2 := time.Date(2011, 11, 1, 5, 0, 0, 0, time.UTC)
-	start3 := time.Date(2011, 11, 1, 12, 0, 0, 0, time.UTC)
-	end1 := time.Date(2011, 11, 1, 4, 20, 0, 0, time.UTC)
-	end2 := time.Date(2011, 11, 1, 6, 5, 0, 0, time.UTC)
-	end3 := time.Date(2011, 11, 1, 13, 5, 0, 0, time.UTC)
-	startSchedule := time.Date(2011, 11, 1, 0, 0, 0, 0, time.UTC)
-	endSchedule := time.Date(2011, 11, 2, 0, 0, 0, 0, time.UTC)
-	al.Initialization(660, 7, 2, startSchedule, endSchedule, 45)
-	root := new(airline.Pair)
-	root.Initialization(0, startSchedule)
-	pair1 := new(airline.Pair)
-	pair2 := new(airline.Pair)
-	pair1.Initialization(1, startSchedule)
-	pair2.Initialization(2, startSchedule)
-	pilot.Initialization(0, al.ScheduleDuration, root)
-	pair1.Add(1, start3, end3, startSchedule)
-	pair1.Add(1, start2, end2, startSchedule)
-	pair1.Add(1, start1, end1, startSchedule)
-
-	pair2.Add(2, start1, end1, startSchedule)
-	// t.Log(pair1.Duration, "minutes")
-	// t.Log("id:", pair1.Id)
-	// t.Log("start:", pair1.Start)
-	// t.Log("end:", pair1.End)
-
-	pilot.Add(pair1, 1)
-	// t.Log("pilot first pair:", pilot.AssignedPairs[0].Id, pilot.AssignedPairs[0].Start)
-	// t.Log("pilot second pair:", pilot.AssignedPairs[1].Id)
-	pilot.Add(pair2, 1)
-	// t.Log("pilot second pair:", pilot.AssignedPairs[1].Id)
-	// t.Log("pilot third pair:", pilot.AssignedPairs[2].Id)
-	pilot.Remove(pair2)
-	// t.Log("pilot second pair:", pilot.AssignedPairs[1].Id)
-
-	pilot2 := new(airline.Pilot)
-	pilot2.Initialization(1, al.ScheduleDuration, root)
-	t.Log("pilot2 pairs:", pilot2.AssignedLength, pilot.AssignedPairs[0])
-	t.Log(al.OverlappingPairs(pair1, pair2))
-	start4 := time.Date(2011, 11, 2, 18, 20, 0, 0, time.UTC)
-	end4 := time.Date(2011, 11, 2, 19, 20, 0, 0, time.UTC)
-	pair3 := new(airline.Pair)
-	pair3.Initialization(3, startSchedule)
-	pair3.Add(3, start4, end4, startSchedule)
-	t.Log(al.OverlappingPairs(pair1, pair3))
-	t.Log(al.OverlappingPairs(pair3, pair1))
-	i := al.RestPeriodRule(pilot, pair1)
-	t.Log("index for pilot and pair1:", i)
-	i = al.RestPeriodRule(pilot, pair3)
-	t.Log("index for pilot and pair3:", i)
-	i = al.RestPeriodRule(pilot2, pair3)
-	t.Log("index for pilot2 and pair3:", i)
-	pilot2.Add(pair3, i)
-	i = al.RestPeriodRule(pilot2, pair1)
-	t.Log("index for pilot2 and pair1:", i)
-	pilot2.Add(pair1, i)
-
-}
+package airline_test
+
+import (
+	"testing"
+	"time"
+
+	"go-airline-crew-rostering/airline"
+)
+
+func TestAirline(t *testing.T) {
+	// Simple test for airline package
+	pilot := new(airline.Pilot)
+	al := new(airline.Airline)
+	start1 := time.Date(2011, 11, 1, 3, 20, 0, 0, time.UTC)
+	start2 := time.Date(2011, 11, 1, 5, 0, 0, 0, time.UTC)
+	start3 := time.Date(2011, 11, 1, 12, 0, 0, 0, time.UTC)
+	end1 := time.Date(2011, 11, 1, 4, 20, 0, 0, time.UTC)
+	end2 := time.Date(2011, 11, 1, 6, 5, 0, 0, time.UTC)
+	end3 := time.Date(2011, 11, 1, 13, 5, 0, 0, time.UTC)
+	startSchedule := time.Date(2011, 11, 1, 0, 0, 0, 0, time.UTC)
+	endSchedule := time.Date(2011, 11, 2, 0, 0, 0, 0, time.UTC)
+	al.Initialization(660, 7, 2, startSchedule, endSchedule, 45)
+	root := new(airline.Pair)
+	root.Initialization(0, startSchedule)
+	pair1 := new(airline.Pair)
+	pair2 := new(airline.Pair)
+	pair1.Initialization(1, startSchedule)
+	pair2.Initialization(2, startSchedule)
+	pilot.Initialization(0, al.ScheduleDuration, root)
+	pair1.Add(1, start3, end3, startSchedule)
+	pair1.Add(1, start2, end2, startSchedule)
+	pair1.Add(1, start1, end1, startSchedule)
+
+	pair2.Add(2, start1, end1, startSchedule)
+	// t.Log(pair1.Duration, "minutes")
+	// t.Log("id:", pair1.Id)
+	// t.Log("start:", pair1.Start)
+	// t.Log("end:", pair1.End)
+
+	pilot.Add(pair1, 1)
+	// t.Log("pilot first pair:", pilot.AssignedPairs[0].Id, pilot.AssignedPairs[0].Start)
+	// t.Log("pilot second pair:", pilot.AssignedPairs[1].Id)
+	pilot.Add(pair2, 1)
+	// t.Log("pilot second pair:", pilot.AssignedPairs[1].Id)
+	// t.Log("pilot third pair:", pilot.AssignedPairs[2].Id)
+	pilot.Remove(pair2)
+	// t.Log("pilot second pair:", pilot.AssignedPairs[1].Id)
+
+	pilot2 := new(airline.Pilot)
+	pilot2.Initialization(1, al.ScheduleDuration, root)
+	t.Log("pilot2 pairs:", pilot2.AssignedLength, pilot.AssignedPairs[0])
+	t.Log(al.OverlappingPairs(pair1, pair2))
+	start4 := time.Date(2011, 11, 2, 18, 20, 0, 0, time.UTC)
+	end4 := time.Date(2011, 11, 2, 19, 20, 0, 0, time.UTC)
+	pair3 := new(airline.Pair)
+	pair3.Initialization(3, startSchedule)
+	pair3.Add(3, start4, end4, startSchedule)
+	t.Log(al.OverlappingPairs(pair1, pair3))
+	t.Log(al.OverlappingPairs(pair3, pair1))
+	i := al.RestPeriodRule(pilot, pair1)
+	t.Log("index for pilot and pair1:", i)
+	i = al.RestPeriodRule(pilot, pair3)
+	t.Log("index for pilot and pair3:", i)
+	i = al.RestPeriodRule(pilot2, pair3)
+	t.Log("index for pilot2 and pair3:", i)
+	pilot2.Add(pair3, i)
+	i = al.RestPeriodRule(pilot2, pair1)
+	t.Log("index for pilot2 and pair1:", i)
+	pilot2.Add(pair1, i)
+
+}
+
+func TestAverageDaysOff(t *testing.T) {
+	// schedules shorter than a timespan count as a single period
+	start := time.Date(2011, 11, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		days         int
+		totalDaysOff int
+		average      float64
+	}{
+		{1, 3, 1},
+		{7, 6, 2},
+		{8, 6, 2},
+		{15, 12, 2},
+		{29, 24, 2},
+	}
+	for _, test := range tests {
+		al := new(airline.Airline)
+		al.Initialization(660, 7, 2, start, start.AddDate(0, 0, test.days), 3)
+		if average := al.AverageDaysOff(test.totalDaysOff); average != test.average {
+			t.Errorf("%d days: AverageDaysOff(%d) = %v, expected %v", test.days, test.totalDaysOff, average, test.average)
+		}
+	}
+}
+
+func TestCopySolution(t *testing.T) {
+	// the copies change their schedules without changing the solution
+	start := time.Date(2011, 11, 1, 0, 0, 0, 0, time.UTC)
+	al := new(airline.Airline)
+	al.Initialization(660, 7, 2, start, start.AddDate(0, 0, 14), 2)
+	root := new(airline.Pair)
+	root.Initialization(0, start)
+	pairs := []*airline.Pair{}
+	for i := 1; i <= 3; i++ {
+		pair := new(airline.Pair)
+		pair.Initialization(i, start)
+		pair.Add(i, start.AddDate(0, 0, i).Add(8*time.Hour), start.AddDate(0, 0, i).Add(12*time.Hour), start)
+		pairs = append(pairs, pair)
+	}
+	solution := []*airline.Pilot{}
+	for id := 0; id < 2; id++ {
+		pilot := new(airline.Pilot)
+		pilot.Initialization(id, al.ScheduleDuration, root)
+		solution = append(solution, pilot)
+	}
+	solution[0].Add(pairs[0], 1)
+	solution[0].Add(pairs[2], 2)
+
+	copied := airline.CopySolution(solution)
+	copied[0].Add(pairs[1], 2)
+	copied[0].Remove(pairs[2])
+	copied[1].Add(pairs[2], 1)
+	if solution[0].AssignedLength != 2 || solution[0].AssignedPairs[2] != pairs[2] || solution[0].FlightTime != 480 || solution[0].DaysOff() != 11 {
+		t.Errorf("pilot 0 has %d pairings, flight time %v and %d days off after its copy changed", solution[0].AssignedLength, solution[0].FlightTime, solution[0].DaysOff())
+	}
+	if solution[1].AssignedLength != 0 || solution[1].DaysOff() != 13 {
+		t.Errorf("pilot 1 has %d pairings and %d days off after its copy changed", solution[1].AssignedLength, solution[1].DaysOff())
+	}
+	if copied[0].Id != 0 || copied[0].AssignedLength != 2 || copied[0].AssignedPairs[2] != pairs[1] || copied[0].DaysOff() != 11 || copied[1].DaysOff() != 12 {
+		t.Errorf("the copies have %d and %d pairings and %d and %d days off", copied[0].AssignedLength, copied[1].AssignedLength, copied[0].DaysOff(), copied[1].DaysOff())
+	}
+}
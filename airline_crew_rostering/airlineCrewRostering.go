@@ -1,146 +1,597 @@
-package main
-
-import (
-	"fmt"
-	"math"
-	"math/rand"
-	"time"
-
-	"go-airline-crew-rostering/airline"
-	"go-airline-crew-rostering/archimedesOptimization"
-	"go-airline-crew-rostering/graph"
-	"go-airline-crew-rostering/input"
-	"go-airline-crew-rostering/metrics"
-	"go-airline-crew-rostering/multicso"
-	"go-airline-crew-rostering/results"
-
-	"golang.org/x/exp/slices"
-)
-
-func main() {
-	startOfExecution := time.Now()
-	args := input.SetUpParser() // parse arguments
-	if args == nil {
-		return
-	}
-	// Initialize random number generator
-	if *args.Seed != -1 {
-		rand.Seed(int64(*args.Seed))
-	}
-	al := AirlineSetup(args)
-	pairGraph := GraphSetup(*args.Agents, al.PairsArray)
-
-	var metric *metrics.Metrics
-	if args.Algorithm == "multiCSO" {
-		// execute multi-step CSO algorithm
-		swarm := SwarmSetup(al, pairGraph, *args.Agents, *args.Generations, *args.FL)
-		swarm.MultiCSO(al, pairGraph)
-		chicken := swarm.Swarm[0]
-		al.PilotsArray = chicken.Solution
-		metric = swarm.Mtr
-
-	} else if args.Algorithm == "AOA" {
-		// execute AOA algorithm
-		collection := CollectionSetup(al, pairGraph, *args.Agents, *args.Generations, args.Constants)
-		collection.AOA(al, pairGraph)
-		object := collection.Collection[0]
-		al.PilotsArray = object.Solution
-		metric = collection.Mtr
-	}
-	difference := 0.0
-	rest := 0.0
-	pairsCovered := 0
-	totalDaysOff := 0
-	// calculate rest between two pairs and days off
-	for _, pilot := range al.PilotsArray {
-		difference = difference + math.Abs(al.AverageWorkload-pilot.FlightTime)
-		pairsCovered += pilot.AssignedLength
-		restPerPilot := 0.0
-		restPerPilot = pilot.TotalRestPeriod(al)
-		totalDaysOff += pilot.DaysOff()
-		rest += restPerPilot
-	}
-	metric.AverageRestPeriod = rest / float64((len(al.PairsArray)-1)*60)
-	metric.AverageDaysOff = al.AverageDaysOff(totalDaysOff)
-	metric.TotalTime = time.Since(startOfExecution)
-	metric.TotalAssignedPairs = pairsCovered
-
-	// check again if the solution obeys the rules
-	if SolutionChecker(al.PilotsArray) {
-		fmt.Println("Valid solution")
-	} else {
-		fmt.Println("Invalid solution")
-	}
-	results.PrintResults(metric, args, al) // store the results
-
-}
-
-func AirlineSetup(args *input.ArgumentCollection) *airline.Airline {
-	// Create, initialize and set up an airline instance
-	// returns pointer to the airline
-	al := new(airline.Airline)
-	al.Initialization(660, 7, 2, args.StartDate, args.EndDate, *args.Pilots)
-
-	// create pairings
-	al.PairsArray = input.ReadFile(*args.Filename, al.ScheduleStart)
-	al.PairsArray = input.FilterPairs(al.PairsArray, al.ScheduleStart, al.ScheduleEnd)
-	al.PairsArray = input.SortPairs(al.PairsArray)
-	root := new(airline.Pair) // create special root pair
-	root.Initialization(0, al.ScheduleStart)
-	al.PairsArray = slices.Insert(al.PairsArray, 0, root)
-
-	// create array of pilots
-	for i := 0; i < al.NumberOfPilots; i++ {
-		pilot := new(airline.Pilot)
-		pilot.Initialization(i, al.ScheduleDuration, root)
-		al.PilotsArray = append(al.PilotsArray, pilot)
-	}
-	al.CalculateAverageWorkload()
-	return al
-}
-
-func GraphSetup(agents int, pairsArray []*airline.Pair) *graph.Graph {
-	// Create and Initialize a graph with the pairings from the pairsArray
-	// returns pointer to the graph
-	pairGraph := new(graph.Graph)
-	pairGraph.Initialization(agents)
-	pairGraph.Populate(pairsArray)
-	return pairGraph
-}
-
-func SwarmSetup(al *airline.Airline, pairGraph *graph.Graph, agents int, maxGenerations int, fl float64) *multicso.MultiCSO {
-	// Create and Initialize a chicken swarm
-	Mtr := new(metrics.Metrics)
-	Mtr.Initialization(maxGenerations*agents, 32)
-	swarm := new(multicso.MultiCSO)
-	swarm.Initialization(al, pairGraph, agents, maxGenerations, fl, Mtr)
-	return swarm
-}
-
-func CollectionSetup(al *airline.Airline, pairGraph *graph.Graph, agents int, maxGenerations int, constants []float64) *archimedesOptimization.AOAObjectCollection {
-	//  Create and Initialize an object collection
-	Mtr := new(metrics.Metrics)
-	Mtr.Initialization(maxGenerations*agents, 32)
-	collection := new(archimedesOptimization.AOAObjectCollection)
-	collection.Initialization(agents, maxGenerations, constants[0], constants[1], constants[2], constants[3], al, pairGraph, Mtr)
-	return collection
-}
-
-func SolutionChecker(solution []*airline.Pilot) bool {
-	// checks if the given solution obeys the rules
-	// returns true on success
-	for _, pilot := range solution {
-		for i := 2; i <= pilot.AssignedLength; i++ {
-			rest := pilot.AssignedPairs[i].Start.Sub(pilot.AssignedPairs[i-1].End).Minutes()
-			if rest < 660 {
-				fmt.Printf("pilot %d: pair %d(source) and pair %d(goal) are only %e minutes apart\n", pilot.Id, pilot.AssignedPairs[i].Id, pilot.AssignedPairs[i-1].Id, rest)
-				return false
-			}
-		}
-		if !pilot.DaysOffRuleChecker() {
-			fmt.Printf("pilot %d: not enough days off\n", pilot.Id)
-			return false
-		}
-	}
-	return true
-}
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"time"
+
+	"go-airline-crew-rostering/airline"
+	"go-airline-crew-rostering/archimedesOptimization"
+	"go-airline-crew-rostering/checkpoint"
+	"go-airline-crew-rostering/columnGeneration"
+	"go-airline-crew-rostering/comparison"
+	"go-airline-crew-rostering/diff"
+	"go-airline-crew-rostering/disruption"
+	"go-airline-crew-rostering/experiment"
+	"go-airline-crew-rostering/fitness"
+	"go-airline-crew-rostering/graph"
+	"go-airline-crew-rostering/input"
+	"go-airline-crew-rostering/island"
+	"go-airline-crew-rostering/metrics"
+	"go-airline-crew-rostering/multicso"
+	"go-airline-crew-rostering/nsga"
+	"go-airline-crew-rostering/problem"
+	"go-airline-crew-rostering/randomness"
+	"go-airline-crew-rostering/results"
+	"go-airline-crew-rostering/stopping"
+	"go-airline-crew-rostering/tuning"
+	"go-airline-crew-rostering/workers"
+)
+
+func main() {
+	startOfExecution := time.Now()
+	args := input.SetUpParser() // parse arguments
+	if args == nil {
+		return
+	}
+	// Read the checkpoint to continue the run from
+	var state *checkpoint.Checkpoint
+	if *args.Resume != "" {
+		if args.Algorithm == "columnGeneration" {
+			log.Fatal("column generation does not support checkpoints")
+		}
+		state = checkpoint.Load(*args.Resume)
+	}
+	if args.Algorithm == "islands" && (state != nil || *args.Checkpoint != "") {
+		log.Fatal("the island model does not support checkpoints")
+	}
+	checkpoints := &checkpoint.Options{Filename: *args.Checkpoint, Every: *args.Every}
+
+	// Initialize random number generator
+	// (without a given seed, one is generated so that the run can be replayed)
+	seed := int64(*args.Seed)
+	if state != nil {
+		seed = state.Seed
+	} else if seed == -1 {
+		seed = time.Now().UnixNano()
+	}
+	generator := randomness.New(seed)
+	if len(*args.Suffixes) > 0 {
+		*args.ResultsFile = input.ResultsName(*args.ResultsFile, *args.Suffixes, args.Algorithm, seed, startOfExecution)
+		fmt.Println("Results file:", *args.ResultsFile)
+	}
+	objective := fitness.DefaultObjective()
+	if *args.Objective != "" {
+		objective = input.ReadObjective(*args.Objective)
+	}
+	// The optimization algorithms stop early if one of the stopping criteria
+	// is met, the time limit is reached or the run is interrupted (Ctrl+C)
+	criteria := new(stopping.Criteria)
+	criteria.Initialization(time.Duration(*args.TimeLimit*float64(time.Minute)), *args.TargetCost, *args.Stagnation, *args.Coverage)
+	interrupt, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	ctx, cancel := criteria.Context(interrupt)
+	defer cancel()
+
+	if args.Algorithm == "compare" {
+		// compare the runs of experiments instead of solving the problem
+		Compare(args)
+		return
+	}
+	if args.Algorithm == "experiment" {
+		// run the algorithm with several seeds instead of solving the problem once
+		Experiment(interrupt, args, objective, generator)
+		return
+	}
+	if args.Algorithm == "tune" {
+		// race the configurations of the algorithm parameters instead of solving the problem
+		Tune(interrupt, args, objective, generator)
+		return
+	}
+	al := AirlineSetup(args)
+	if args.Algorithm == "replan" {
+		// repair the published roster instead of searching for a new one
+		Replan(args, al, objective)
+		return
+	}
+	if args.Algorithm == "diff" {
+		// compare two rosters instead of searching for a new one
+		Diff(args, al)
+		return
+	}
+	if args.Algorithm == "score" {
+		// validate and score a given roster instead of searching for a new one
+		Score(args, al, objective, seed, startOfExecution)
+		return
+	}
+	pairGraph := GraphSetup(*args.Agents, al.PairsArray)
+	// Read the roster used as the starting point of the search
+	var roster []*airline.Pilot
+	if *args.WarmStart != "" {
+		roster = input.ReadRoster(*args.WarmStart, al)
+		pairGraph.Seed(roster, *args.WarmWeight)
+	}
+
+	var metric *metrics.Metrics
+	if args.Algorithm == "multiCSO" {
+		// execute multi-step CSO algorithm
+		swarm := SwarmSetup(al, pairGraph, *args.Agents, *args.Generations, *args.FL, objective, *args.Workers, criteria, generator)
+		if roster != nil {
+			swarm.WarmStart(al, roster)
+		}
+		swarm.SetCheckpoints(checkpoints)
+		if state != nil {
+			swarm.Resume(state, al, pairGraph)
+		}
+		swarm.MultiCSO(ctx, al, pairGraph)
+		chicken := swarm.Swarm[0]
+		al.PilotsArray = chicken.Solution
+		metric = swarm.Mtr
+
+	} else if args.Algorithm == "AOA" {
+		// execute AOA algorithm
+		collection := CollectionSetup(al, pairGraph, *args.Agents, *args.Generations, args.Constants, objective, *args.Workers, criteria, generator)
+		if roster != nil {
+			collection.WarmStart(al, roster)
+		}
+		collection.SetCheckpoints(checkpoints)
+		if state != nil {
+			collection.Resume(state, al, pairGraph)
+		}
+		collection.AOA(ctx, al, pairGraph)
+		object := collection.Collection[0]
+		al.PilotsArray = object.Solution
+		metric = collection.Mtr
+
+	} else if args.Algorithm == "columnGeneration" {
+		// execute column generation
+		cg := ColumnGenerationSetup(al, *args.Generations, *args.Columns, *args.Penalty, objective)
+		if roster != nil {
+			cg.WarmStart(al, roster)
+		}
+		al.PilotsArray = cg.ColumnGeneration(al)
+		metric = cg.Mtr
+
+	} else if args.Algorithm == "islands" {
+		// execute several swarms that exchange their best solutions
+		model := IslandsSetup(args, al, roster, objective, criteria, generator)
+		al.PilotsArray = model.Run(ctx, al)
+		metric = model.Mtr
+
+	} else if args.Algorithm == "NSGA" {
+		// execute NSGA-II (the offspring need their own positions in the graph)
+		pairGraph = GraphSetup(2*(*args.Agents), al.PairsArray)
+		if roster != nil {
+			pairGraph.Seed(roster, *args.WarmWeight)
+		}
+		population := NSGASetup(al, pairGraph, *args.Agents, *args.Generations, *args.Mutation, objective, *args.Workers, criteria, generator)
+		if roster != nil {
+			population.WarmStart(al, pairGraph, roster)
+		}
+		population.SetCheckpoints(checkpoints)
+		if state != nil {
+			population.Resume(state, al, pairGraph)
+		}
+		front := population.NSGA(ctx, al, pairGraph)
+		al.PilotsArray = front[0].Solution
+		metric = population.Mtr
+	}
+	SolutionMetrics(metric, al, seed, startOfExecution)
+
+	// check again if the solution obeys the rules
+	if SolutionChecker(al.PilotsArray) {
+		fmt.Println("Valid solution")
+	} else {
+		fmt.Println("Invalid solution")
+	}
+	results.PrintResults(metric, args, al, objective) // store the results
+	if checkpoints.Err != nil {
+		// the results are stored, but the run cannot be continued from its last checkpoint
+		log.Fatal(checkpoints.Err)
+	}
+}
+
+func SolutionMetrics(metric *metrics.Metrics, al *airline.Airline, seed int64, startOfExecution time.Time) {
+	// Store the metrics of the solution "al.PilotsArray" in "metric"
+	difference := 0.0
+	rest := 0.0
+	pairsCovered := 0
+	totalDaysOff := 0
+	// calculate rest between two pairs and days off
+	for _, pilot := range al.PilotsArray {
+		difference = difference + math.Abs(al.AverageWorkload-pilot.FlightTime)
+		pairsCovered += pilot.AssignedLength
+		restPerPilot := 0.0
+		restPerPilot = pilot.TotalRestPeriod(al)
+		totalDaysOff += pilot.DaysOff()
+		rest += restPerPilot
+	}
+	metric.AverageRestPeriod = rest / float64((len(al.PairsArray)-1)*60)
+	metric.AverageDaysOff = al.AverageDaysOff(totalDaysOff)
+	metric.TotalTime = time.Since(startOfExecution)
+	metric.TotalAssignedPairs = pairsCovered
+	metric.Fairness = metrics.FairnessIndicators(al.PilotsArray, al)
+	metric.Pilots = metrics.PilotStatisticsOf(al.PilotsArray, al)
+	metric.Seed = seed
+	metric.Uncovered = problem.UncoveredPairs(al, al.PilotsArray)
+}
+
+func AirlineSetup(args *input.ArgumentCollection) *airline.Airline {
+	// Create, initialize and set up an airline instance
+	// returns pointer to the airline
+	al := input.ReadInstance(*args.Filename, args.StartDate, args.EndDate, *args.Pilots)
+
+	// read the requested days off of the pilots
+	if args.Preferences != nil && *args.Preferences != "" {
+		al.Preferences = input.ReadPreferences(*args.Preferences, al.ScheduleStart)
+	}
+	return al
+}
+
+func Replan(args *input.ArgumentCollection, al *airline.Airline, objective *fitness.Objective) {
+	// Repair the published roster after the disruption events, changing as few
+	// assignments as possible, and store the repaired roster along with the changes
+	events := input.ReadEvents(*args.Events, al.ScheduleStart)
+	roster := input.ReadRoster(*args.Roster, al)
+	repair := new(disruption.Disruption)
+	repair.Initialization(events, args.Cutoff)
+	al.PilotsArray = repair.Repair(al, roster)
+
+	// print the changes of each pilot
+	pilot := -1
+	for _, change := range repair.Changes {
+		if change.Pilot != pilot {
+			pilot = change.Pilot
+			fmt.Printf("Pilot %d:\n", pilot)
+		}
+		fmt.Printf("\t%s pairing %d (%s)\n", change.Action, change.Pair.Id, change.Reason)
+	}
+	fmt.Println(len(repair.Changes), "changed assignments,", len(repair.Uncovered), "uncovered pairings")
+
+	if SolutionChecker(al.PilotsArray) {
+		fmt.Println("Valid solution")
+	} else {
+		fmt.Println("Invalid solution")
+	}
+	results.PrintReplan(repair, args, al, objective)
+}
+
+func Score(args *input.ArgumentCollection, al *airline.Airline, objective *fitness.Objective, seed int64, startOfExecution time.Time) {
+	// Validate the roster of a results file (for example a roster edited by hand)
+	// against the pairings and the rules, and store it along with its metrics
+	roster, violations := input.ValidateRoster(*args.Roster, al)
+	for _, violation := range violations {
+		fmt.Printf("pilot %d: pairing %d is ignored (%s)\n", violation.Pilot, violation.Pair, violation.Rule)
+	}
+	fmt.Println(len(violations), "assignments of the roster are ignored")
+	al.PilotsArray = roster
+
+	// the roster is the only solution of the run
+	_, cost := objective.Evaluate(roster, al)
+	cost *= objective.UnitCost
+	metric := new(metrics.Metrics)
+	metric.Initialization(1, objective.UnitCost)
+	metric.ValidSolutions = 1
+	metric.UniqueCount = 1
+	metric.GlobalBestSolutionCost = cost
+	metric.IterBestCost = []float64{cost}
+	metric.IterWorstCost = []float64{cost}
+	metric.IterAverageCost = []float64{cost}
+	SolutionMetrics(metric, al, seed, startOfExecution)
+	fmt.Printf("Cost %.0f, %d assigned pairings, %d uncovered pairings\n", cost, metric.TotalAssignedPairs, len(metric.Uncovered))
+
+	if SolutionChecker(al.PilotsArray) {
+		fmt.Println("Valid solution")
+	} else {
+		fmt.Println("Invalid solution")
+	}
+	results.PrintResults(metric, args, al, objective)
+}
+
+func Diff(args *input.ArgumentCollection, al *airline.Airline) {
+	// Compare the assignments of every pilot in two rosters over the same pairings
+	// and store the changes along with the schedule of the new roster
+	// the rosters are compared as they are stored, and the assignments
+	// that break the rules are listed separately
+	oldRoster, oldViolations := input.ReadRawRoster(*args.OldRoster, al)
+	newRoster, newViolations := input.ReadRawRoster(*args.NewRoster, al)
+	d := new(diff.Diff)
+	d.Initialization(*args.OldRoster, *args.NewRoster)
+	d.Compare(oldRoster, newRoster)
+	for _, violation := range oldViolations {
+		d.OldViolations = append(d.OldViolations, &diff.Violation{Pilot: violation.Pilot, Pairing: violation.Pair, Rule: violation.Rule})
+	}
+	for _, violation := range newViolations {
+		d.NewViolations = append(d.NewViolations, &diff.Violation{Pilot: violation.Pilot, Pairing: violation.Pair, Rule: violation.Rule})
+	}
+	al.PilotsArray = newRoster
+
+	// print the changes of each pilot
+	ids := func(pairings []*diff.Pairing) []int {
+		values := []int{}
+		for _, pairing := range pairings {
+			values = append(values, pairing.Id)
+		}
+		return values
+	}
+	for _, pilot := range d.Pilots {
+		fmt.Printf("Pilot %d:\n", pilot.Id)
+		if len(pilot.Added) > 0 {
+			fmt.Printf("\tadded pairings %v\n", ids(pilot.Added))
+		}
+		if len(pilot.Removed) > 0 {
+			fmt.Printf("\tremoved pairings %v\n", ids(pilot.Removed))
+		}
+		fmt.Printf("\tflight time %.0f -> %.0f minutes, days off %d -> %d\n", pilot.FlightTimeBefore, pilot.FlightTimeAfter,
+			pilot.DaysOffBefore, pilot.DaysOffAfter)
+	}
+	for _, move := range d.Moves {
+		fmt.Printf("Pairing %d moved from pilot %d to pilot %d\n", move.Pairing.Id, move.From, move.To)
+	}
+	for _, move := range d.Covered {
+		fmt.Printf("Pairing %d is covered by pilot %d\n", move.Pairing.Id, move.To)
+	}
+	for _, move := range d.Uncovered {
+		fmt.Printf("Pairing %d is no longer covered (was pilot %d)\n", move.Pairing.Id, move.From)
+	}
+	fmt.Printf("%d pilots changed, %d added and %d removed assignments, %d moved pairings, %d newly covered and %d newly uncovered pairings\n",
+		d.Summary.PilotsChanged, d.Summary.Added, d.Summary.Removed, d.Summary.Moved, d.Summary.NewlyCovered, d.Summary.NewlyUncovered)
+	for _, violation := range d.OldViolations {
+		fmt.Printf("old roster: pilot %d, pairing %d is invalid (%s)\n", violation.Pilot, violation.Pairing, violation.Rule)
+	}
+	for _, violation := range d.NewViolations {
+		fmt.Printf("new roster: pilot %d, pairing %d is invalid (%s)\n", violation.Pilot, violation.Pairing, violation.Rule)
+	}
+	results.PrintDiff(d, args, al)
+}
+
+func Tune(ctx context.Context, args *input.ArgumentCollection, objective *fitness.Objective, generator *randomness.Generator) {
+	// Race configurations of the parameters of multi-step CSO or AOA on the instances
+	// and store the best configuration along with the results of the races
+	instances := []*airline.Airline{}
+	for _, instance := range args.Instances {
+		instances = append(instances, input.ReadInstance(instance.Filename, instance.StartDate, instance.EndDate, instance.Pilots))
+	}
+	parameters := tuning.MultiCSOParameters
+	if *args.TuneType == "AOA" {
+		parameters = tuning.AOAParameters
+	}
+
+	// every run builds its own graph and stopping criteria, so the configurations
+	// of a block run concurrently (each run builds its solutions sequentially)
+	evaluate := func(ctx context.Context, configuration *tuning.Configuration, instance int, seed int64) float64 {
+		return SingleRun(ctx, args, instances[instance], *args.TuneType, configuration.Values, objective, seed).Cost
+	}
+	tuner := new(tuning.Tuner)
+	tuner.Initialization(parameters, len(instances), *args.Budget, *args.Candidates, *args.Workers, evaluate, generator)
+	best := tuner.Tune(ctx)
+	if best == nil {
+		fmt.Println("The budget is too small to race any configuration")
+		return
+	}
+
+	fmt.Printf("Best configuration: %s\n", tuner.Format(best))
+	fmt.Printf("\tmean cost %.0f, mean rank %.2f over %d blocks (%d runs)\n", best.MeanCost(), best.MeanRank, tuner.Blocks, tuner.Runs)
+	fmt.Printf("\tFriedman statistic %.3f, p-value %.4f\n", tuner.Statistic, tuner.PValue)
+	for _, configuration := range tuner.Elites[1:] {
+		fmt.Printf("\t%s: mean cost %.0f, p-value %.4f against the best\n", tuner.Format(configuration), configuration.MeanCost(), configuration.PValue)
+	}
+	results.PrintTuning(tuner, args)
+}
+
+func Experiment(ctx context.Context, args *input.ArgumentCollection, objective *fitness.Objective, generator *randomness.Generator) {
+	// Run multi-step CSO or AOA with the same seeds for every configuration of the
+	// parameter grid and store the summary of the measures of every configuration
+	al := AirlineSetup(args)
+	seed, _ := generator.State()
+	seeds := []int64{}
+	for i := 0; i < *args.Runs; i++ {
+		seeds = append(seeds, generator.Int63())
+	}
+	names := []string{"FL"}
+	if *args.Experiment == "AOA" {
+		names = []string{"C1", "C2", "C3", "C4"}
+	}
+	e := new(experiment.Experiment)
+	e.Initialization(*args.Experiment, names, args.Grid, seeds)
+	if len(e.Configurations) == 0 || len(seeds) == 0 {
+		log.Fatal("the experiment needs at least one run and one value of every parameter")
+	}
+	e.Filename, e.StartDate, e.EndDate, e.Pilots = *args.Filename, args.StartDate, args.EndDate, *args.Pilots
+	e.Generations, e.Agents, e.Seed = *args.Generations, *args.Agents, seed
+
+	// the runs are executed concurrently (each run builds its solutions sequentially)
+	workers.ForEach(*args.Workers, len(e.Configurations)*len(seeds), func(job int) {
+		configuration := e.Configurations[job/len(seeds)]
+		run := job % len(seeds)
+		configuration.Runs[run] = SingleRun(ctx, args, al, *args.Experiment, configuration.Values(), objective, seeds[run])
+	})
+	e.Aggregate()
+
+	for _, configuration := range e.Configurations {
+		fmt.Printf("%s: cost %.0f ± %.0f (best %.0f, worst %.0f), coverage %.2f%%, jumps %.1f, similarity %.2f%%, time %.1fs\n",
+			configuration.Name(), configuration.Cost.Mean, configuration.Cost.Std, configuration.Cost.Best, configuration.Cost.Worst,
+			configuration.Coverage.Mean, configuration.Jumps.Mean, configuration.Similarity.Mean, configuration.Time.Mean)
+	}
+	results.PrintExperiment(e, args)
+}
+
+func Compare(args *input.ArgumentCollection) {
+	// Compare every configuration of the experiments with the rest using the
+	// Wilcoxon rank-sum test and store the tests along with the convergence curves
+	c := new(comparison.Comparison)
+	c.Initialization(*args.Measure, *args.Alpha)
+	for _, fileName := range *args.Experiments {
+		e := input.ReadExperiment(fileName)
+		for _, configuration := range e.Configurations {
+			name := e.Algorithm + " " + configuration.Name()
+			for _, set := range c.Sets {
+				if set.Name == name {
+					// the same configuration was run by another experiment
+					name += " (" + filepath.Base(fileName) + ")"
+					break
+				}
+			}
+			c.Add(name, configuration)
+		}
+	}
+	if len(c.Sets) < 2 {
+		log.Fatal("the comparison needs at least two configurations")
+	}
+	c.Compare()
+
+	for _, set := range c.Sets {
+		fmt.Printf("%s: %s median %.2f, mean %.2f ± %.2f (%d runs)\n", set.Name, c.Measure, set.Median, set.Mean, set.Std, len(set.Values))
+	}
+	for _, test := range c.Tests {
+		verdict := "no significant difference"
+		if test.Significant {
+			verdict = test.Better.Name + " is better"
+		}
+		fmt.Printf("%s vs %s: U=%.1f, p-value %.4f, A12 %.3f, Cliff's delta %.3f (%s): %s\n",
+			test.A.Name, test.B.Name, test.U, test.PValue, test.A12, test.Delta, test.Magnitude, verdict)
+	}
+	results.PrintComparison(c, args)
+}
+
+func SingleRun(ctx context.Context, args *input.ArgumentCollection, al *airline.Airline, algorithm string, parameters []float64, objective *fitness.Objective, seed int64) *experiment.Run {
+	// Run multi-step CSO or AOA with "parameters" (FL, or C1 to C4) on its own graph
+	// and stopping criteria, so that several runs can share the airline concurrently
+	// returns the measures of the run
+	startOfRun := time.Now()
+	criteria := new(stopping.Criteria)
+	criteria.Initialization(time.Duration(*args.TimeLimit*float64(time.Minute)), *args.TargetCost, *args.Stagnation, *args.Coverage)
+	ctx, cancel := criteria.Context(ctx)
+	defer cancel()
+	pairGraph := GraphSetup(*args.Agents, al.PairsArray)
+	var solution []*airline.Pilot
+	var metric *metrics.Metrics
+	cost := 0.0
+	if algorithm == "AOA" {
+		collection := CollectionSetup(al, pairGraph, *args.Agents, *args.Generations, parameters, objective, 1, criteria, randomness.New(seed))
+		collection.AOA(ctx, al, pairGraph)
+		solution, cost, metric = collection.Collection[0].Solution, collection.Collection[0].Cost, collection.Mtr
+	} else {
+		swarm := SwarmSetup(al, pairGraph, *args.Agents, *args.Generations, parameters[0], objective, 1, criteria, randomness.New(seed))
+		swarm.MultiCSO(ctx, al, pairGraph)
+		solution, cost, metric = swarm.Swarm[0].Solution, swarm.Swarm[0].Cost, swarm.Mtr
+	}
+	pairsCovered := 0
+	for _, pilot := range solution {
+		pairsCovered += pilot.AssignedLength
+	}
+	return &experiment.Run{
+		Seed:           seed,
+		Cost:           cost,
+		Coverage:       100 * float64(pairsCovered) / float64(len(al.PairsArray)-1),
+		Jumps:          metric.Jumps,
+		Similarity:     metric.AverageSimilarity,
+		ValidSolutions: metric.ValidSolutions,
+		Time:           time.Since(startOfRun).Seconds(),
+		IterBestCost:   metric.IterBestCost,
+	}
+}
+
+func GraphSetup(agents int, pairsArray []*airline.Pair) *graph.Graph {
+	// Create and Initialize a graph with the pairings from the pairsArray
+	// returns pointer to the graph
+	pairGraph := new(graph.Graph)
+	pairGraph.Initialization(agents)
+	pairGraph.Populate(pairsArray)
+	return pairGraph
+}
+
+func SwarmSetup(al *airline.Airline, pairGraph *graph.Graph, agents int, maxGenerations int, fl float64, objective *fitness.Objective, workers int, criteria *stopping.Criteria, generator *randomness.Generator) *multicso.MultiCSO {
+	// Create and Initialize a chicken swarm
+	Mtr := new(metrics.Metrics)
+	Mtr.Initialization(maxGenerations*agents, objective.UnitCost)
+	Mtr.Seed, _ = generator.State() // seed of the run (stored in the checkpoints)
+	swarm := new(multicso.MultiCSO)
+	swarm.Initialization(al, pairGraph, agents, maxGenerations, fl, Mtr, objective, workers, criteria, generator)
+	return swarm
+}
+
+func CollectionSetup(al *airline.Airline, pairGraph *graph.Graph, agents int, maxGenerations int, constants []float64, objective *fitness.Objective, workers int, criteria *stopping.Criteria, generator *randomness.Generator) *archimedesOptimization.AOAObjectCollection {
+	//  Create and Initialize an object collection
+	Mtr := new(metrics.Metrics)
+	Mtr.Initialization(maxGenerations*agents, objective.UnitCost)
+	Mtr.Seed, _ = generator.State() // seed of the run (stored in the checkpoints)
+	collection := new(archimedesOptimization.AOAObjectCollection)
+	collection.Initialization(agents, maxGenerations, constants[0], constants[1], constants[2], constants[3], al, pairGraph, Mtr, objective, workers, criteria, generator)
+	return collection
+}
+
+func ColumnGenerationSetup(al *airline.Airline, maxIterations int, columns int, penalty float64, objective *fitness.Objective) *columnGeneration.ColumnGeneration {
+	// Create and Initialize the master and pricing problems of the column generation
+	Mtr := new(metrics.Metrics)
+	Mtr.Initialization(0, objective.UnitCost)
+	cg := new(columnGeneration.ColumnGeneration)
+	cg.Initialization(al, maxIterations, columns, penalty, Mtr, objective)
+	return cg
+}
+
+func NSGASetup(al *airline.Airline, pairGraph *graph.Graph, agents int, maxGenerations int, mutation float64, objective *fitness.Objective, workers int, criteria *stopping.Criteria, generator *randomness.Generator) *nsga.NSGA {
+	// Create and Initialize a population of individuals
+	Mtr := new(metrics.Metrics)
+	Mtr.Initialization(maxGenerations*agents, objective.UnitCost)
+	Mtr.Seed, _ = generator.State() // seed of the run (stored in the checkpoints)
+	population := new(nsga.NSGA)
+	population.Initialization(al, pairGraph, agents, maxGenerations, mutation, Mtr, objective, workers, criteria, generator)
+	return population
+}
+
+func IslandsSetup(args *input.ArgumentCollection, al *airline.Airline, roster []*airline.Pilot, objective *fitness.Objective, criteria *stopping.Criteria, generator *randomness.Generator) *island.IslandModel {
+	// Create an island model, where every island runs multi-step CSO or AOA
+	// on its own graph with its own random number generator
+	Mtr := new(metrics.Metrics)
+	Mtr.Initialization(0, objective.UnitCost)
+	Mtr.Seed, _ = generator.State()
+	model := new(island.IslandModel)
+	model.Initialization(*args.Generations, *args.Migration, Mtr, criteria)
+	for i := 0; i < *args.Islands; i++ {
+		pairGraph := GraphSetup(*args.Agents, al.PairsArray)
+		if roster != nil {
+			pairGraph.Seed(roster, *args.WarmWeight)
+		}
+		islandGenerator := randomness.New(generator.Int63())
+		if *args.IslandType == "multiCSO" || (*args.IslandType == "mixed" && i%2 == 0) {
+			swarm := SwarmSetup(al, pairGraph, *args.Agents, *args.Generations, *args.FL, objective, *args.Workers, criteria, islandGenerator)
+			if roster != nil {
+				// every island gets its own copy of the roster, since the islands change their solutions
+				swarm.WarmStart(al, airline.CopySolution(roster))
+			}
+			model.Add("multiCSO", swarm, pairGraph, swarm.Mtr)
+		} else {
+			collection := CollectionSetup(al, pairGraph, *args.Agents, *args.Generations, args.Constants, objective, *args.Workers, criteria, islandGenerator)
+			if roster != nil {
+				collection.WarmStart(al, airline.CopySolution(roster))
+			}
+			model.Add("AOA", collection, pairGraph, collection.Mtr)
+		}
+	}
+	return model
+}
+
+func SolutionChecker(solution []*airline.Pilot) bool {
+	// checks if the given solution obeys the rules
+	// returns true on success
+	for _, pilot := range solution {
+		for i := 2; i <= pilot.AssignedLength; i++ {
+			rest := pilot.AssignedPairs[i].Start.Sub(pilot.AssignedPairs[i-1].End).Minutes()
+			if rest < 660 {
+				fmt.Printf("pilot %d: pair %d(source) and pair %d(goal) are only %e minutes apart\n", pilot.Id, pilot.AssignedPairs[i].Id, pilot.AssignedPairs[i-1].Id, rest)
+				return false
+			}
+		}
+		if !pilot.DaysOffRuleChecker() {
+			fmt.Printf("pilot %d: not enough days off\n", pilot.Id)
+			return false
+		}
+	}
+	return true
+}
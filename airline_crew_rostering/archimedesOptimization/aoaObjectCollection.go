@@ -1,247 +1,364 @@
-package archimedesOptimization
-
-import (
-	"fmt"
-	"math"
-	"math/rand"
-	"sort"
-
-	"go-airline-crew-rostering/airline"
-	"go-airline-crew-rostering/fitness"
-	"go-airline-crew-rostering/graph"
-	"go-airline-crew-rostering/metrics"
-)
-
-// Container for the functions related to a collection of AOA objects
-type AOARepo interface {
-	Initialization() *AOAObjectCollection
-	AOA() []*AOAObject
-	solution() []*airline.Pilot
-	collectionUpdate()
-	sort() []*AOAObject
-}
-
-// Collection of AOA objects
-type AOAObjectCollection struct {
-	Collection     []*AOAObject     // List of the objects of the collection
-	population     int              // Number of objects in the collection
-	maxGenerations int              // Maximum number of iterations executed by the optimization algorithm
-	params         *aoaParameters   // all parameters of the algoritmh that are shared between the objects
-	Mtr            *metrics.Metrics // Metrics used to evaluate the algorithm's efficiency
-	costList       []float64        // List of solutions' cost found by all objects of the collection in the current generation
-}
-
-type aoaParameters struct {
-	// Parameters used in the update step that are the same for all objects
-	TF         float64
-	d          float64
-	C1         float64
-	C2         float64
-	C3         float64
-	C4         float64
-	F          int
-	T          float64
-	bestObject *AOAObject // Object with the best fitness found so far
-}
-
-func (collection *AOAObjectCollection) Initialization(population int, maxGenerations int,
-	C1 float64, C2 float64, C3 float64, C4 float64, al *airline.Airline, pairGraph *graph.Graph,
-	Mtr *metrics.Metrics) *AOAObjectCollection {
-	// Initialize an instance of a collection of AOA objects
-	collection.Collection = []*AOAObject{}
-	collection.population = population
-	collection.maxGenerations = maxGenerations
-	collection.params = &aoaParameters{
-		TF:         0,
-		d:          0,
-		C1:         C1,
-		C2:         C2,
-		C3:         C3,
-		C4:         C4,
-		F:          0,
-		T:          0,
-		bestObject: nil,
-	}
-	collection.costList = []float64{}
-	collection.Mtr = Mtr
-
-	// Create the objects and build the initial solutions for each one
-	for agent := 0; agent < population; agent++ {
-		object := new(AOAObject)
-		object.Initialization(agent)
-		collection.Collection = append(collection.Collection, object)
-		collection.solution(al, pairGraph, agent)
-	}
-	return collection
-}
-
-func (collection *AOAObjectCollection) solution(al *airline.Airline, pairGraph *graph.Graph, objectId int) {
-	// Build a new solution for the object with id "objectID"
-	object := collection.Collection[objectId]
-	validSolution := object.ConstructSolution(al, pairGraph)
-	if validSolution {
-		collection.Mtr.ValidSolutions++
-	}
-	// Calculate the new solution's fitness and cost
-	object.NewFitness, object.NewCost = fitness.FitnessFunction(object.ProposedSolution, len(al.PairsArray)-1, al.AverageWorkload)
-	object.NewCost = object.NewCost * collection.Mtr.UnitCost
-
-	collection.costList = append(collection.costList, object.NewCost)
-
-	// Adopt the new solution as the object's solution if the new solution is better
-	if object.NewFitness > object.Fitness {
-		object.Evaluate()
-		// Replace the best object if the new solution is the best found overall
-		if collection.params.bestObject == nil || collection.params.bestObject.Fitness < object.Fitness {
-			collection.params.bestObject = object
-		}
-	}
-}
-
-func (collection *AOAObjectCollection) collectionUpdate(pairGraph *graph.Graph, generation int) {
-	// Update all relevant edges of the graph for all objects of the collection
-
-	edgesToUpdate := make(map[int]*graph.Edge) // List of edges that will be updated
-
-	// Calculated shared parameters
-	collection.params.TF = math.Exp((float64(generation-collection.maxGenerations) / float64(collection.maxGenerations)))
-	collection.params.d = math.Exp((float64(collection.maxGenerations-generation) / float64(collection.maxGenerations))) - (float64(generation) / float64(collection.maxGenerations))
-	P := 2*rand.Float64() - collection.params.C4
-	if P <= 0.5 {
-		collection.params.F = 1
-	} else if P > 0.5 {
-		collection.params.F = -1
-	}
-	collection.params.T = collection.params.C3 * collection.params.TF
-
-	maxAccelerationObject := collection.Collection[0]
-	minAccelerationObject := collection.Collection[0]
-	// Calculate individual parameters for each object
-	for _, object := range collection.Collection {
-		object.SetUpdateParameters(collection.Collection, collection.params.bestObject.Id, collection.params.TF)
-		if object.accelleration > maxAccelerationObject.accelleration {
-			maxAccelerationObject = object
-		}
-		if object.accelleration < minAccelerationObject.accelleration {
-			minAccelerationObject = object
-		}
-	}
-	maxAcceleration := maxAccelerationObject.accelleration
-	minAcceleration := minAccelerationObject.accelleration
-
-	// normalize acceleration
-	for _, object := range collection.Collection {
-		object.accelleration = 0.9*(object.accelleration-minAcceleration)/(maxAcceleration-minAcceleration) + 0.1
-	}
-
-	// Gather all edges that need to be updated by scanning the
-	// current solution of each object
-	for _, object := range collection.Collection {
-		for _, pilot := range object.Solution {
-			for i := 0; i < pilot.AssignedLength; i++ {
-				sourcePairId := pilot.AssignedPairs[i].Id
-				goalPairId := pilot.AssignedPairs[i+1].Id
-				edge, edgeExists := pairGraph.Nodes[sourcePairId].Edges[goalPairId]
-				// Create a new edge if a pair of successive pairings has not be
-				// found in another solution so far
-				if !edgeExists {
-					pairGraph.AddEdge(sourcePairId, goalPairId)
-					edge = pairGraph.Nodes[sourcePairId].Edges[goalPairId]
-					for i := 0; i < collection.population; i++ {
-						edge.Position[i] = 0.95 + rand.Float64()*0.05
-					}
-				}
-				edgesToUpdate[edge.Id] = edge
-			}
-		}
-	}
-
-	// Update all relevant edges for all objects
-	for _, edge := range edgesToUpdate {
-		for _, object := range collection.Collection {
-			object.UpdatePosition(edge, collection.params)
-		}
-	}
-
-}
-
-func (collection *AOAObjectCollection) sort() {
-	// sort the list of AOA objects by their fitness, from best to worst
-	sort.Slice(collection.Collection, func(i int, j int) bool {
-		return collection.Collection[i].Fitness > collection.Collection[j].Fitness
-	})
-}
-
-func (collection *AOAObjectCollection) AOA(al *airline.Airline, pairGraph *graph.Graph) []*AOAObject {
-	// Main body of the optimization algorithm
-	// Returns the list of objects of the collection
-
-	// Calculate Metrics for the initialization step
-	globalbestobject, _ := collection.Mtr.SetUpIterationMetrics(collection.costList)
-	collection.Mtr.Jumps++
-	object := collection.Collection[globalbestobject]
-
-	collection.Mtr.GlobalBestSolutionCost = object.Cost
-	collection.Mtr.GlobalBestString = collection.Mtr.SolutionEncoding(object.CondensedSolution, object.Solution)
-	for _, object := range collection.Collection {
-		if object.Id == globalbestobject {
-			continue
-		}
-		normalisedSolution := collection.Mtr.SolutionEncoding(object.CondensedSolution, object.Solution)
-		collection.Mtr.AverageSimilarity += collection.Mtr.SolutionSimilarity(collection.Mtr.GlobalBestString, normalisedSolution)
-	}
-
-	// Execute the algorithm for iterations equal to "maxGenerations"
-	for t := 1; t < collection.maxGenerations; t++ {
-
-		// Update the positions of the collection
-		collection.collectionUpdate(pairGraph, t-1)
-
-		collection.costList = []float64{} // empty the cost list from the previous iteration
-
-		// build solutions for all objects
-		for _, object := range collection.Collection {
-			collection.solution(al, pairGraph, object.Id)
-		}
-
-		// Calculate the metrics of the current iteration
-		globalbestobject = collection.calculateMetrics(globalbestobject, t)
-
-	}
-	// Try to optimize the solutions of each object
-	for _, object := range collection.Collection {
-		al.EqualizeWorkload(object.Solution)
-		object.Fitness, object.Cost = fitness.FitnessFunction(object.Solution, len(al.PairsArray)-1, al.AverageWorkload)
-		object.Cost *= collection.Mtr.UnitCost
-	}
-	collection.Mtr.AverageSimilarity = collection.Mtr.AverageSimilarity / float64(collection.population*collection.maxGenerations)
-	collection.sort()
-	return collection.Collection
-}
-
-func (collection *AOAObjectCollection) calculateMetrics(globalBest int, generation int) int {
-	// Calculate metrics of current iteration and update all metrics
-	globalbestobject := globalBest
-	globalbestFitness := collection.Collection[globalbestobject].Fitness
-	bestObject, _ := collection.Mtr.SetUpIterationMetrics(collection.costList)
-	bestFitness := collection.Collection[bestObject].Fitness
-	bestCost := collection.costList[bestObject]
-	bestSolutionString := []string{}
-	for _, object := range collection.Collection {
-		normalisedSolution := collection.Mtr.SolutionEncoding(object.NewCondensedSolution, object.ProposedSolution)
-		collection.Mtr.AverageSimilarity += collection.Mtr.SolutionSimilarity(collection.Mtr.GlobalBestString, normalisedSolution)
-		if object.Id == bestObject {
-			bestSolutionString = normalisedSolution
-		}
-	}
-	if bestFitness > globalbestFitness {
-		globalbestobject = bestObject
-		collection.Mtr.GlobalBestSolutionCost = bestCost
-		collection.Mtr.GlobalBestString = bestSolutionString
-		collection.Mtr.Jumps++
-	}
-	if generation%200 == 0 {
-		fmt.Println("Generation", generation)
-	}
-	return globalbestobject
-}
+package archimedesOptimization
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"go-airline-crew-rostering/airline"
+	"go-airline-crew-rostering/checkpoint"
+	"go-airline-crew-rostering/fitness"
+	"go-airline-crew-rostering/graph"
+	"go-airline-crew-rostering/metrics"
+	"go-airline-crew-rostering/problem"
+	"go-airline-crew-rostering/randomness"
+	"go-airline-crew-rostering/stopping"
+	"go-airline-crew-rostering/workers"
+)
+
+// Container for the functions related to a collection of AOA objects
+type AOARepo interface {
+	Initialization() *AOAObjectCollection
+	AOA() []*AOAObject
+	Begin()
+	Generation()
+	Finish()
+	Best() ([]*airline.Pilot, float64)
+	Immigrate()
+	solutions()
+	WarmStart()
+	collectionUpdate()
+	sort() []*AOAObject
+}
+
+// Collection of AOA objects
+type AOAObjectCollection struct {
+	Collection     []*AOAObject          // List of the objects of the collection
+	population     int                   // Number of objects in the collection
+	maxGenerations int                   // Maximum number of iterations executed by the optimization algorithm
+	params         *aoaParameters        // all parameters of the algoritmh that are shared between the objects
+	Mtr            *metrics.Metrics      // Metrics used to evaluate the algorithm's efficiency
+	objective      *fitness.Objective    // Objective used to calculate the fitness and the cost of the solutions
+	costList       []float64             // List of solutions' cost found by all objects of the collection in the current generation
+	workers        int                   // Maximum number of solutions built concurrently
+	criteria       *stopping.Criteria    // Criteria used to stop the algorithm before "maxGenerations"
+	checkpoints    *checkpoint.Options   // Options of the checkpoints saved during the execution
+	start          int                   // first generation executed (bigger than 1 if the run continues from a checkpoint)
+	globalBest     int                   // id of the object with the best solution found so far
+	generator      *randomness.Generator // random number generator used for the parameters shared by all objects
+	pairs          int                   // number of pairings of the schedule
+}
+
+type aoaParameters struct {
+	// Parameters used in the update step that are the same for all objects
+	TF         float64
+	d          float64
+	C1         float64
+	C2         float64
+	C3         float64
+	C4         float64
+	F          int
+	T          float64
+	bestObject *AOAObject // Object with the best fitness found so far
+}
+
+func (collection *AOAObjectCollection) Initialization(population int, maxGenerations int,
+	C1 float64, C2 float64, C3 float64, C4 float64, al *airline.Airline, pairGraph *graph.Graph,
+	Mtr *metrics.Metrics, objective *fitness.Objective, workers int, criteria *stopping.Criteria, generator *randomness.Generator) *AOAObjectCollection {
+	// Initialize an instance of a collection of AOA objects
+	collection.Collection = []*AOAObject{}
+	collection.population = population
+	collection.maxGenerations = maxGenerations
+	collection.params = &aoaParameters{
+		TF:         0,
+		d:          0,
+		C1:         C1,
+		C2:         C2,
+		C3:         C3,
+		C4:         C4,
+		F:          0,
+		T:          0,
+		bestObject: nil,
+	}
+	collection.costList = []float64{}
+	collection.Mtr = Mtr
+	collection.objective = objective
+	collection.workers = workers
+	collection.criteria = criteria
+	collection.checkpoints = nil
+	collection.start = 1
+	collection.globalBest = 0
+	collection.generator = generator
+	collection.pairs = len(al.PairsArray) - 1
+
+	// Create the objects and build the initial solutions for each one
+	// (every object gets its own random number generator, seeded by "generator")
+	for agent := 0; agent < population; agent++ {
+		object := new(AOAObject)
+		object.Initialization(agent, randomness.New(generator.Int63()))
+		collection.Collection = append(collection.Collection, object)
+	}
+	collection.solutions(al, pairGraph)
+	return collection
+}
+
+func (collection *AOAObjectCollection) solutions(al *airline.Airline, pairGraph *graph.Graph) {
+	// Build a new solution for every object of the collection
+	// The solutions are built concurrently, each one with the object's random generator,
+	// while the metrics are updated in the order of the objects, so the results
+	// do not depend on the number of workers
+	validSolutions := make([]bool, len(collection.Collection))
+	workers.ForEach(collection.workers, len(collection.Collection), func(i int) {
+		object := collection.Collection[i]
+		validSolutions[i] = object.ConstructSolution(al, pairGraph)
+		// Calculate the new solution's fitness and cost
+		object.NewFitness, object.NewCost = collection.objective.Evaluate(object.ProposedSolution, al)
+		object.NewCost = object.NewCost * collection.Mtr.UnitCost
+	})
+
+	for i, object := range collection.Collection {
+		if validSolutions[i] {
+			collection.Mtr.ValidSolutions++
+		}
+		collection.costList = append(collection.costList, object.NewCost)
+
+		// Adopt the new solution as the object's solution if the new solution is better
+		if object.NewFitness > object.Fitness {
+			object.Evaluate()
+			// Replace the best object if the new solution is the best found overall
+			if collection.params.bestObject == nil || collection.params.bestObject.Fitness < object.Fitness {
+				collection.params.bestObject = object
+			}
+		}
+	}
+}
+
+func (collection *AOAObjectCollection) WarmStart(al *airline.Airline, solution []*airline.Pilot) {
+	// Replace the solution of the first object with "solution"
+	// (used to start the search from a known roster)
+	object := collection.Collection[0]
+	object.ProposedSolution = solution
+	object.NewCondensedSolution = problem.CondensedSolution(al, solution)
+	object.NewFitness, object.NewCost = collection.objective.Evaluate(solution, al)
+	object.NewCost = object.NewCost * collection.Mtr.UnitCost
+	object.Evaluate()
+	collection.costList[0] = object.Cost
+	if collection.params.bestObject == nil || collection.params.bestObject.Fitness < object.Fitness {
+		collection.params.bestObject = object
+	}
+}
+
+func (collection *AOAObjectCollection) collectionUpdate(pairGraph *graph.Graph, generation int) {
+	// Update all relevant edges of the graph for all objects of the collection
+
+	edgesToUpdate := make(map[int]*graph.Edge) // List of edges that will be updated
+
+	// Calculated shared parameters
+	collection.params.TF = math.Exp((float64(generation-collection.maxGenerations) / float64(collection.maxGenerations)))
+	collection.params.d = math.Exp((float64(collection.maxGenerations-generation) / float64(collection.maxGenerations))) - (float64(generation) / float64(collection.maxGenerations))
+	P := 2*collection.generator.Float64() - collection.params.C4
+	if P <= 0.5 {
+		collection.params.F = 1
+	} else if P > 0.5 {
+		collection.params.F = -1
+	}
+	collection.params.T = collection.params.C3 * collection.params.TF
+
+	maxAccelerationObject := collection.Collection[0]
+	minAccelerationObject := collection.Collection[0]
+	// Calculate individual parameters for each object
+	for _, object := range collection.Collection {
+		object.SetUpdateParameters(collection.Collection, collection.params.bestObject.Id, collection.params.TF)
+		if object.accelleration > maxAccelerationObject.accelleration {
+			maxAccelerationObject = object
+		}
+		if object.accelleration < minAccelerationObject.accelleration {
+			minAccelerationObject = object
+		}
+	}
+	maxAcceleration := maxAccelerationObject.accelleration
+	minAcceleration := minAccelerationObject.accelleration
+
+	// normalize acceleration
+	for _, object := range collection.Collection {
+		object.accelleration = 0.9*(object.accelleration-minAcceleration)/(maxAcceleration-minAcceleration) + 0.1
+	}
+
+	// Gather all edges that need to be updated by scanning the
+	// current solution of each object
+	for _, object := range collection.Collection {
+		for _, pilot := range object.Solution {
+			for i := 0; i < pilot.AssignedLength; i++ {
+				sourcePairId := pilot.AssignedPairs[i].Id
+				goalPairId := pilot.AssignedPairs[i+1].Id
+				edge, edgeExists := pairGraph.Nodes[sourcePairId].Edges[goalPairId]
+				// Create a new edge if a pair of successive pairings has not be
+				// found in another solution so far
+				if !edgeExists {
+					pairGraph.AddEdge(sourcePairId, goalPairId)
+					edge = pairGraph.Nodes[sourcePairId].Edges[goalPairId]
+					for i := 0; i < collection.population; i++ {
+						edge.Position[i] = 0.95 + collection.generator.Float64()*0.05
+					}
+				}
+				edgesToUpdate[edge.Id] = edge
+			}
+		}
+	}
+
+	// Update all relevant edges for all objects
+	for _, edge := range graph.SortedEdges(edgesToUpdate) {
+		for _, object := range collection.Collection {
+			object.UpdatePosition(edge, collection.params)
+		}
+	}
+
+}
+
+func (collection *AOAObjectCollection) sort() {
+	// sort the list of AOA objects by their fitness, from best to worst
+	sort.Slice(collection.Collection, func(i int, j int) bool {
+		return collection.Collection[i].Fitness > collection.Collection[j].Fitness
+	})
+}
+
+func (collection *AOAObjectCollection) AOA(ctx context.Context, al *airline.Airline, pairGraph *graph.Graph) []*AOAObject {
+	// Main body of the optimization algorithm
+	// Returns the list of objects of the collection
+
+	// Calculate Metrics for the initialization step
+	// (unless the run continues from a checkpoint)
+	reason := ""
+	if collection.start == 1 {
+		collection.Begin()
+		reason = collection.criteria.Stop(ctx, collection.Mtr.GlobalBestSolutionCost, collection.Collection[collection.globalBest].Solution, al)
+	}
+
+	// Execute the algorithm for iterations equal to "maxGenerations"
+	// or until one of the stopping criteria is met
+	generations := collection.start
+	for t := collection.start; t < collection.maxGenerations && reason == ""; t++ {
+		generations++
+		collection.Generation(al, pairGraph, t)
+
+		reason = collection.criteria.Stop(ctx, collection.Mtr.GlobalBestSolutionCost, collection.Collection[collection.globalBest].Solution, al)
+		if collection.checkpoints.Due(t, reason) {
+			collection.checkpoints.Save(collection.checkpoint(t, collection.globalBest, pairGraph))
+		}
+	}
+	if reason == "" {
+		reason = stopping.MaxGenerations
+	}
+	collection.Finish(al, reason, generations)
+	return collection.Collection
+}
+
+func (collection *AOAObjectCollection) Begin() {
+	// Calculate the metrics of the initial solutions
+	globalbestobject, _ := collection.Mtr.SetUpIterationMetrics(collection.costList)
+	collection.Mtr.Jumps++
+	object := collection.Collection[globalbestobject]
+
+	collection.Mtr.GlobalBestSolutionCost = object.Cost
+	collection.Mtr.GlobalBestString = collection.Mtr.SolutionEncoding(object.CondensedSolution, object.Solution)
+	similarities := []float64{}
+	for _, object := range collection.Collection {
+		if object.Id == globalbestobject {
+			continue
+		}
+		normalisedSolution := collection.Mtr.SolutionEncoding(object.CondensedSolution, object.Solution)
+		similarities = append(similarities, collection.Mtr.SolutionSimilarity(collection.Mtr.GlobalBestString, normalisedSolution))
+	}
+	collection.Mtr.SetUpDiversityMetrics(similarities, collection.pairs-len(object.CondensedSolution))
+	collection.globalBest = globalbestobject
+}
+
+func (collection *AOAObjectCollection) Generation(al *airline.Airline, pairGraph *graph.Graph, generation int) {
+	// Execute one generation of the algorithm
+
+	// Update the positions of the collection
+	collection.collectionUpdate(pairGraph, generation-1)
+
+	collection.costList = []float64{} // empty the cost list from the previous iteration
+
+	// build solutions for all objects
+	collection.solutions(al, pairGraph)
+
+	// Calculate the metrics of the current iteration
+	collection.globalBest = collection.calculateMetrics(collection.globalBest, generation)
+}
+
+func (collection *AOAObjectCollection) Finish(al *airline.Airline, reason string, generations int) {
+	// Optimize the solutions of the objects, complete the metrics after
+	// "generations" generations and sort the objects from best to worst
+	collection.Mtr.StopReason = reason
+	// Try to optimize the solutions of each object
+	for _, object := range collection.Collection {
+		al.EqualizeWorkload(object.Solution)
+		object.Fitness, object.Cost = collection.objective.Evaluate(object.Solution, al)
+		object.Cost *= collection.Mtr.UnitCost
+	}
+	collection.Mtr.Generations = generations
+	collection.Mtr.TotalSolutions = collection.population * generations
+	collection.Mtr.AverageSimilarity = collection.Mtr.AverageSimilarity / float64(collection.population*generations)
+	collection.sort()
+	collection.globalBest = 0
+}
+
+func (collection *AOAObjectCollection) Best() ([]*airline.Pilot, float64) {
+	// returns the best solution of the collection and its fitness
+	object := collection.Collection[collection.globalBest]
+	return object.Solution, object.Fitness
+}
+
+func (collection *AOAObjectCollection) Immigrate(al *airline.Airline, solution []*airline.Pilot) {
+	// Replace the solution of the worst object with "solution"
+	// (a solution found by another collection of the island model)
+	worst := collection.Collection[0]
+	for _, object := range collection.Collection {
+		if object.Fitness < worst.Fitness {
+			worst = object
+		}
+	}
+	if worst.Id == collection.globalBest {
+		return
+	}
+	worst.ProposedSolution = solution
+	worst.NewCondensedSolution = problem.CondensedSolution(al, solution)
+	worst.NewFitness, worst.NewCost = collection.objective.Evaluate(solution, al)
+	worst.NewCost = worst.NewCost * collection.Mtr.UnitCost
+	worst.Evaluate()
+	if collection.params.bestObject == nil || collection.params.bestObject.Fitness < worst.Fitness {
+		collection.params.bestObject = worst
+	}
+}
+
+func (collection *AOAObjectCollection) calculateMetrics(globalBest int, generation int) int {
+	// Calculate metrics of current iteration and update all metrics
+	globalbestobject := globalBest
+	globalbestFitness := collection.Collection[globalbestobject].Fitness
+	bestObject, _ := collection.Mtr.SetUpIterationMetrics(collection.costList)
+	bestFitness := collection.Collection[bestObject].Fitness
+	bestCost := collection.costList[bestObject]
+	bestSolutionString := []string{}
+	similarities := []float64{}
+	for _, object := range collection.Collection {
+		normalisedSolution := collection.Mtr.SolutionEncoding(object.NewCondensedSolution, object.ProposedSolution)
+		similarities = append(similarities, collection.Mtr.SolutionSimilarity(collection.Mtr.GlobalBestString, normalisedSolution))
+		if object.Id == bestObject {
+			bestSolutionString = normalisedSolution
+		}
+	}
+	collection.Mtr.SetUpDiversityMetrics(similarities, collection.pairs-len(collection.Collection[bestObject].NewCondensedSolution))
+	if bestFitness > globalbestFitness {
+		globalbestobject = bestObject
+		collection.Mtr.GlobalBestSolutionCost = bestCost
+		collection.Mtr.GlobalBestString = bestSolutionString
+		collection.Mtr.Jumps++
+	}
+	if generation%200 == 0 {
+		fmt.Println("Generation", generation)
+	}
+	return globalbestobject
+}
@@ -0,0 +1,64 @@
+package archimedesOptimization
+
+import (
+	"go-airline-crew-rostering/airline"
+	"go-airline-crew-rostering/checkpoint"
+	"go-airline-crew-rostering/graph"
+)
+
+func (collection *AOAObjectCollection) SetCheckpoints(options *checkpoint.Options) {
+	// Save checkpoints during the execution based on "options"
+	collection.checkpoints = options
+}
+
+func (collection *AOAObjectCollection) checkpoint(generation int, globalBest int, pairGraph *graph.Graph) *checkpoint.Checkpoint {
+	// returns the state of the collection after "generation"
+	// (the generator of the collection is stored after the generators of the objects)
+	state := &checkpoint.Checkpoint{Algorithm: "AOA", Seed: collection.Mtr.Seed, Generation: generation, GlobalBest: globalBest}
+	for _, object := range collection.Collection {
+		state.Agents = append(state.Agents, &checkpoint.Agent{
+			Fitness:           object.Fitness,
+			Cost:              object.Cost,
+			Solution:          checkpoint.EncodeSolution(object.Solution),
+			CondensedSolution: object.CondensedSolution,
+			Density:           object.density,
+			Volume:            object.volume,
+			Acceleration:      object.accelleration,
+		})
+		state.Generators = append(state.Generators, checkpoint.EncodeGenerator(object.generator))
+	}
+	state.Generators = append(state.Generators, checkpoint.EncodeGenerator(collection.generator))
+	state.BestObject = -1
+	if collection.params.bestObject != nil {
+		state.BestObject = collection.params.bestObject.Id
+	}
+	state.SetGraph(pairGraph)
+	state.SetMetrics(collection.Mtr)
+	state.SetCriteria(collection.criteria)
+	return state
+}
+
+func (collection *AOAObjectCollection) Resume(state *checkpoint.Checkpoint, al *airline.Airline, pairGraph *graph.Graph) {
+	// Restore the state of the collection, so that the execution continues
+	// after the generation of the checkpoint
+	state.Check("AOA", collection.population)
+	for i, object := range collection.Collection {
+		agent := state.Agents[i]
+		object.Fitness, object.Cost = agent.Fitness, agent.Cost
+		object.Solution = checkpoint.DecodeSolution(agent.Solution, al)
+		object.CondensedSolution = agent.CondensedSolution
+		object.density, object.volume, object.accelleration = agent.Density, agent.Volume, agent.Acceleration
+		object.generator.Restore(state.Generators[i].Seed, state.Generators[i].Draws)
+	}
+	last := state.Generators[len(state.Generators)-1]
+	collection.generator.Restore(last.Seed, last.Draws)
+	collection.params.bestObject = nil
+	if state.BestObject >= 0 {
+		collection.params.bestObject = collection.Collection[state.BestObject]
+	}
+	state.RestoreGraph(pairGraph, al.PairsArray)
+	state.RestoreMetrics(collection.Mtr)
+	state.RestoreCriteria(collection.criteria)
+	collection.start = state.Generation + 1
+	collection.globalBest = state.GlobalBest
+}
@@ -0,0 +1,221 @@
+package checkpoint
+
+import (
+	"compress/gzip"
+	"encoding/gob"
+	"fmt"
+	"log"
+	"os"
+
+	"go-airline-crew-rostering/airline"
+	"go-airline-crew-rostering/graph"
+	"go-airline-crew-rostering/metrics"
+	"go-airline-crew-rostering/randomness"
+	"go-airline-crew-rostering/stopping"
+)
+
+// Container for the functions related to checkpoints
+type CheckpointRepo interface {
+	Due() bool
+	Save()
+	SetGraph()
+	RestoreGraph()
+	SetMetrics()
+	RestoreMetrics()
+	SetCriteria()
+	RestoreCriteria()
+}
+
+// State of an optimization algorithm after a generation,
+// from which the algorithm can continue as if it was never stopped
+type Checkpoint struct {
+	Algorithm       string           // name of the optimization algorithm
+	Seed            int64            // seed of the run
+	Generation      int              // last generation executed
+	GlobalBest      int              // id of the agent with the best solution found so far
+	Best            *Agent           // best solution found so far (NSGA-II only)
+	BestObject      int              // id of the object with the best fitness (AOA only, -1 if there is none)
+	Agents          []*Agent         // state of each agent
+	Generators      []*Generator     // state of each random number generator of the algorithm
+	Edges           []*graph.Edge    // edges of the graph with the agents' positions
+	Metrics         *metrics.Metrics // metrics gathered so far
+	UniqueSolutions []string         // different solutions found so far
+	BestCost        float64          // best cost seen by the stopping criteria
+	Stagnation      int              // generations without improvement seen by the stopping criteria
+}
+
+// State of an agent of an optimization algorithm
+type Agent struct {
+	Fitness           float64
+	Cost              float64
+	Objectives        []float64
+	Solution          [][]int // ids of the pairings assigned to each pilot
+	CondensedSolution []int
+	Density           float64 // AOA only
+	Volume            float64 // AOA only
+	Acceleration      float64 // AOA only
+	Rank              int     // NSGA-II only
+	Crowding          float64 // NSGA-II only
+}
+
+// State of a random number generator
+type Generator struct {
+	Seed  int64
+	Draws uint64
+}
+
+// Options of the checkpoints of a run
+type Options struct {
+	Filename string // name of the checkpoint file
+	Every    int    // number of generations between two checkpoints
+	Err      error  // error of the last checkpoint (nil if it was saved)
+}
+
+func (options *Options) Due(generation int, reason string) bool {
+	// returns true if a checkpoint must be saved after "generation",
+	// either periodically or because the run was interrupted
+	if options == nil || options.Filename == "" {
+		return false
+	}
+	if reason == stopping.TimeLimit || reason == stopping.Cancelled {
+		return true
+	}
+	return options.Every > 0 && generation%options.Every == 0
+}
+
+func (options *Options) Save(checkpoint *Checkpoint) {
+	// Write the checkpoint to the checkpoint file
+	// (a temporary file is used so that an interruption never leaves a broken checkpoint)
+	// a failure is logged and kept in "Err", so that the run can report it when it ends
+	if err := options.write(checkpoint); err != nil {
+		options.Err = fmt.Errorf("checkpoint of generation %d was not saved to %s: %w", checkpoint.Generation, options.Filename, err)
+		log.Println(options.Err)
+	} else {
+		options.Err = nil
+	}
+}
+
+func (options *Options) write(checkpoint *Checkpoint) error {
+	// Write the checkpoint to a temporary file and replace the checkpoint file with it
+	temporaryName := options.Filename + ".tmp"
+	file, err := os.Create(temporaryName)
+	if err != nil {
+		return err
+	}
+	writer := gzip.NewWriter(file)
+	err = gob.NewEncoder(writer).Encode(checkpoint)
+	if err == nil {
+		err = writer.Close()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(temporaryName)
+		return err
+	}
+	return os.Rename(temporaryName, options.Filename)
+}
+
+func Load(filename string) *Checkpoint {
+	// Read a checkpoint from the file "filename"
+	file, err := os.Open(filename)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		log.Fatal("invalid checkpoint file ", filename, ": ", err)
+	}
+	checkpoint := new(Checkpoint)
+	if err = gob.NewDecoder(reader).Decode(checkpoint); err != nil {
+		log.Fatal("invalid checkpoint file ", filename, ": ", err)
+	}
+	return checkpoint
+}
+
+func (checkpoint *Checkpoint) Check(algorithm string, agents int) {
+	// Stop the application if the checkpoint does not belong to a run
+	// of "algorithm" with "agents" agents
+	if checkpoint.Algorithm != algorithm {
+		log.Fatal("the checkpoint was created by ", checkpoint.Algorithm, ", not ", algorithm)
+	}
+	if len(checkpoint.Agents) != agents {
+		log.Fatal("the checkpoint has ", len(checkpoint.Agents), " agents, not ", agents)
+	}
+}
+
+func EncodeSolution(solution []*airline.Pilot) [][]int {
+	// returns the ids of the pairings assigned to each pilot of the solution
+	encoded := [][]int{}
+	for _, pilot := range solution {
+		pairs := []int{}
+		for _, pair := range pilot.AssignedPairs[1 : pilot.AssignedLength+1] {
+			pairs = append(pairs, pair.Id)
+		}
+		encoded = append(encoded, pairs)
+	}
+	return encoded
+}
+
+func DecodeSolution(encoded [][]int, al *airline.Airline) []*airline.Pilot {
+	// Build the solution described by the ids of the pairings assigned to each pilot
+	pairs := make(map[int]*airline.Pair)
+	for _, pair := range al.PairsArray {
+		pairs[pair.Id] = pair
+	}
+	solution := []*airline.Pilot{}
+	for id, pairIds := range encoded {
+		pilot := new(airline.Pilot)
+		pilot.Initialization(id, al.ScheduleDuration, al.PairsArray[0])
+		for _, pairId := range pairIds {
+			pilot.Add(pairs[pairId], pilot.AssignedLength+1)
+		}
+		solution = append(solution, pilot)
+	}
+	return solution
+}
+
+func EncodeGenerator(generator *randomness.Generator) *Generator {
+	// returns the state of a random number generator
+	seed, draws := generator.State()
+	return &Generator{Seed: seed, Draws: draws}
+}
+
+func (checkpoint *Checkpoint) SetGraph(pairGraph *graph.Graph) {
+	// Store the edges of the graph
+	checkpoint.Edges = pairGraph.Edges
+}
+
+func (checkpoint *Checkpoint) RestoreGraph(pairGraph *graph.Graph, pairsArray []*airline.Pair) {
+	// Replace the edges of the graph with the stored edges
+	pairGraph.Initialization(pairGraph.Agents)
+	pairGraph.Populate(pairsArray)
+	for _, edge := range checkpoint.Edges {
+		pairGraph.AddEdge(edge.SourceId, edge.GoalId)
+		copy(pairGraph.Edges[edge.Id].Position, edge.Position)
+	}
+}
+
+func (checkpoint *Checkpoint) SetMetrics(Mtr *metrics.Metrics) {
+	// Store the metrics gathered so far
+	checkpoint.Metrics = Mtr
+	checkpoint.UniqueSolutions = Mtr.UniqueSolutions()
+}
+
+func (checkpoint *Checkpoint) RestoreMetrics(Mtr *metrics.Metrics) {
+	// Replace the metrics with the stored metrics
+	*Mtr = *checkpoint.Metrics
+	Mtr.RestoreUniqueSolutions(checkpoint.UniqueSolutions)
+}
+
+func (checkpoint *Checkpoint) SetCriteria(criteria *stopping.Criteria) {
+	// Store the state of the stopping criteria
+	checkpoint.BestCost, checkpoint.Stagnation = criteria.State()
+}
+
+func (checkpoint *Checkpoint) RestoreCriteria(criteria *stopping.Criteria) {
+	// Restore the state of the stopping criteria
+	criteria.Restore(checkpoint.BestCost, checkpoint.Stagnation)
+}
@@ -0,0 +1,157 @@
+package checkpoint_test
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go-airline-crew-rostering/airline"
+	"go-airline-crew-rostering/checkpoint"
+	"go-airline-crew-rostering/graph"
+	"go-airline-crew-rostering/input"
+	"go-airline-crew-rostering/metrics"
+	"go-airline-crew-rostering/problem"
+	"go-airline-crew-rostering/randomness"
+	"go-airline-crew-rostering/stopping"
+
+	"golang.org/x/exp/slices"
+)
+
+func instance() *airline.Airline {
+	// returns an airline with two weeks of pairings and 45 pilots
+	return input.ReadInstance("../Pairings.csv", time.Date(2011, 11, 1, 0, 0, 0, 0, time.UTC), time.Date(2011, 11, 15, 0, 0, 0, 0, time.UTC), 45)
+}
+
+func TestDue(t *testing.T) {
+	tests := []struct {
+		name       string
+		options    *checkpoint.Options
+		generation int
+		reason     string
+		due        bool
+	}{
+		{"no options", nil, 10, "", false},
+		{"no file", &checkpoint.Options{Every: 5}, 10, "", false},
+		{"periodic", &checkpoint.Options{Filename: "run.gz", Every: 5}, 10, "", true},
+		{"between checkpoints", &checkpoint.Options{Filename: "run.gz", Every: 5}, 11, "", false},
+		{"only on interruption", &checkpoint.Options{Filename: "run.gz"}, 10, "", false},
+		{"time limit", &checkpoint.Options{Filename: "run.gz", Every: 5}, 11, stopping.TimeLimit, true},
+		{"cancelled", &checkpoint.Options{Filename: "run.gz"}, 11, stopping.Cancelled, true},
+		{"target cost", &checkpoint.Options{Filename: "run.gz", Every: 5}, 11, stopping.TargetCost, false},
+	}
+	for _, test := range tests {
+		if due := test.options.Due(test.generation, test.reason); due != test.due {
+			t.Errorf("%s: Due(%d, %q) = %v, expected %v", test.name, test.generation, test.reason, due, test.due)
+		}
+	}
+}
+
+func TestSolution(t *testing.T) {
+	// a decoded solution has the assignments, the flight time and the days off of the encoded one
+	al := instance()
+	pairGraph := new(graph.Graph)
+	pairGraph.Initialization(1)
+	pairGraph.Populate(al.PairsArray)
+	for seed := int64(1); seed <= 3; seed++ {
+		solution, _, _ := problem.ConstructSolution(al, pairGraph, 0, rand.New(rand.NewSource(seed)))
+		decoded := checkpoint.DecodeSolution(checkpoint.EncodeSolution(solution), al)
+		if len(decoded) != len(solution) {
+			t.Fatalf("seed %d: %d pilots, expected %d", seed, len(decoded), len(solution))
+		}
+		for i, pilot := range decoded {
+			expected := solution[i]
+			if pilot.Id != expected.Id || pilot.AssignedLength != expected.AssignedLength ||
+				pilot.FlightTime != expected.FlightTime || pilot.DaysOff() != expected.DaysOff() {
+				t.Errorf("seed %d: pilot %d has %d pairings, flight time %v and %d days off, expected %d, %v and %d", seed, i,
+					pilot.AssignedLength, pilot.FlightTime, pilot.DaysOff(), expected.AssignedLength, expected.FlightTime, expected.DaysOff())
+				continue
+			}
+			for j := 1; j <= pilot.AssignedLength; j++ {
+				if pilot.AssignedPairs[j] != expected.AssignedPairs[j] {
+					t.Errorf("seed %d: pairing %d of pilot %d is %d, expected %d", seed, j, i, pilot.AssignedPairs[j].Id, expected.AssignedPairs[j].Id)
+				}
+			}
+		}
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	// a saved checkpoint is loaded with the state of the run
+	al := instance()
+	pairGraph := new(graph.Graph)
+	pairGraph.Initialization(2)
+	pairGraph.Populate(al.PairsArray)
+	pairGraph.AddEdge(al.PairsArray[1].Id, al.PairsArray[2].Id)
+	pairGraph.Edges[0].Position[1] = 2.5
+	generator := randomness.New(42)
+	generator.Intn(10)
+	Mtr := new(metrics.Metrics)
+	Mtr.Initialization(10, 32)
+	Mtr.IterBestCost = []float64{3, 2, 1}
+	criteria := new(stopping.Criteria)
+	criteria.Initialization(0, 0, 5, false)
+	criteria.Restore(1, 2)
+
+	state := &checkpoint.Checkpoint{Algorithm: "multiCSO", Seed: 42, Generation: 3, GlobalBest: 1}
+	state.Agents = []*checkpoint.Agent{{Cost: 1, Solution: [][]int{{al.PairsArray[1].Id}, {}}}, {Cost: 2, Solution: [][]int{{}, {}}}}
+	state.Generators = []*checkpoint.Generator{checkpoint.EncodeGenerator(generator)}
+	state.SetGraph(pairGraph)
+	state.SetMetrics(Mtr)
+	state.SetCriteria(criteria)
+	options := &checkpoint.Options{Filename: filepath.Join(t.TempDir(), "run.gz"), Every: 1}
+	options.Save(state)
+
+	loaded := checkpoint.Load(options.Filename)
+	if loaded.Algorithm != "multiCSO" || loaded.Seed != 42 || loaded.Generation != 3 || loaded.GlobalBest != 1 || len(loaded.Agents) != 2 {
+		t.Fatalf("loaded %s, seed %d, generation %d, global best %d, %d agents",
+			loaded.Algorithm, loaded.Seed, loaded.Generation, loaded.GlobalBest, len(loaded.Agents))
+	}
+	if !slices.Equal(loaded.Agents[0].Solution[0], []int{al.PairsArray[1].Id}) {
+		t.Errorf("solution of agent 0 = %v", loaded.Agents[0].Solution)
+	}
+	if *loaded.Generators[0] != *state.Generators[0] {
+		t.Errorf("generator = %v, expected %v", *loaded.Generators[0], *state.Generators[0])
+	}
+
+	restoredGraph := new(graph.Graph)
+	restoredGraph.Initialization(2)
+	loaded.RestoreGraph(restoredGraph, al.PairsArray)
+	edge, exists := restoredGraph.Nodes[al.PairsArray[1].Id].Edges[al.PairsArray[2].Id]
+	if !exists || edge.Position[1] != 2.5 {
+		t.Errorf("restored edge = %v", edge)
+	}
+	restoredMetrics := new(metrics.Metrics)
+	loaded.RestoreMetrics(restoredMetrics)
+	if !slices.Equal(restoredMetrics.IterBestCost, Mtr.IterBestCost) || restoredMetrics.UnitCost != 32 {
+		t.Errorf("restored metrics have the costs %v and the unit cost %v", restoredMetrics.IterBestCost, restoredMetrics.UnitCost)
+	}
+	restoredCriteria := new(stopping.Criteria)
+	restoredCriteria.Initialization(0, 0, 5, false)
+	loaded.RestoreCriteria(restoredCriteria)
+	if bestCost, stagnation := restoredCriteria.State(); bestCost != 1 || stagnation != 2 {
+		t.Errorf("restored criteria = %v %d, expected 1 2", bestCost, stagnation)
+	}
+}
+
+func TestSaveError(t *testing.T) {
+	// a checkpoint that cannot be written is reported until a later checkpoint is saved
+	directory := t.TempDir()
+	options := &checkpoint.Options{Filename: filepath.Join(directory, "missing", "run.gz"), Every: 1}
+	options.Save(&checkpoint.Checkpoint{Algorithm: "multiCSO", Generation: 1})
+	if options.Err == nil {
+		t.Fatal("no error for a checkpoint in a missing directory")
+	}
+	if _, err := os.Stat(options.Filename + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("the temporary file of the failed checkpoint exists")
+	}
+	options.Filename = filepath.Join(directory, "run.gz")
+	options.Save(&checkpoint.Checkpoint{Algorithm: "multiCSO", Generation: 2})
+	if options.Err != nil {
+		t.Errorf("error %v after a saved checkpoint", options.Err)
+	}
+	if loaded := checkpoint.Load(options.Filename); loaded.Generation != 2 {
+		t.Errorf("loaded generation %d, expected 2", loaded.Generation)
+	}
+}
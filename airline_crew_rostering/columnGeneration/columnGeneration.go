@@ -0,0 +1,281 @@
+package columnGeneration
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+
+	"go-airline-crew-rostering/airline"
+	"go-airline-crew-rostering/fitness"
+	"go-airline-crew-rostering/metrics"
+)
+
+// Container for the functions related to the column generation
+type ColumnGenerationRepo interface {
+	Initialization() *ColumnGeneration
+	ColumnGeneration() []*airline.Pilot
+	WarmStart()
+	greedySolution() []*airline.Pilot
+	addSolution()
+	integerSolution() []*airline.Pilot
+}
+
+// Exact solver that uses column generation over the lines of work of the pilots
+type ColumnGeneration struct {
+	maxIterations int                // Maximum number of column generation iterations
+	maxColumns    int                // Maximum number of columns added to the master problem per iteration
+	penalty       float64            // Cost of leaving a pairing uncovered (in minutes of deviation)
+	upperBound    float64            // Objective value of the best integer solution
+	master        *master            // Restricted master problem
+	pricing       *pricing           // Pricing subproblem
+	LowerBound    float64            // Best lower bound of the master problem's objective
+	Solution      []*airline.Pilot   // Integer solution guided by the master problem
+	Mtr           *metrics.Metrics   // Metrics used to evaluate the algorithm's efficiency
+	objective     *fitness.Objective // Objective used to calculate the cost of the integer solution
+}
+
+var lambdas = []float64{0, 0.5, 1} // linearizations of the lines' cost used by the pricing
+var initialSolutions = 20          // number of greedy solutions that initialize the master problem
+var candidates = 3.0               // pilots considered by the randomized greedy solutions
+
+func (cg *ColumnGeneration) Initialization(al *airline.Airline, maxIterations int, maxColumns int,
+	penalty float64, Mtr *metrics.Metrics, objective *fitness.Objective) *ColumnGeneration {
+	// Initialize the pricing subproblem and the master problem
+	// with the lines of several greedy solutions
+	cg.maxIterations = maxIterations
+	cg.maxColumns = maxColumns
+	cg.penalty = penalty
+	cg.LowerBound = 0
+	cg.Solution = []*airline.Pilot{}
+	cg.Mtr = Mtr
+	cg.objective = objective
+	cg.pricing = new(pricing)
+	cg.pricing.Initialization(al)
+	empty := &line{pairs: []*airline.Pair{}, rows: []int{}, cost: al.AverageWorkload}
+	cg.master = new(master)
+	cg.master.Initialization(len(al.PairsArray)-1, al.NumberOfPilots, penalty, empty)
+	cg.pricing.generatedLines[empty.key] = true
+	cg.upperBound = math.Inf(1)
+	for i := 0; i < initialSolutions; i++ {
+		// every greedy solution is a feasible solution of the master problem
+		cg.addSolution(al, cg.greedySolution(al, i))
+	}
+	return cg
+}
+
+func (cg *ColumnGeneration) WarmStart(al *airline.Airline, solution []*airline.Pilot) {
+	// Add the lines of "solution" to the master problem
+	// (used to start the search from a known roster)
+	cg.addSolution(al, solution)
+}
+
+func (cg *ColumnGeneration) addSolution(al *airline.Airline, pilots []*airline.Pilot) {
+	// Add the lines of a feasible solution to the master problem
+	// and keep the solution if it is the best integer solution so far
+	if cost := cg.solutionCost(al, pilots); cost < cg.upperBound {
+		cg.upperBound = cost
+		cg.Solution = pilots
+	}
+	for _, l := range cg.pilotLines(pilots) {
+		if !cg.pricing.generatedLines[l.key] {
+			cg.pricing.generatedLines[l.key] = true
+			cg.master.AddLine(l)
+		}
+	}
+}
+
+func (cg *ColumnGeneration) greedySolution(al *airline.Airline, seed int) []*airline.Pilot {
+	// Build a greedy solution, assigning each pairing to the pilot with the smallest
+	// workload that can accept it (for seed > 0, to a random one of the "candidates"
+	// pilots with the smallest workload) and equalizing the workload
+	random := rand.New(rand.NewSource(int64(seed)))
+	pilots := cg.emptyPilots(al)
+	for _, pair := range al.PairsArray[1:] {
+		feasible := []*airline.Pilot{}
+		for _, pilot := range pilots {
+			if al.RestPeriodRule(pilot, pair) > -1 && al.DaysOffRule(pilot, pair, true) {
+				feasible = append(feasible, pilot)
+			}
+		}
+		if len(feasible) == 0 {
+			continue
+		}
+		sort.SliceStable(feasible, func(i int, j int) bool {
+			return feasible[i].FlightTime < feasible[j].FlightTime
+		})
+		selectedPilot := feasible[0]
+		if seed > 0 {
+			selectedPilot = feasible[random.Intn(int(math.Min(candidates, float64(len(feasible)))))]
+		}
+		selectedPilot.Add(pair, al.RestPeriodRule(selectedPilot, pair))
+	}
+	return al.EqualizeWorkload(pilots)
+}
+
+func (cg *ColumnGeneration) emptyPilots(al *airline.Airline) []*airline.Pilot {
+	// returns a list of pilots without assigned pairings
+	pilots := []*airline.Pilot{}
+	for i := 0; i < al.NumberOfPilots; i++ {
+		pilot := new(airline.Pilot)
+		pilot.Initialization(i, al.ScheduleDuration, al.PairsArray[0])
+		pilots = append(pilots, pilot)
+	}
+	return pilots
+}
+
+func (cg *ColumnGeneration) solutionCost(al *airline.Airline, pilots []*airline.Pilot) float64 {
+	// returns the objective value of an integer solution in the master problem
+	uncovered := len(al.PairsArray) - 1
+	cost := 0.0
+	for _, pilot := range pilots {
+		uncovered -= pilot.AssignedLength
+		cost += math.Abs(al.AverageWorkload - pilot.FlightTime)
+	}
+	return cost + cg.penalty*float64(uncovered)
+}
+
+func (cg *ColumnGeneration) pilotLines(pilots []*airline.Pilot) []*line {
+	// Convert the schedules of the pilots to lines of work
+	lines := []*line{}
+	for _, pilot := range pilots {
+		if pilot.AssignedLength == 0 {
+			continue
+		}
+		var l *label
+		for _, pair := range pilot.AssignedPairs[1:] {
+			l = &label{pair: pair, flightTime: pair.Duration, parent: l}
+		}
+		lines = append(lines, cg.pricing.NewLine(l))
+	}
+	return lines
+}
+
+func (cg *ColumnGeneration) ColumnGeneration(al *airline.Airline) []*airline.Pilot {
+	// Main body of the column generation
+	// Returns the integer solution guided by the master problem
+	pilots := float64(al.NumberOfPilots)
+	cg.LowerBound = math.Inf(-1)
+	for iteration := 0; iteration < cg.maxIterations; iteration++ {
+		objective := cg.master.Solve(500, cg.upperBound)
+		duals := cg.master.duals
+		// the dual value of the pilots' row is the reduced cost of the last line selected by the master problem
+		pilotsDual := cg.master.pilotsDual()
+
+		// every linearization of the lines' cost gives a valid lower bound
+		// of the Lagrangian relaxation over all possible lines
+		newLines := []*line{}
+		bound := math.Inf(-1)
+		for _, lambda := range lambdas {
+			labels, minimum := cg.pricing.Solve(duals, lambda)
+			bound = math.Max(bound, minimum)
+			newLines = append(newLines, cg.pricing.BestLines(labels, duals, pilotsDual, cg.maxColumns)...)
+		}
+		lowerBound := pilots * bound
+		for _, dual := range duals {
+			lowerBound += dual
+		}
+		cg.LowerBound = math.Max(cg.LowerBound, lowerBound)
+
+		cg.Mtr.SetUpIterationMetrics([]float64{objective * cg.Mtr.UnitCost})
+		cg.Mtr.IterLowerBound = append(cg.Mtr.IterLowerBound, math.Max(cg.LowerBound, 0)*cg.Mtr.UnitCost)
+		if iteration%10 == 0 {
+			fmt.Printf("Iteration %d: master objective %.2f, lower bound %.2f, columns %d\n",
+				iteration, objective, cg.LowerBound, len(cg.master.lines))
+		}
+		if len(newLines) == 0 || objective-cg.LowerBound < 1e-4*(1+math.Abs(objective)) {
+			break
+		}
+		for _, l := range newLines {
+			cg.master.AddLine(l)
+		}
+		// the lines of the rounded solution help the master problem
+		// combine the new lines into better solutions
+		cg.Solution = cg.integerSolution(al)
+		for _, l := range cg.pilotLines(cg.Solution) {
+			if !cg.pricing.generatedLines[l.key] {
+				cg.pricing.generatedLines[l.key] = true
+				cg.master.AddLine(l)
+			}
+		}
+	}
+	cg.LowerBound = math.Max(cg.LowerBound, 0)
+	cg.Mtr.LowerBound = cg.LowerBound * cg.Mtr.UnitCost
+	cg.Mtr.TotalSolutions = len(cg.master.lines)
+	cg.Mtr.ValidSolutions = len(cg.master.lines)
+	cg.Mtr.UniqueCount = len(cg.master.lines)
+
+	cg.Solution = cg.integerSolution(al)
+	_, cost := cg.objective.Evaluate(cg.Solution, al)
+	cg.Mtr.GlobalBestSolutionCost = cost * cg.Mtr.UnitCost
+	return cg.Solution
+}
+
+func (cg *ColumnGeneration) integerSolution(al *airline.Airline) []*airline.Pilot {
+	// Round the solution of the master problem to an integer solution, by selecting
+	// the lines with the biggest values that do not share pairings, assigning the
+	// remaining pairings greedily and equalizing the workload
+	// returns the rounded solution or the best greedy solution if it is better
+	values := cg.master.LineValues()
+	order := make([]int, len(values))
+	for j := range order {
+		order[j] = j
+	}
+	sort.SliceStable(order, func(i int, j int) bool {
+		return values[order[i]] > values[order[j]]
+	})
+
+	pilots := cg.emptyPilots(al)
+	covered := make(map[int]bool)
+	next := 0
+	for _, j := range order {
+		l := cg.master.lines[j]
+		if next == len(pilots) || values[j] < eps {
+			break
+		}
+		if len(l.pairs) == 0 {
+			continue
+		}
+		disjoint := true
+		for _, pair := range l.pairs {
+			if covered[pair.Id] {
+				disjoint = false
+				break
+			}
+		}
+		if !disjoint {
+			continue
+		}
+		for _, pair := range l.pairs {
+			pilots[next].Add(pair, pilots[next].AssignedLength+1)
+			covered[pair.Id] = true
+		}
+		next++
+	}
+
+	for _, pair := range al.PairsArray[1:] {
+		if covered[pair.Id] {
+			continue
+		}
+		var selectedPilot *airline.Pilot
+		selectedIndex := -1
+		for _, pilot := range pilots {
+			index := al.RestPeriodRule(pilot, pair)
+			if index > -1 && al.DaysOffRule(pilot, pair) {
+				if selectedPilot == nil || pilot.FlightTime < selectedPilot.FlightTime {
+					selectedPilot = pilot
+					selectedIndex = index
+				}
+			}
+		}
+		if selectedPilot != nil {
+			selectedPilot.Add(pair, selectedIndex)
+		}
+	}
+	al.EqualizeWorkload(pilots)
+	// keep the best greedy solution if the rounding is worse
+	if cg.solutionCost(al, pilots) > cg.solutionCost(al, cg.Solution) {
+		return cg.Solution
+	}
+	return pilots
+}
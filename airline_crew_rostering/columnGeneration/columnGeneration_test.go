@@ -0,0 +1,69 @@
+package columnGeneration_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"go-airline-crew-rostering/columnGeneration"
+	"go-airline-crew-rostering/fitness"
+	"go-airline-crew-rostering/input"
+	"go-airline-crew-rostering/metrics"
+)
+
+func TestColumnGeneration(t *testing.T) {
+	// the solution obeys the rules and its cost (with the penalty of the
+	// uncovered pairings) is not below the lower bound
+	tests := []struct {
+		name    string
+		end     time.Time
+		pilots  int
+		penalty float64
+	}{
+		{"covered", time.Date(2011, 11, 15, 0, 0, 0, 0, time.UTC), 45, 2000},
+		{"small penalty", time.Date(2011, 11, 15, 0, 0, 0, 0, time.UTC), 25, 10},
+	}
+	for _, test := range tests {
+		al := input.ReadInstance("../Pairings.csv", time.Date(2011, 11, 1, 0, 0, 0, 0, time.UTC), test.end, test.pilots)
+		objective := fitness.DefaultObjective()
+		Mtr := new(metrics.Metrics)
+		Mtr.Initialization(0, objective.UnitCost)
+		cg := new(columnGeneration.ColumnGeneration)
+		cg.Initialization(al, 8, 20, test.penalty, Mtr, objective)
+		solution := cg.ColumnGeneration(al)
+
+		if len(solution) != al.NumberOfPilots {
+			t.Fatalf("%s: %d pilots, expected %d", test.name, len(solution), al.NumberOfPilots)
+		}
+		assigned := make(map[int]bool)
+		deviation := 0.0
+		for _, pilot := range solution {
+			for i, pair := range pilot.AssignedPairs[1 : pilot.AssignedLength+1] {
+				if assigned[pair.Id] {
+					t.Errorf("%s: pairing %d is assigned twice", test.name, pair.Id)
+				}
+				assigned[pair.Id] = true
+				if i > 0 && pair.Start.Sub(pilot.AssignedPairs[i].End).Minutes() < float64(al.RestPeriod) {
+					t.Errorf("%s: pilot %d does not rest before pairing %d", test.name, pilot.Id, pair.Id)
+				}
+			}
+			if !pilot.DaysOffRuleChecker() {
+				t.Errorf("%s: pilot %d does not get the days off", test.name, pilot.Id)
+			}
+			deviation += math.Abs(al.AverageWorkload - pilot.FlightTime)
+		}
+		uncovered := float64(len(al.PairsArray) - 1 - len(assigned))
+		cost := (deviation + test.penalty*uncovered) * objective.UnitCost
+		if Mtr.LowerBound < 0 || Mtr.LowerBound > cost*(1+1e-6) {
+			t.Errorf("%s: lower bound %v, cost %v", test.name, Mtr.LowerBound, cost)
+		}
+		if math.Abs(Mtr.GlobalBestSolutionCost-deviation*objective.UnitCost) > 1e-6*Mtr.GlobalBestSolutionCost {
+			t.Errorf("%s: best cost %v, expected %v", test.name, Mtr.GlobalBestSolutionCost, deviation*objective.UnitCost)
+		}
+		for i := 1; i < len(Mtr.IterLowerBound); i++ {
+			if Mtr.IterLowerBound[i] < Mtr.IterLowerBound[i-1] {
+				t.Errorf("%s: the lower bound decreases in iteration %d", test.name, i)
+			}
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package columnGeneration
+
+import (
+	"math"
+	"sort"
+
+	"go-airline-crew-rostering/airline"
+)
+
+var eps float64 = 1e-9 // tolerance used for the comparison of reduced costs
+
+// struct representing a line of work (a column of the master problem)
+type line struct {
+	pairs      []*airline.Pair // pairings of the line in chronological order
+	rows       []int           // rows of the master problem covered by the line
+	flightTime float64         // total flight time of the line (in minutes)
+	cost       float64         // deviation of the line's flight time from the average workload
+	key        string          // encoding of the line used to avoid duplicate columns
+}
+
+// Restricted master problem of the column generation. Every row demands that a pairing
+// is covered exactly once (an uncovered pairing is paid through a slack variable with
+// cost "penalty") and exactly one line is selected for each pilot. The set partitioning
+// constraints are highly degenerate, so the linear program is solved with the volume
+// algorithm, which returns dual values along with an approximate primal solution.
+type master struct {
+	rows      int       // number of pairings' rows
+	pilots    float64   // number of available pilots
+	penalty   float64   // cost of leaving a pairing uncovered
+	lines     []*line   // columns of the master problem
+	duals     []float64 // dual values of the pairings' rows that gave the best Lagrangian value
+	bound     float64   // best Lagrangian value over the columns of the master problem
+	values    []float64 // approximate primal value of each line
+	coverage  []float64 // approximate coverage of each row by the lines
+	uncovered []float64 // approximate value of each slack variable
+	step      float64   // step factor of the volume algorithm
+}
+
+func (m *master) Initialization(pairings int, pilots int, penalty float64, empty *line) *master {
+	// Initialize the master problem with the empty line, which is selected for every pilot
+	m.rows = pairings
+	m.pilots = float64(pilots)
+	m.penalty = penalty
+	m.lines = []*line{empty}
+	m.duals = make([]float64, pairings)
+	m.values = []float64{m.pilots}
+	m.coverage = make([]float64, pairings)
+	m.uncovered = make([]float64, pairings)
+	m.bound = m.pilots * empty.cost
+	m.step = 0.1
+	return m
+}
+
+func (m *master) AddLine(l *line) {
+	// Add a new column to the master problem
+	m.lines = append(m.lines, l)
+	m.values = append(m.values, 0)
+}
+
+func (m *master) ReducedCost(l *line, duals []float64) float64 {
+	// returns the cost of a line minus the dual values of its pairings
+	reducedCost := l.cost
+	for _, row := range l.rows {
+		reducedCost -= duals[row]
+	}
+	return reducedCost
+}
+
+func (m *master) lagrangian(duals []float64) (float64, []float64) {
+	// Calculate the Lagrangian relaxation of the pairings' rows, where every line
+	// except the empty one is selected at most once
+	// returns its value and the value of each line in the relaxation's solution
+	value := 0.0
+	for _, dual := range duals {
+		value += dual
+	}
+	reducedCosts := make([]float64, len(m.lines))
+	order := make([]int, 0, len(m.lines))
+	for j, l := range m.lines {
+		reducedCosts[j] = m.ReducedCost(l, duals)
+		if j > 0 && reducedCosts[j] < reducedCosts[0] {
+			order = append(order, j)
+		}
+	}
+	sort.Slice(order, func(i int, j int) bool {
+		return reducedCosts[order[i]] < reducedCosts[order[j]]
+	})
+	solution := make([]float64, len(m.lines))
+	solution[0] = m.pilots
+	for _, j := range order {
+		if solution[0] < 1 {
+			break
+		}
+		solution[j] = 1
+		solution[0]--
+		value += reducedCosts[j]
+	}
+	return value + solution[0]*reducedCosts[0], solution
+}
+
+func (m *master) Solve(iterations int, upperBound float64) float64 {
+	// Improve the dual values and the approximate primal solution of the
+	// master problem with "iterations" steps of the volume algorithm
+	// returns the best Lagrangian value, which approximates the objective value
+	var solution []float64
+	m.bound, solution = m.lagrangian(m.duals)
+	// start the approximate primal solution from the solution of the relaxation,
+	// which includes the lines added since the last call
+	m.setPrimal(solution, 1)
+	for i := range m.uncovered {
+		m.uncovered[i] = 0
+		if m.duals[i] >= m.penalty && m.coverage[i] < 1 {
+			m.uncovered[i] = 1 - m.coverage[i]
+		}
+	}
+	duals := make([]float64, m.rows)
+	m.step = 0.1
+	alpha := 0.1 // weight of the newest solution in the approximate primal solution
+	nonImproving := 0
+	for iteration := 0; iteration < iterations; iteration++ {
+		// subgradient at the approximate primal solution
+		norm := 0.0
+		for i := range duals {
+			violation := 1 - m.coverage[i] - m.uncovered[i]
+			norm += violation * violation
+		}
+		if norm < eps {
+			break
+		}
+		target := math.Min(upperBound, math.Max(m.PrimalObjective(), m.bound+math.Abs(m.bound)*0.01+1))
+		stepSize := m.step * (target - m.bound) / norm
+		for i := range duals {
+			duals[i] = m.duals[i] + stepSize*(1-m.coverage[i]-m.uncovered[i])
+			// an uncovered pairing costs "penalty", so bigger dual values are useless
+			duals[i] = math.Min(duals[i], m.penalty)
+		}
+
+		value, solution := m.lagrangian(duals)
+
+		// update the approximate primal solution
+		m.setPrimal(solution, alpha)
+		for i := range m.uncovered {
+			uncovered := 0.0
+			if duals[i] >= m.penalty && m.coverage[i] < 1 {
+				uncovered = 1 - m.coverage[i]
+			}
+			m.uncovered[i] = (1-alpha)*m.uncovered[i] + alpha*uncovered
+		}
+
+		// update the dual values and the step factor
+		if value > m.bound {
+			m.bound = value
+			copy(m.duals, duals)
+			m.step = math.Min(2, m.step*1.1)
+			nonImproving = 0
+		} else if nonImproving++; nonImproving >= 20 {
+			m.step *= 0.66
+			nonImproving = 0
+		}
+	}
+	return m.bound
+}
+
+func (m *master) setPrimal(solution []float64, alpha float64) {
+	// Move the approximate primal solution towards the solution of the relaxation
+	for j := range m.values {
+		m.values[j] = (1-alpha)*m.values[j] + alpha*solution[j]
+	}
+	for i := range m.coverage {
+		m.coverage[i] *= 1 - alpha
+	}
+	for j, value := range solution {
+		if j > 0 && value > 0 {
+			for _, row := range m.lines[j].rows {
+				m.coverage[row] += alpha * value
+			}
+		}
+	}
+}
+
+func (m *master) pilotsDual() float64 {
+	// returns the dual value of the pilots' row, which is the reduced cost of
+	// the last line selected by the Lagrangian relaxation
+	_, solution := m.lagrangian(m.duals)
+	dual := m.ReducedCost(m.lines[0], m.duals)
+	if solution[0] >= 1 {
+		return dual
+	}
+	for j, value := range solution {
+		if value > 0 && j > 0 {
+			dual = math.Max(dual, m.ReducedCost(m.lines[j], m.duals))
+		}
+	}
+	return dual
+}
+
+func (m *master) PrimalObjective() float64 {
+	// returns the objective value of the approximate primal solution
+	objective := 0.0
+	for j, l := range m.lines {
+		objective += l.cost * m.values[j]
+	}
+	for _, uncovered := range m.uncovered {
+		objective += m.penalty * uncovered
+	}
+	return objective
+}
+
+func (m *master) LineValues() []float64 {
+	// returns the value of each line in the approximate primal solution
+	return append([]float64(nil), m.values...)
+}
@@ -0,0 +1,239 @@
+package columnGeneration
+
+import (
+	"math"
+	"math/bits"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go-airline-crew-rostering/airline"
+)
+
+// struct representing a partial line of work in the labeling algorithm
+type label struct {
+	cost       float64       // linear reduced cost accumulated so far
+	duals      float64       // sum of the dual values of the covered pairings
+	flightTime float64       // flight time accumulated so far
+	mask       uint64        // days with duty in the last "timespan" days (bit 0 is the last day)
+	pair       *airline.Pair // last pairing of the partial line (nil for labels of day nodes)
+	parent     *label        // label that was extended to create this one
+}
+
+// Pricing subproblem of the column generation. The pair connection graph contains a node
+// for each pairing and a node for each day of the schedule. Two pairings are connected
+// directly when the second one starts at most one day after the end of the first one and
+// the rest period between them is respected. Otherwise, a line waits in the day nodes,
+// which are connected in a chain, until it reaches the day its next pairing starts.
+type pricing struct {
+	pairs          []*airline.Pair  // pairings sorted by their start
+	rows           map[int]int      // row of the master problem for each pairing id
+	successors     [][]int          // pairings that can directly follow each pairing
+	startingOn     map[int][]int    // pairings that start on each day
+	firstDay       int              // first day with a pairing
+	lastDay        int              // last day with a pairing
+	restPeriod     float64          // minimum rest period between two pairings (in minutes)
+	timespan       int              // length of the days off window
+	maxWorkdays    int              // maximum days with duty in a window
+	averageWork    float64          // average workload per pilot
+	dayLabels      map[int][]*label // labels of day nodes
+	pairLabels     [][]*label       // labels of pairing nodes
+	generatedLines map[string]bool  // lines already added to the master problem
+}
+
+func (p *pricing) Initialization(al *airline.Airline) *pricing {
+	// Build the pair connection graph for the pairings of the airline
+	p.pairs = al.PairsArray[1:]
+	p.rows = make(map[int]int)
+	p.startingOn = make(map[int][]int)
+	p.restPeriod = float64(al.RestPeriod)
+	p.timespan = al.Timespan()
+	p.maxWorkdays = al.Timespan() - al.MinimumDaysOff()
+	p.averageWork = al.AverageWorkload
+	p.generatedLines = make(map[string]bool)
+	p.firstDay, p.lastDay = math.MaxInt, 0
+	for i, pair := range p.pairs {
+		p.rows[pair.Id] = i
+		p.startingOn[pair.StartDay] = append(p.startingOn[pair.StartDay], i)
+		if pair.StartDay < p.firstDay {
+			p.firstDay = pair.StartDay
+		}
+		if pair.EndDay > p.lastDay {
+			p.lastDay = pair.EndDay
+		}
+	}
+	p.successors = make([][]int, len(p.pairs))
+	for i, pair := range p.pairs {
+		for day := pair.EndDay; day <= pair.EndDay+1; day++ {
+			for _, j := range p.startingOn[day] {
+				if p.pairs[j].Start.Sub(pair.End).Minutes() >= p.restPeriod {
+					p.successors[i] = append(p.successors[i], j)
+				}
+			}
+		}
+	}
+	return p
+}
+
+func (p *pricing) advance(mask uint64, from int, to int, workStart int) (uint64, bool) {
+	// Move the days off window of a label from day "from" to day "to", where the
+	// days from "workStart" onwards have duty
+	// returns the new window and whether the days off rule is still obeyed
+	full := uint64(1)<<p.timespan - 1
+	for day := from + 1; day <= to; day++ {
+		mask <<= 1
+		if day >= workStart {
+			mask |= 1
+		}
+		mask &= full
+		if day >= p.timespan-1 && bits.OnesCount64(mask) > p.maxWorkdays {
+			return mask, false
+		}
+	}
+	return mask, true
+}
+
+func (p *pricing) insert(labels []*label, newLabel *label) ([]*label, bool) {
+	// Insert a label into a list of non dominated labels
+	// returns the new list and whether the label was inserted
+	for _, l := range labels {
+		if l.cost <= newLabel.cost+eps && l.mask&^newLabel.mask == 0 {
+			return labels, false
+		}
+	}
+	filtered := labels[:0]
+	for _, l := range labels {
+		if !(newLabel.cost <= l.cost+eps && newLabel.mask&^l.mask == 0) {
+			filtered = append(filtered, l)
+		}
+	}
+	return append(filtered, newLabel), true
+}
+
+func (p *pricing) extendToPair(l *label, from int, j int, duals []float64, coefficient float64) {
+	// Extend a label ending on day "from" with the pairing at position "j"
+	pair := p.pairs[j]
+	mask, feasible := p.advance(l.mask, from, pair.EndDay, pair.StartDay)
+	if !feasible {
+		return
+	}
+	newLabel := &label{
+		cost:       l.cost + coefficient*pair.Duration - duals[j],
+		duals:      l.duals + duals[j],
+		flightTime: l.flightTime + pair.Duration,
+		mask:       mask,
+		pair:       pair,
+		parent:     l,
+	}
+	p.pairLabels[j], _ = p.insert(p.pairLabels[j], newLabel)
+}
+
+func (p *pricing) extendToDay(l *label, from int, day int) {
+	// Extend a label ending on day "from" to the day node "day"
+	// (all days in between are without duty)
+	mask, feasible := p.advance(l.mask, from, day-1, day)
+	if !feasible {
+		return
+	}
+	newLabel := &label{cost: l.cost, duals: l.duals, flightTime: l.flightTime, mask: mask, parent: l}
+	p.dayLabels[day], _ = p.insert(p.dayLabels[day], newLabel)
+}
+
+func (p *pricing) Solve(duals []float64, lambda float64) ([]*label, float64) {
+	// Solve the pricing subproblem for a linearized cost of the lines, where
+	// |flightTime-average| is replaced by (1-2*lambda)*(flightTime-average),
+	// which is never bigger than the actual cost for any lambda in [0,1]
+	// returns the labels of all complete lines and the minimum linearized
+	// cost of a line minus the dual values of its pairings
+	coefficient := 1 - 2*lambda
+	p.dayLabels = make(map[int][]*label)
+	p.pairLabels = make([][]*label, len(p.pairs))
+	p.dayLabels[p.firstDay] = []*label{{}}
+
+	minimum := -coefficient * p.averageWork // the empty line
+	complete := []*label{}
+	complete = append(complete, p.dayLabels[p.firstDay][0])
+	next := 0 // next pairing to process in chronological order
+	for day := p.firstDay; day <= p.lastDay+1; day++ {
+		// process all pairings that start before the day node
+		for ; next < len(p.pairs) && p.pairs[next].StartDay < day; next++ {
+			for _, l := range p.pairLabels[next] {
+				if l.cost-coefficient*p.averageWork < minimum {
+					minimum = l.cost - coefficient*p.averageWork
+				}
+				complete = append(complete, l)
+				for _, j := range p.successors[next] {
+					p.extendToPair(l, p.pairs[next].EndDay, j, duals, coefficient)
+				}
+				if p.pairs[next].EndDay+2 <= p.lastDay {
+					p.extendToDay(l, p.pairs[next].EndDay, p.pairs[next].EndDay+2)
+				}
+			}
+		}
+		for _, l := range p.dayLabels[day] {
+			for _, j := range p.startingOn[day] {
+				p.extendToPair(l, day-1, j, duals, coefficient)
+			}
+			if day < p.lastDay {
+				p.extendToDay(l, day-1, day+1)
+			}
+		}
+	}
+	for ; next < len(p.pairs); next++ {
+		for _, l := range p.pairLabels[next] {
+			if l.cost-coefficient*p.averageWork < minimum {
+				minimum = l.cost - coefficient*p.averageWork
+			}
+			complete = append(complete, l)
+		}
+	}
+	return complete, minimum
+}
+
+func (p *pricing) NewLine(l *label) *line {
+	// Create the line of work represented by a complete label
+	newLine := &line{pairs: []*airline.Pair{}, rows: []int{}}
+	for current := l; current != nil; current = current.parent {
+		if current.pair != nil {
+			newLine.pairs = append(newLine.pairs, current.pair)
+		}
+	}
+	ids := make([]string, len(newLine.pairs))
+	for i := 0; i < len(newLine.pairs)/2; i++ {
+		j := len(newLine.pairs) - 1 - i
+		newLine.pairs[i], newLine.pairs[j] = newLine.pairs[j], newLine.pairs[i]
+	}
+	for i, pair := range newLine.pairs {
+		newLine.rows = append(newLine.rows, p.rows[pair.Id])
+		newLine.flightTime += pair.Duration
+		ids[i] = strconv.Itoa(pair.Id)
+	}
+	newLine.cost = math.Abs(p.averageWork - newLine.flightTime)
+	newLine.key = strings.Join(ids, ",")
+	return newLine
+}
+
+func (p *pricing) BestLines(labels []*label, duals []float64, pilotsDual float64, maxLines int) []*line {
+	// Select the lines with the most negative actual reduced cost for the given dual
+	// values, examining the labels in order of their actual reduced cost for the dual
+	// values used to create them
+	// returns at most "maxLines" lines that have not been added to the master problem yet
+	sort.Slice(labels, func(i int, j int) bool {
+		return math.Abs(p.averageWork-labels[i].flightTime)-labels[i].duals <
+			math.Abs(p.averageWork-labels[j].flightTime)-labels[j].duals
+	})
+	lines := []*line{}
+	for i := 0; i < len(labels) && i < 20*maxLines && len(lines) < maxLines; i++ {
+		newLine := p.NewLine(labels[i])
+		reducedCost := newLine.cost - pilotsDual
+		for _, row := range newLine.rows {
+			reducedCost -= duals[row]
+		}
+		if reducedCost >= -eps || p.generatedLines[newLine.key] {
+			continue
+		}
+		p.generatedLines[newLine.key] = true
+		lines = append(lines, newLine)
+	}
+	return lines
+}
@@ -0,0 +1,123 @@
+package comparison
+
+import (
+	"go-airline-crew-rostering/experiment"
+	"go-airline-crew-rostering/statistics"
+)
+
+// Container for the functions related to the comparison of result sets
+type ComparisonRepo interface {
+	Initialization() *Comparison
+	Add()
+	Compare()
+}
+
+// Measures that can be compared (the measure of each run of an experiment)
+var Measures = []string{"cost", "coverage", "jumps", "similarity", "time"}
+
+// Runs of a configuration of an experiment
+type Set struct {
+	Name   string
+	Values []float64 // compared measure of every run
+	Mean   float64
+	Median float64
+	Std    float64
+	Best   float64
+	Worst  float64
+	Curve  []float64 // best cost of each generation averaged over the runs
+}
+
+// Comparison of two result sets
+type Test struct {
+	A           *Set
+	B           *Set
+	U           float64 // Mann-Whitney U statistic of A
+	PValue      float64 // two-sided p-value of the Wilcoxon rank-sum (Mann-Whitney) test
+	A12         float64 // Vargha-Delaney effect size (probability that a run of A has a bigger value than a run of B)
+	Delta       float64 // Cliff's delta effect size
+	Magnitude   string  // magnitude of Cliff's delta
+	Significant bool    // true if the p-value is smaller than the significance level
+	Better      *Set    // set with the better values if the difference is significant
+}
+
+// Statistical comparison of the result sets of repeated runs
+type Comparison struct {
+	Measure string  // compared measure of the runs
+	Alpha   float64 // significance level of the tests
+	Sets    []*Set
+	Tests   []*Test // tests of every pair of sets
+}
+
+func (c *Comparison) Initialization(measure string, alpha float64) *Comparison {
+	// Initialize a comparison of "measure" without result sets
+	c.Measure = measure
+	c.Alpha = alpha
+	c.Sets = []*Set{}
+	c.Tests = []*Test{}
+	return c
+}
+
+func (c *Comparison) Add(name string, configuration *experiment.Configuration) {
+	// Add the runs of "configuration" as a result set
+	set := &Set{Name: name, Values: []float64{}, Curve: []float64{}}
+	runs := []int{} // number of runs that executed each generation
+	for _, run := range configuration.Runs {
+		set.Values = append(set.Values, measure(run, c.Measure))
+		for t, cost := range run.IterBestCost {
+			if t == len(set.Curve) {
+				set.Curve = append(set.Curve, 0)
+				runs = append(runs, 0)
+			}
+			set.Curve[t] += cost
+			runs[t]++
+		}
+	}
+	for t := range set.Curve {
+		set.Curve[t] /= float64(runs[t])
+	}
+	summary := experiment.Summarize(set.Values, c.higherIsBetter())
+	set.Mean, set.Std, set.Best, set.Worst = summary.Mean, summary.Std, summary.Best, summary.Worst
+	set.Median = statistics.Median(set.Values)
+	c.Sets = append(c.Sets, set)
+}
+
+func (c *Comparison) Compare() {
+	// Test the difference of every pair of result sets
+	for i, a := range c.Sets {
+		for _, b := range c.Sets[i+1:] {
+			test := &Test{A: a, B: b}
+			test.U, test.PValue = statistics.MannWhitney(a.Values, b.Values)
+			test.A12 = statistics.VarghaDelaney(a.Values, b.Values)
+			test.Delta, test.Magnitude = statistics.CliffDelta(a.Values, b.Values)
+			test.Significant = test.PValue < c.Alpha
+			if test.Significant {
+				// A tends to have bigger values than B if A12 is bigger than 0.5
+				test.Better = a
+				if (test.A12 > 0.5) != c.higherIsBetter() {
+					test.Better = b
+				}
+			}
+			c.Tests = append(c.Tests, test)
+		}
+	}
+}
+
+func (c *Comparison) higherIsBetter() bool {
+	// returns true if bigger values of the measure are better
+	return experiment.HigherIsBetter(c.Measure)
+}
+
+func measure(run *experiment.Run, name string) float64 {
+	// returns the measure "name" of the run
+	switch name {
+	case "coverage":
+		return run.Coverage
+	case "jumps":
+		return float64(run.Jumps)
+	case "similarity":
+		return run.Similarity
+	case "time":
+		return run.Time
+	}
+	return run.Cost
+}
@@ -0,0 +1,94 @@
+package comparison_test
+
+import (
+	"math"
+	"testing"
+
+	"go-airline-crew-rostering/comparison"
+	"go-airline-crew-rostering/experiment"
+
+	"golang.org/x/exp/slices"
+)
+
+func configuration(costs []float64, coverages []float64, curves [][]float64) *experiment.Configuration {
+	// returns a configuration with a run for every cost, coverage and convergence curve
+	c := &experiment.Configuration{Runs: []*experiment.Run{}}
+	for i := range costs {
+		c.Runs = append(c.Runs, &experiment.Run{Seed: int64(i), Cost: costs[i], Coverage: coverages[i], IterBestCost: curves[i]})
+	}
+	return c
+}
+
+func TestAdd(t *testing.T) {
+	// the curve of a set averages the runs that executed each generation
+	c := new(comparison.Comparison).Initialization("cost", 0.05)
+	c.Add("A", configuration([]float64{30, 10, 20}, []float64{90, 95, 100}, [][]float64{{40, 30}, {20, 10}, {30, 20, 20}}))
+	set := c.Sets[0]
+	if set.Name != "A" || !slices.Equal(set.Values, []float64{30, 10, 20}) {
+		t.Errorf("set %s with the values %v, expected A with [30 10 20]", set.Name, set.Values)
+	}
+	if set.Mean != 20 || set.Median != 20 || set.Best != 10 || set.Worst != 30 || math.Abs(set.Std-10) > 1e-9 {
+		t.Errorf("mean, median, std, best and worst = %v %v %v %v %v, expected 20 20 10 10 30", set.Mean, set.Median, set.Std, set.Best, set.Worst)
+	}
+	if !slices.Equal(set.Curve, []float64{30, 20, 20}) {
+		t.Errorf("curve = %v, expected [30 20 20]", set.Curve)
+	}
+
+	// the best coverage is the biggest one
+	c = new(comparison.Comparison).Initialization("coverage", 0.05)
+	c.Add("A", configuration([]float64{30, 10, 20}, []float64{90, 95, 100}, [][]float64{{}, {}, {}}))
+	if set := c.Sets[0]; set.Best != 100 || set.Worst != 90 {
+		t.Errorf("coverage: best and worst = %v %v, expected 100 90", set.Best, set.Worst)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	low := []float64{1, 2, 3, 4, 5, 6, 7, 8}
+	high := []float64{11, 12, 13, 14, 15, 16, 17, 18}
+	mixed := []float64{1.5, 2.5, 3.5, 4.5, 5.5, 6.5, 7.5, 8.5}
+	curves := make([][]float64, len(low))
+	tests := []struct {
+		name        string
+		measure     string
+		a           []float64
+		b           []float64
+		significant bool
+		better      string // name of the better set if the difference is significant
+	}{
+		{"smaller cost", "cost", low, high, true, "A"},
+		{"bigger cost", "cost", high, low, true, "B"},
+		{"bigger coverage", "coverage", high, low, true, "A"},
+		{"smaller coverage", "coverage", low, high, true, "B"},
+		{"overlapping", "cost", low, mixed, false, ""},
+	}
+	for _, test := range tests {
+		c := new(comparison.Comparison).Initialization(test.measure, 0.05)
+		c.Add("A", configuration(test.a, test.a, curves))
+		c.Add("B", configuration(test.b, test.b, curves))
+		c.Compare()
+		if len(c.Tests) != 1 {
+			t.Fatalf("%s: %d tests, expected 1", test.name, len(c.Tests))
+		}
+		result := c.Tests[0]
+		if result.Significant != test.significant {
+			t.Errorf("%s: significant = %v (p-value %v), expected %v", test.name, result.Significant, result.PValue, test.significant)
+			continue
+		}
+		if test.significant && result.Better.Name != test.better {
+			t.Errorf("%s: better set = %s, expected %s", test.name, result.Better.Name, test.better)
+		}
+		if !test.significant && result.Better != nil {
+			t.Errorf("%s: better set = %s, expected none", test.name, result.Better.Name)
+		}
+	}
+
+	// every pair of sets is tested once
+	c := new(comparison.Comparison).Initialization("cost", 0.05)
+	for _, name := range []string{"A", "B", "C", "D"} {
+		c.Add(name, configuration(low, low, curves))
+	}
+	c.Compare()
+	if len(c.Tests) != 6 {
+		t.Errorf("%d tests of 4 sets, expected 6", len(c.Tests))
+	}
+}
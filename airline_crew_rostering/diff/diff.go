@@ -0,0 +1,154 @@
+package diff
+
+import (
+	"sort"
+	"time"
+
+	"go-airline-crew-rostering/airline"
+)
+
+// Container for the functions related to the differences between two rosters
+type DiffRepo interface {
+	Initialization() *Diff
+	Compare()
+}
+
+// Pairing of a roster
+type Pairing struct {
+	Id    int       `json:"id"`
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// Differences between the assignments of a pilot in the two rosters
+type Pilot struct {
+	Id               int        `json:"pilot"`
+	Added            []*Pairing `json:"added"`            // pairings assigned to the pilot only by the new roster
+	Removed          []*Pairing `json:"removed"`          // pairings assigned to the pilot only by the old roster
+	FlightTimeBefore float64    `json:"flightTimeBefore"` // flight time of the old roster (in minutes)
+	FlightTimeAfter  float64    `json:"flightTimeAfter"`  // flight time of the new roster (in minutes)
+	DaysOffBefore    int        `json:"daysOffBefore"`    // days off of the old roster
+	DaysOffAfter     int        `json:"daysOffAfter"`     // days off of the new roster
+}
+
+// Pairing assigned to different pilots (or covered by only one roster)
+type Move struct {
+	Pairing *Pairing `json:"pairing"`
+	From    int      `json:"from"` // pilot of the old roster (-1 if the pairing is uncovered)
+	To      int      `json:"to"`   // pilot of the new roster (-1 if the pairing is uncovered)
+}
+
+// Assignment of a roster that breaks the rules of the schedule or cannot be assigned
+// (it is listed apart from the differences)
+type Violation struct {
+	Pilot   int    `json:"pilot"`
+	Pairing int    `json:"pairing"`
+	Rule    string `json:"rule"` // rule broken by the assignment
+}
+
+// Number of differences between the two rosters
+type Summary struct {
+	PilotsChanged  int `json:"pilotsChanged"`  // pilots with different assignments
+	Added          int `json:"added"`          // assignments only in the new roster
+	Removed        int `json:"removed"`        // assignments only in the old roster
+	Moved          int `json:"moved"`          // pairings assigned to different pilots
+	NewlyCovered   int `json:"newlyCovered"`   // pairings covered only by the new roster
+	NewlyUncovered int `json:"newlyUncovered"` // pairings covered only by the old roster
+}
+
+// Differences between two rosters over the same pairings
+type Diff struct {
+	Old       string   `json:"old"` // name of the file of the old roster
+	New       string   `json:"new"` // name of the file of the new roster
+	Pilots    []*Pilot `json:"pilots"`
+	Moves     []*Move  `json:"moves"`
+	Covered   []*Move  `json:"covered"`   // pairings covered only by the new roster
+	Uncovered []*Move  `json:"uncovered"` // pairings covered only by the old roster
+	Summary   *Summary `json:"summary"`
+	// assignments of each roster that break the rules (they are part of the differences)
+	// or refer to unknown pilots or pairings (they are not)
+	OldViolations []*Violation `json:"oldViolations"`
+	NewViolations []*Violation `json:"newViolations"`
+}
+
+func (d *Diff) Initialization(oldName string, newName string) *Diff {
+	// Initialization of the differences between the rosters of two files
+	d.Old = oldName
+	d.New = newName
+	d.Pilots = []*Pilot{}
+	d.Moves = []*Move{}
+	d.Covered = []*Move{}
+	d.Uncovered = []*Move{}
+	d.Summary = new(Summary)
+	d.OldViolations = []*Violation{}
+	d.NewViolations = []*Violation{}
+	return d
+}
+
+func (d *Diff) Compare(oldRoster []*airline.Pilot, newRoster []*airline.Pilot) {
+	// Find the differences between the assignments of every pilot of the two rosters
+	// (both rosters must have the same pilots and be built from the same pairings,
+	// and every assignment of the files, valid or not, should be part of them)
+	oldPilots := assignedPilots(oldRoster)
+	newPilots := assignedPilots(newRoster)
+	for i, pilot := range newRoster {
+		before := oldRoster[i]
+		p := &Pilot{Id: pilot.Id, Added: []*Pairing{}, Removed: []*Pairing{},
+			FlightTimeBefore: before.FlightTime, FlightTimeAfter: pilot.FlightTime,
+			DaysOffBefore: before.DaysOff(), DaysOffAfter: pilot.DaysOff()}
+		for _, pair := range pilot.AssignedPairs[1 : pilot.AssignedLength+1] {
+			if from, assigned := oldPilots[pair]; !assigned || from != pilot.Id {
+				p.Added = append(p.Added, pairing(pair))
+			}
+		}
+		for _, pair := range before.AssignedPairs[1 : before.AssignedLength+1] {
+			to, assigned := newPilots[pair]
+			if !assigned || to != pilot.Id {
+				p.Removed = append(p.Removed, pairing(pair))
+			}
+			if !assigned {
+				d.Uncovered = append(d.Uncovered, &Move{Pairing: pairing(pair), From: pilot.Id, To: -1})
+			} else if to != pilot.Id {
+				d.Moves = append(d.Moves, &Move{Pairing: pairing(pair), From: pilot.Id, To: to})
+			}
+		}
+		if len(p.Added) > 0 || len(p.Removed) > 0 {
+			d.Pilots = append(d.Pilots, p)
+			d.Summary.Added += len(p.Added)
+			d.Summary.Removed += len(p.Removed)
+		}
+	}
+	for pair, pilot := range newPilots {
+		if _, assigned := oldPilots[pair]; !assigned {
+			d.Covered = append(d.Covered, &Move{Pairing: pairing(pair), From: -1, To: pilot})
+		}
+	}
+
+	// the pairings are sorted by their start
+	for _, moves := range [][]*Move{d.Moves, d.Covered, d.Uncovered} {
+		sort.Slice(moves, func(a int, b int) bool {
+			return moves[a].Pairing.Start.Before(moves[b].Pairing.Start) ||
+				moves[a].Pairing.Start.Equal(moves[b].Pairing.Start) && moves[a].Pairing.Id < moves[b].Pairing.Id
+		})
+	}
+	d.Summary.PilotsChanged = len(d.Pilots)
+	d.Summary.Moved = len(d.Moves)
+	d.Summary.NewlyCovered = len(d.Covered)
+	d.Summary.NewlyUncovered = len(d.Uncovered)
+}
+
+func assignedPilots(roster []*airline.Pilot) map[*airline.Pair]int {
+	// returns the pilot of each assigned pairing of the roster
+	pilots := make(map[*airline.Pair]int)
+	for _, pilot := range roster {
+		for _, pair := range pilot.AssignedPairs[1 : pilot.AssignedLength+1] {
+			pilots[pair] = pilot.Id
+		}
+	}
+	return pilots
+}
+
+func pairing(pair *airline.Pair) *Pairing {
+	// returns the pairing as stored by the differences
+	return &Pairing{pair.Id, pair.Start, pair.End}
+}
@@ -0,0 +1,100 @@
+package diff_test
+
+import (
+	"testing"
+	"time"
+
+	"go-airline-crew-rostering/airline"
+	"go-airline-crew-rostering/diff"
+)
+
+func schedule() *airline.Airline {
+	// returns a week with one pairing per day (pairing i is on day i-1)
+	start := time.Date(2011, 11, 1, 0, 0, 0, 0, time.UTC)
+	al := new(airline.Airline)
+	al.Initialization(660, 7, 2, start, start.AddDate(0, 0, 7), 3)
+	root := new(airline.Pair)
+	root.Initialization(0, start)
+	al.PairsArray = []*airline.Pair{root}
+	for i := 1; i <= 6; i++ {
+		pair := new(airline.Pair)
+		pair.Initialization(i, start)
+		pair.Add(i, start.AddDate(0, 0, i-1).Add(8*time.Hour), start.AddDate(0, 0, i-1).Add(12*time.Hour), start)
+		al.PairsArray = append(al.PairsArray, pair)
+	}
+	return al
+}
+
+func roster(al *airline.Airline, assignments [][]int) []*airline.Pilot {
+	// returns the pilots of "al" with the pairings of "assignments" (in chronological order)
+	pilots := []*airline.Pilot{}
+	for id, pairs := range assignments {
+		pilot := new(airline.Pilot)
+		pilot.Initialization(id, al.ScheduleDuration, al.PairsArray[0])
+		for _, pair := range pairs {
+			pilot.Add(al.PairsArray[pair], pilot.AssignedLength+1)
+		}
+		pilots = append(pilots, pilot)
+	}
+	return pilots
+}
+
+func ids(moves []*diff.Move) [][3]int {
+	// returns the pairing, the old and the new pilot of every move
+	result := [][3]int{}
+	for _, move := range moves {
+		result = append(result, [3]int{move.Pairing.Id, move.From, move.To})
+	}
+	return result
+}
+
+func equal(a [][3]int, b [][3]int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name      string
+		old       [][]int
+		new       [][]int
+		moves     [][3]int
+		covered   [][3]int
+		uncovered [][3]int
+		summary   diff.Summary
+	}{
+		{"same roster", [][]int{{1, 3}, {2}, {}}, [][]int{{1, 3}, {2}, {}}, [][3]int{}, [][3]int{}, [][3]int{}, diff.Summary{}},
+		{"moved pairing", [][]int{{1, 3}, {2}, {}}, [][]int{{1}, {2}, {3}}, [][3]int{{3, 0, 2}}, [][3]int{}, [][3]int{},
+			diff.Summary{PilotsChanged: 2, Added: 1, Removed: 1, Moved: 1}},
+		{"swapped pilots", [][]int{{1}, {2}, {}}, [][]int{{2}, {1}, {}}, [][3]int{{1, 0, 1}, {2, 1, 0}}, [][3]int{}, [][3]int{},
+			diff.Summary{PilotsChanged: 2, Added: 2, Removed: 2, Moved: 2}},
+		{"covered and uncovered", [][]int{{1, 5}, {2}, {}}, [][]int{{1}, {2}, {4, 6}}, [][3]int{}, [][3]int{{4, -1, 2}, {6, -1, 2}}, [][3]int{{5, 0, -1}},
+			diff.Summary{PilotsChanged: 2, Added: 2, Removed: 1, NewlyCovered: 2, NewlyUncovered: 1}},
+	}
+	for _, test := range tests {
+		al := schedule()
+		d := new(diff.Diff).Initialization("old.json", "new.json")
+		d.Compare(roster(al, test.old), roster(al, test.new))
+		if !equal(ids(d.Moves), test.moves) || !equal(ids(d.Covered), test.covered) || !equal(ids(d.Uncovered), test.uncovered) {
+			t.Errorf("%s: moves %v, covered %v and uncovered %v, expected %v, %v and %v", test.name,
+				ids(d.Moves), ids(d.Covered), ids(d.Uncovered), test.moves, test.covered, test.uncovered)
+		}
+		if *d.Summary != test.summary {
+			t.Errorf("%s: summary %+v, expected %+v", test.name, *d.Summary, test.summary)
+		}
+		for _, pilot := range d.Pilots {
+			if pilot.FlightTimeAfter-pilot.FlightTimeBefore != float64(len(pilot.Added)-len(pilot.Removed))*240 ||
+				pilot.DaysOffBefore-pilot.DaysOffAfter != len(pilot.Added)-len(pilot.Removed) {
+				t.Errorf("%s: pilot %d has the flight time %v -> %v and the days off %d -> %d", test.name, pilot.Id,
+					pilot.FlightTimeBefore, pilot.FlightTimeAfter, pilot.DaysOffBefore, pilot.DaysOffAfter)
+			}
+		}
+	}
+}
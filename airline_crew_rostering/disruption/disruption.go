@@ -0,0 +1,249 @@
+package disruption
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"go-airline-crew-rostering/airline"
+)
+
+// Actions of the changes of a roster
+const (
+	Removed = "removed"
+	Added   = "added"
+)
+
+// Container for the functions related to the repair of a disrupted roster
+type DisruptionRepo interface {
+	Initialization() *Disruption
+	Repair() []*airline.Pilot
+	available() bool
+	assign() bool
+	swap() bool
+	changes()
+}
+
+// Events that disrupt a published roster
+type Events struct {
+	Unavailable map[int][]int   // days (from start of schedule) each pilot is unavailable
+	Cancelled   map[int]bool    // ids of the cancelled pairings
+	NewPairs    []*airline.Pair // pairings added after the roster was published
+}
+
+// Change of an assignment of the roster
+type Change struct {
+	Pilot  int
+	Pair   *airline.Pair
+	Action string // "removed" or "added"
+	Reason string
+}
+
+// Repair of a published roster that changes as few assignments as possible
+type Disruption struct {
+	events    *Events
+	Cutoff    time.Time             // assignments of pairings that start before the cutoff are frozen
+	pairs     map[int]*airline.Pair // pairings of the schedule, including the cancelled and the new ones
+	original  map[int]int           // pilot of each assigned pairing of the published roster
+	reasons   map[int]string        // reason for removing each pairing from its pilot
+	Changes   []*Change             // changed assignments, sorted by pilot and start of the pairing
+	Uncovered []*airline.Pair       // pairings left without a pilot by the disruption
+}
+
+func (d *Disruption) Initialization(events *Events, cutoff time.Time) *Disruption {
+	// Initialization of the repair of a roster
+	d.events = events
+	d.Cutoff = cutoff
+	d.pairs = make(map[int]*airline.Pair)
+	d.original = make(map[int]int)
+	d.reasons = make(map[int]string)
+	d.Changes = []*Change{}
+	d.Uncovered = []*airline.Pair{}
+	return d
+}
+
+func (d *Disruption) Repair(al *airline.Airline, roster []*airline.Pilot) []*airline.Pilot {
+	// Remove the cancelled pairings and the pairings of unavailable pilots from "roster"
+	// and assign them, along with the new pairings, to other pilots
+	// (a pairing is assigned directly if possible, otherwise by moving one pairing
+	// of a pilot to another pilot), leaving the frozen assignments untouched
+	// returns the repaired roster
+	for _, pair := range al.PairsArray[1:] {
+		d.pairs[pair.Id] = pair
+	}
+	open := []*airline.Pair{}
+	for _, pilot := range roster {
+		for i := 1; i <= pilot.AssignedLength; i++ {
+			pair := pilot.AssignedPairs[i]
+			d.original[pair.Id] = pilot.Id
+			if pair.Start.Before(d.Cutoff) {
+				if d.events.Cancelled[pair.Id] {
+					fmt.Printf("pairing %d started before the cutoff and is not cancelled\n", pair.Id)
+				}
+				continue
+			}
+			if d.events.Cancelled[pair.Id] {
+				d.reasons[pair.Id] = "pairing cancelled"
+			} else if !d.available(pilot.Id, pair) {
+				d.reasons[pair.Id] = "pilot unavailable"
+				open = append(open, pair)
+			} else {
+				continue
+			}
+			pilot.Remove(pair)
+			i--
+		}
+	}
+
+	// the cancelled pairings are no longer part of the schedule
+	pairs := []*airline.Pair{}
+	for _, pair := range al.PairsArray {
+		if !d.events.Cancelled[pair.Id] || pair.Start.Before(d.Cutoff) {
+			pairs = append(pairs, pair)
+		}
+	}
+	for _, pair := range d.events.NewPairs {
+		if pair.Start.Before(d.Cutoff) || !pair.Start.After(al.ScheduleStart) || !pair.End.Before(al.ScheduleEnd) {
+			fmt.Printf("new pairing %d starts before the cutoff or is outside the schedule and is ignored\n", pair.Id)
+			continue
+		}
+		if _, exists := d.pairs[pair.Id]; exists {
+			fmt.Printf("new pairing %d has the id of an existing pairing and is ignored\n", pair.Id)
+			continue
+		}
+		d.pairs[pair.Id] = pair
+		pairs = append(pairs, pair)
+		open = append(open, pair)
+	}
+	sort.SliceStable(pairs[1:], func(i int, j int) bool {
+		return pairs[i+1].Start.Before(pairs[j+1].Start)
+	})
+	al.PairsArray = pairs
+	al.CalculateAverageWorkload()
+
+	sort.SliceStable(open, func(i int, j int) bool {
+		return open[i].Start.Before(open[j].Start)
+	})
+	for _, pair := range open {
+		if !d.assign(al, roster, pair) && !d.swap(al, roster, pair) {
+			d.Uncovered = append(d.Uncovered, pair)
+		}
+	}
+	d.changes(roster)
+	return roster
+}
+
+func (d *Disruption) available(pilotId int, pair *airline.Pair) bool {
+	// returns true if the pilot is available on all days of the pairing
+	for _, day := range d.events.Unavailable[pilotId] {
+		if day >= pair.StartDay && day <= pair.EndDay {
+			return false
+		}
+	}
+	return true
+}
+
+func (d *Disruption) assign(al *airline.Airline, roster []*airline.Pilot, pair *airline.Pair) bool {
+	// Assign "pair" to the available pilot that can accept it and whose
+	// workload gets closest to the average workload
+	// returns true on success
+	var selectedPilot *airline.Pilot
+	selectedIndex := -1
+	increase := math.Inf(1)
+	for _, pilot := range roster {
+		if !d.available(pilot.Id, pair) {
+			continue
+		}
+		index := al.RestPeriodRule(pilot, pair)
+		if index == -1 || !al.DaysOffRule(pilot, pair) {
+			continue
+		}
+		deviation := math.Abs(pilot.FlightTime+pair.Duration-al.AverageWorkload) - math.Abs(pilot.FlightTime-al.AverageWorkload)
+		if deviation < increase {
+			selectedPilot = pilot
+			selectedIndex = index
+			increase = deviation
+		}
+	}
+	if selectedPilot == nil {
+		return false
+	}
+	selectedPilot.Add(pair, selectedIndex)
+	return true
+}
+
+func (d *Disruption) swap(al *airline.Airline, roster []*airline.Pilot, pair *airline.Pair) bool {
+	// Assign "pair" to an available pilot after moving one of the pilot's
+	// pairings, that is not frozen, to another pilot
+	// returns true on success
+	for _, pilot := range roster {
+		if !d.available(pilot.Id, pair) {
+			continue
+		}
+		for i := 1; i <= pilot.AssignedLength; i++ {
+			moved := pilot.AssignedPairs[i]
+			if moved.Start.Before(d.Cutoff) || moved.StartDay > pair.EndDay+al.Timespan() || moved.EndDay < pair.StartDay-al.Timespan() {
+				continue
+			}
+			pilot.Remove(moved)
+			index := al.RestPeriodRule(pilot, pair)
+			if index > -1 && al.DaysOffRule(pilot, pair) {
+				pilot.Add(pair, index)
+				others := []*airline.Pilot{}
+				for _, other := range roster {
+					if other != pilot {
+						others = append(others, other)
+					}
+				}
+				if d.assign(al, others, moved) {
+					if _, exists := d.reasons[moved.Id]; !exists {
+						d.reasons[moved.Id] = fmt.Sprintf("makes room for pairing %d", pair.Id)
+					}
+					return true
+				}
+				pilot.Remove(pair)
+			}
+			pilot.Add(moved, al.RestPeriodRule(pilot, moved))
+		}
+	}
+	return false
+}
+
+func (d *Disruption) changes(roster []*airline.Pilot) {
+	// Compare the repaired roster with the published roster
+	// and store the changed assignments
+	pilots := make(map[int]int) // pilot of each assigned pairing of the repaired roster
+	for _, pilot := range roster {
+		for _, pair := range pilot.AssignedPairs[1 : pilot.AssignedLength+1] {
+			pilots[pair.Id] = pilot.Id
+		}
+	}
+	for pairId, pilotId := range d.original {
+		if newPilot, assigned := pilots[pairId]; assigned && newPilot == pilotId {
+			continue
+		}
+		d.Changes = append(d.Changes, &Change{Pilot: pilotId, Pair: d.pairs[pairId], Action: Removed, Reason: d.reasons[pairId]})
+	}
+	for pairId, pilotId := range pilots {
+		originalPilot, assigned := d.original[pairId]
+		if assigned && originalPilot == pilotId {
+			continue
+		}
+		reason := "new pairing"
+		if assigned {
+			reason = fmt.Sprintf("replaces pilot %d", originalPilot)
+		}
+		d.Changes = append(d.Changes, &Change{Pilot: pilotId, Pair: d.pairs[pairId], Action: Added, Reason: reason})
+	}
+	sort.Slice(d.Changes, func(i int, j int) bool {
+		a, b := d.Changes[i], d.Changes[j]
+		if a.Pilot != b.Pilot {
+			return a.Pilot < b.Pilot
+		}
+		if !a.Pair.Start.Equal(b.Pair.Start) {
+			return a.Pair.Start.Before(b.Pair.Start)
+		}
+		return a.Action > b.Action
+	})
+}
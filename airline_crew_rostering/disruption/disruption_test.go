@@ -0,0 +1,150 @@
+package disruption_test
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"go-airline-crew-rostering/airline"
+	"go-airline-crew-rostering/disruption"
+	"go-airline-crew-rostering/graph"
+	"go-airline-crew-rostering/input"
+	"go-airline-crew-rostering/problem"
+
+	"golang.org/x/exp/slices"
+)
+
+func instance() *airline.Airline {
+	// returns an airline with two weeks of pairings and 45 pilots
+	return input.ReadInstance("../Pairings.csv", time.Date(2011, 11, 1, 0, 0, 0, 0, time.UTC), time.Date(2011, 11, 15, 0, 0, 0, 0, time.UTC), 45)
+}
+
+func published(al *airline.Airline) ([]*airline.Pilot, map[int]int) {
+	// returns a roster of "al" and the pilot of each of its pairings
+	pairGraph := new(graph.Graph)
+	pairGraph.Initialization(1)
+	pairGraph.Populate(al.PairsArray)
+	roster, _, _ := problem.ConstructSolution(al, pairGraph, 0, rand.New(rand.NewSource(3)))
+	pilots := make(map[int]int)
+	for _, pilot := range roster {
+		for _, pair := range pilot.AssignedPairs[1 : pilot.AssignedLength+1] {
+			pilots[pair.Id] = pilot.Id
+		}
+	}
+	return roster, pilots
+}
+
+func TestRepair(t *testing.T) {
+	day := func(days int, hour int) time.Time {
+		return time.Date(2011, 11, 1+days, hour, 0, 0, 0, time.UTC)
+	}
+	newPair := new(airline.Pair)
+	newPair.Initialization(1000000, day(0, 0))
+	newPair.Add(1000000, day(10, 9), day(10, 13), day(0, 0))
+
+	tests := []struct {
+		name        string
+		unavailable map[int][]int // days each pilot is unavailable
+		cancelFirst int           // pilot whose first pairing after the cutoff is cancelled (-1 for none)
+		newPairs    []*airline.Pair
+		cutoff      time.Time
+	}{
+		{"unavailable pilot", map[int][]int{0: {2, 3, 4, 5}, 1: {8}}, -1, nil, day(0, 0)},
+		{"cancelled pairing", map[int][]int{}, 2, nil, day(0, 0)},
+		{"frozen assignments", map[int][]int{0: {2, 3, 4, 5, 6, 7, 8, 9}}, -1, nil, day(5, 0)},
+		{"new pairing", map[int][]int{}, -1, []*airline.Pair{newPair}, day(0, 0)},
+	}
+	for _, test := range tests {
+		al := instance()
+		roster, original := published(al)
+		events := &disruption.Events{Unavailable: test.unavailable, Cancelled: map[int]bool{}, NewPairs: test.newPairs}
+		cancelled := -1
+		if test.cancelFirst >= 0 {
+			for _, pair := range roster[test.cancelFirst].AssignedPairs[1 : roster[test.cancelFirst].AssignedLength+1] {
+				if !pair.Start.Before(test.cutoff) {
+					cancelled = pair.Id
+					events.Cancelled[cancelled] = true
+					break
+				}
+			}
+		}
+		d := new(disruption.Disruption)
+		d.Initialization(events, test.cutoff)
+		repaired := d.Repair(al, roster)
+
+		uncovered := make(map[int]bool)
+		for _, pair := range d.Uncovered {
+			uncovered[pair.Id] = true
+		}
+		assigned := make(map[int]int)
+		for _, pilot := range repaired {
+			for i, pair := range pilot.AssignedPairs[1 : pilot.AssignedLength+1] {
+				if other, exists := assigned[pair.Id]; exists {
+					t.Errorf("%s: pairing %d is assigned to pilots %d and %d", test.name, pair.Id, other, pilot.Id)
+				}
+				assigned[pair.Id] = pilot.Id
+				// the repaired roster obeys the rules and the events
+				if i > 0 && pair.Start.Sub(pilot.AssignedPairs[i].End).Minutes() < float64(al.RestPeriod) {
+					t.Errorf("%s: pilot %d does not rest before pairing %d", test.name, pilot.Id, pair.Id)
+				}
+				for _, unavailable := range test.unavailable[pilot.Id] {
+					if unavailable >= pair.StartDay && unavailable <= pair.EndDay && !pair.Start.Before(test.cutoff) {
+						t.Errorf("%s: pilot %d is unavailable on day %d of pairing %d", test.name, pilot.Id, unavailable, pair.Id)
+					}
+				}
+				if pair.Start.Before(test.cutoff) && original[pair.Id] != pilot.Id {
+					t.Errorf("%s: frozen pairing %d moved from pilot %d to %d", test.name, pair.Id, original[pair.Id], pilot.Id)
+				}
+			}
+			if !pilot.DaysOffRuleChecker() {
+				t.Errorf("%s: pilot %d does not get the days off", test.name, pilot.Id)
+			}
+		}
+
+		// every pairing of the schedule is either assigned or uncovered, and the cancelled one is neither
+		for _, pair := range al.PairsArray[1:] {
+			if _, exists := assigned[pair.Id]; exists == uncovered[pair.Id] {
+				t.Errorf("%s: pairing %d is assigned %v and uncovered %v", test.name, pair.Id, exists, uncovered[pair.Id])
+			}
+		}
+		if cancelled >= 0 {
+			if _, exists := assigned[cancelled]; exists || slices.IndexFunc(al.PairsArray, func(pair *airline.Pair) bool { return pair.Id == cancelled }) >= 0 {
+				t.Errorf("%s: cancelled pairing %d is still part of the schedule", test.name, cancelled)
+			}
+			if slices.IndexFunc(d.Changes, func(change *disruption.Change) bool {
+				return change.Pair.Id == cancelled && change.Action == disruption.Removed && change.Reason == "pairing cancelled"
+			}) < 0 {
+				t.Errorf("%s: the removal of cancelled pairing %d is not a change", test.name, cancelled)
+			}
+		}
+		for _, pair := range test.newPairs {
+			if _, exists := assigned[pair.Id]; !exists && !uncovered[pair.Id] {
+				t.Errorf("%s: new pairing %d is neither assigned nor uncovered", test.name, pair.Id)
+			}
+		}
+
+		// the changes are exactly the assignments that differ from the published roster
+		changed := 0
+		for id, pilot := range assigned {
+			if originalPilot, exists := original[id]; !exists || originalPilot != pilot {
+				changed++
+			}
+		}
+		for id, pilot := range original {
+			if newPilot, exists := assigned[id]; !exists || newPilot != pilot {
+				changed++
+			}
+		}
+		if len(d.Changes) != changed {
+			t.Errorf("%s: %d changes, expected %d", test.name, len(d.Changes), changed)
+		}
+		if changed == 0 {
+			t.Errorf("%s: the events do not change the roster", test.name)
+		}
+		for _, change := range d.Changes {
+			if change.Reason == "" && change.Action == disruption.Added {
+				t.Errorf("%s: pairing %d is added to pilot %d without a reason", test.name, change.Pair.Id, change.Pilot)
+			}
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package experiment
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Container for the functions related to an experiment
+type ExperimentRepo interface {
+	Initialization() *Experiment
+	Aggregate()
+}
+
+// Results of one run of an experiment
+type Run struct {
+	Seed           int64     `json:"seed"`           // seed of the run
+	Cost           float64   `json:"cost"`           // cost of the best solution
+	Coverage       float64   `json:"coverage"`       // percentage of the pairings covered by the best solution
+	Jumps          int       `json:"jumps"`          // number of times a new global best was found
+	Similarity     float64   `json:"similarity"`     // average similarity between each solution and the global best
+	ValidSolutions int       `json:"validSolutions"` // number of valid solutions found
+	Time           float64   `json:"time"`           // execution time of the run (in seconds)
+	IterBestCost   []float64 `json:"iterBestCost"`   // best cost of each generation
+}
+
+// Summary of the values of a measure over the runs of a configuration
+type Summary struct {
+	Mean  float64 `json:"mean"`
+	Std   float64 `json:"std"`   // sample standard deviation
+	Best  float64 `json:"best"`  // smallest value (biggest if higher values of the measure are better)
+	Worst float64 `json:"worst"` // biggest value (smallest if higher values of the measure are better)
+}
+
+// Value of an algorithm parameter
+type Parameter struct {
+	Name  string  `json:"name"`
+	Value float64 `json:"value"`
+}
+
+// Parameter values of the algorithm along with the results of their runs
+type Configuration struct {
+	Parameters []*Parameter `json:"parameters"`
+	Runs       []*Run       `json:"runs"`
+	Cost       *Summary     `json:"cost"`
+	Coverage   *Summary     `json:"coverage"`
+	Jumps      *Summary     `json:"jumps"`
+	Similarity *Summary     `json:"similarity"`
+	Time       *Summary     `json:"time"`
+}
+
+// Runs of an algorithm with several seeds for every configuration of a parameter grid
+type Experiment struct {
+	Algorithm      string           `json:"algorithm"`   // name of the optimization algorithm
+	Filename       string           `json:"filename"`    // name of the file that contains the pairs
+	StartDate      time.Time        `json:"startDate"`   // start date of the schedule
+	EndDate        time.Time        `json:"endDate"`     // end date of the schedule
+	Pilots         int              `json:"pilots"`      // number of available pilots
+	Generations    int              `json:"generations"` // maximum number of generations of every run
+	Agents         int              `json:"agents"`      // number of agents of every run
+	Seed           int64            `json:"seed"`        // seed of the random number generator that drew the seeds of the runs
+	Seeds          []int64          `json:"seeds"`       // seeds of the runs (the same for every configuration)
+	Configurations []*Configuration `json:"configurations"`
+}
+
+func (e *Experiment) Initialization(algorithm string, names []string, grid [][]float64, seeds []int64) *Experiment {
+	// Initialize an experiment with a configuration for every combination
+	// of the values of the parameters in "grid" (grid[i] holds the values of names[i])
+	e.Algorithm = algorithm
+	e.Seeds = seeds
+	e.Configurations = []*Configuration{}
+	combinations := [][]float64{{}}
+	for i := range names {
+		next := [][]float64{}
+		for _, combination := range combinations {
+			for _, value := range grid[i] {
+				next = append(next, append(append([]float64{}, combination...), value))
+			}
+		}
+		combinations = next
+	}
+	for _, combination := range combinations {
+		configuration := &Configuration{Parameters: []*Parameter{}, Runs: make([]*Run, len(seeds))}
+		for i, name := range names {
+			configuration.Parameters = append(configuration.Parameters, &Parameter{Name: name, Value: combination[i]})
+		}
+		e.Configurations = append(e.Configurations, configuration)
+	}
+	return e
+}
+
+func (e *Experiment) Aggregate() {
+	// Summarize the measures of the runs of every configuration
+	for _, configuration := range e.Configurations {
+		costs, coverages, jumps, similarities, times := []float64{}, []float64{}, []float64{}, []float64{}, []float64{}
+		for _, run := range configuration.Runs {
+			costs = append(costs, run.Cost)
+			coverages = append(coverages, run.Coverage)
+			jumps = append(jumps, float64(run.Jumps))
+			similarities = append(similarities, run.Similarity)
+			times = append(times, run.Time)
+		}
+		configuration.Cost = Summarize(costs, HigherIsBetter("cost"))
+		configuration.Coverage = Summarize(coverages, HigherIsBetter("coverage"))
+		configuration.Jumps = Summarize(jumps, HigherIsBetter("jumps"))
+		configuration.Similarity = Summarize(similarities, HigherIsBetter("similarity"))
+		configuration.Time = Summarize(times, HigherIsBetter("time"))
+	}
+}
+
+func (c *Configuration) Name() string {
+	// returns the values of the parameters of the configuration
+	text := ""
+	for i, parameter := range c.Parameters {
+		if i > 0 {
+			text += " "
+		}
+		text += fmt.Sprintf("%s=%g", parameter.Name, parameter.Value)
+	}
+	return text
+}
+
+func (c *Configuration) Values() []float64 {
+	// returns the values of the parameters of the configuration
+	values := []float64{}
+	for _, parameter := range c.Parameters {
+		values = append(values, parameter.Value)
+	}
+	return values
+}
+
+func HigherIsBetter(measure string) bool {
+	// returns true if bigger values of "measure" are better (a better roster covers
+	// more pairings, and a search that improves more often stays closer to its best)
+	return measure == "coverage" || measure == "jumps" || measure == "similarity"
+}
+
+func Summarize(values []float64, higherIsBetter bool) *Summary {
+	// returns the mean, the sample standard deviation, the best
+	// and the worst of "values"
+	summary := &Summary{Mean: 0, Std: 0, Best: math.Inf(1), Worst: math.Inf(-1)}
+	for _, value := range values {
+		summary.Mean += value / float64(len(values))
+		summary.Best = math.Min(summary.Best, value)
+		summary.Worst = math.Max(summary.Worst, value)
+	}
+	for _, value := range values {
+		if len(values) > 1 {
+			summary.Std += (value - summary.Mean) * (value - summary.Mean) / float64(len(values)-1)
+		}
+	}
+	summary.Std = math.Sqrt(summary.Std)
+	if higherIsBetter {
+		summary.Best, summary.Worst = summary.Worst, summary.Best
+	}
+	return summary
+}
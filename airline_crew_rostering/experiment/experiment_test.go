@@ -0,0 +1,78 @@
+package experiment_test
+
+import (
+	"math"
+	"testing"
+
+	"go-airline-crew-rostering/experiment"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestInitialization(t *testing.T) {
+	// the experiment has a configuration for every combination of the grid values
+	e := new(experiment.Experiment)
+	e.Initialization("AOA", []string{"C1", "C2"}, [][]float64{{1, 2}, {3, 4, 5}}, []int64{7, 8})
+	expected := []string{"C1=1 C2=3", "C1=1 C2=4", "C1=1 C2=5", "C1=2 C2=3", "C1=2 C2=4", "C1=2 C2=5"}
+	if len(e.Configurations) != len(expected) {
+		t.Fatalf("%d configurations, expected %d", len(e.Configurations), len(expected))
+	}
+	for i, configuration := range e.Configurations {
+		if name := configuration.Name(); name != expected[i] {
+			t.Errorf("configuration %d = %s, expected %s", i, name, expected[i])
+		}
+		if len(configuration.Runs) != 2 {
+			t.Errorf("configuration %s has %d runs, expected 2", configuration.Name(), len(configuration.Runs))
+		}
+	}
+	if values := e.Configurations[4].Values(); !slices.Equal(values, []float64{2, 4}) {
+		t.Errorf("values = %v, expected [2 4]", values)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	tests := []struct {
+		name           string
+		values         []float64
+		higherIsBetter bool
+		summary        experiment.Summary
+	}{
+		{"lower is better", []float64{2, 4, 6}, false, experiment.Summary{Mean: 4, Std: 2, Best: 2, Worst: 6}},
+		{"higher is better", []float64{2, 4, 6}, true, experiment.Summary{Mean: 4, Std: 2, Best: 6, Worst: 2}},
+		{"one run", []float64{5}, true, experiment.Summary{Mean: 5, Std: 0, Best: 5, Worst: 5}},
+	}
+	for _, test := range tests {
+		summary := experiment.Summarize(test.values, test.higherIsBetter)
+		if math.Abs(summary.Mean-test.summary.Mean) > 1e-9 || math.Abs(summary.Std-test.summary.Std) > 1e-9 ||
+			summary.Best != test.summary.Best || summary.Worst != test.summary.Worst {
+			t.Errorf("%s: summary = %+v, expected %+v", test.name, *summary, test.summary)
+		}
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	// the best value of every measure follows its direction
+	e := new(experiment.Experiment)
+	e.Initialization("multiCSO", []string{"FL"}, [][]float64{{1}}, []int64{1, 2})
+	e.Configurations[0].Runs[0] = &experiment.Run{Seed: 1, Cost: 100, Coverage: 90, Jumps: 3, Similarity: 0.5, Time: 2}
+	e.Configurations[0].Runs[1] = &experiment.Run{Seed: 2, Cost: 200, Coverage: 95, Jumps: 5, Similarity: 0.7, Time: 1}
+	e.Aggregate()
+	c := e.Configurations[0]
+	tests := []struct {
+		measure string
+		summary *experiment.Summary
+		best    float64
+		worst   float64
+	}{
+		{"cost", c.Cost, 100, 200},
+		{"coverage", c.Coverage, 95, 90},
+		{"jumps", c.Jumps, 5, 3},
+		{"similarity", c.Similarity, 0.7, 0.5},
+		{"time", c.Time, 1, 2},
+	}
+	for _, test := range tests {
+		if test.summary.Best != test.best || test.summary.Worst != test.worst {
+			t.Errorf("%s: best and worst = %v %v, expected %v %v", test.measure, test.summary.Best, test.summary.Worst, test.best, test.worst)
+		}
+	}
+}
@@ -1,24 +1,27 @@
-package fitness
-
-import (
-	"math"
-
-	"go-airline-crew-rostering/airline"
-)
-
-func FitnessFunction(solution []*airline.Pilot, totalPairs int, averageWorkload float64) (float64, float64) {
-	// Fitness function for the airline crew rostering problem
-	// returns the solution's fitness and cost (cost is the
-	// sum of each pilot's deviation from the average workload)
-	pairsCovered := 0 // pairs covered by the solution
-	deviation := 0.0  // sum of each pilot's deviation from the average workload
-	for _, pilot := range solution {
-		pairsCovered += pilot.AssignedLength
-		deviation += math.Abs(averageWorkload - pilot.FlightTime)
-	}
-	cost := deviation
-	deviation += 1
-	deviation /= 750
-	fitness := 1/deviation + 1/float64(totalPairs-pairsCovered+1)*0.75
-	return fitness, cost
-}
+package fitness
+
+import (
+	"math"
+
+	"go-airline-crew-rostering/airline"
+)
+
+func Objectives(solution []*airline.Pilot, al *airline.Airline) []float64 {
+	// Objectives of the multi-objective airline crew rostering problem
+	// (all of them are minimized)
+	// returns the uncovered pairs, the sum of each pilot's deviation from the
+	// average workload, the total excess rest period (in hours) and the
+	// requested days off that were not granted
+	pairsCovered := 0
+	deviation := 0.0
+	rest := 0.0
+	unmetPreferences := 0
+	for _, pilot := range solution {
+		pairsCovered += pilot.AssignedLength
+		deviation += math.Abs(al.AverageWorkload - pilot.FlightTime)
+		rest += pilot.TotalRestPeriod(al) / 60
+		unmetPreferences += pilot.UnmetPreferences(al)
+	}
+	uncovered := float64(len(al.PairsArray) - 1 - pairsCovered)
+	return []float64{uncovered, deviation, rest, float64(unmetPreferences)}
+}
@@ -0,0 +1,114 @@
+package fitness
+
+import (
+	"math"
+
+	"go-airline-crew-rostering/airline"
+	"go-airline-crew-rostering/metrics"
+)
+
+// names of the terms that can be used by an objective
+const (
+	UncoveredPairs   = "uncoveredPairs"   // pairs that are not assigned to a pilot
+	UncoveredMinutes = "uncoveredMinutes" // flight time (in minutes) of the pairs that are not assigned to a pilot
+	Deviation        = "deviation"        // deviation of the pilots' flight time from the average workload
+	DaysOffSurplus   = "daysOffSurplus"   // days off of the pilots beyond the minimum days off
+	ExcessRest       = "excessRest"       // rest period (in minutes) beyond the minimum rest periods and days off
+	Fairness         = "fairness"         // fairness indicator of a dimension of the pilots' schedules
+)
+
+// Container for the functions related to an objective
+type ObjectiveRepo interface {
+	Evaluate() (float64, float64)
+	Value() float64
+}
+
+// weighted term of an objective
+type Term struct {
+	Name       string  `json:"name"`       // name of the term (one of the constants above)
+	Norm       string  `json:"norm"`       // norm used by the deviation term ("L1", "L2" or "max")
+	Dimension  string  `json:"dimension"`  // dimension used by the fairness term (see metrics.Dimensions)
+	Indicator  string  `json:"indicator"`  // indicator used by the fairness term ("gini", "spread" or "std")
+	Weight     float64 `json:"weight"`     // weight of the term in the solution's fitness
+	Normalizer float64 `json:"normalizer"` // scale of the term's contribution to the fitness (weight*normalizer when the term is 0)
+	CostWeight float64 `json:"costWeight"` // weight of the term in the solution's cost
+}
+
+// Objective of the airline crew rostering problem, as a list of weighted terms
+type Objective struct {
+	UnitCost float64 `json:"unitCost"` // cost of 1 unit of the solution's cost
+	Terms    []*Term `json:"terms"`
+}
+
+func DefaultObjective() *Objective {
+	// returns the objective used when no configuration is given, where the fitness
+	// rewards small deviation from the average workload and few uncovered pairs
+	// and the cost is the sum of each pilot's deviation from the average workload
+	return &Objective{
+		UnitCost: 32,
+		Terms: []*Term{
+			{Name: Deviation, Norm: "L1", Weight: 1, Normalizer: 750, CostWeight: 1},
+			{Name: UncoveredPairs, Weight: 0.75, Normalizer: 1, CostWeight: 0},
+		},
+	}
+}
+
+func (objective *Objective) Evaluate(solution []*airline.Pilot, al *airline.Airline) (float64, float64) {
+	// Fitness function for the airline crew rostering problem
+	// every term contributes weight*normalizer/(value+1) to the fitness
+	// and costWeight*value to the cost
+	// returns the solution's fitness and cost
+	fitness := 0.0
+	cost := 0.0
+	for _, term := range objective.Terms {
+		value := objective.Value(term, solution, al)
+		fitness += term.Weight * term.Normalizer / (value + 1)
+		cost += term.CostWeight * value
+	}
+	return fitness, cost
+}
+
+func (objective *Objective) Value(term *Term, solution []*airline.Pilot, al *airline.Airline) float64 {
+	// Calculate the value of a term of the objective for a solution
+	value := 0.0
+	switch term.Name {
+	case UncoveredPairs:
+		value = float64(len(al.PairsArray) - 1)
+		for _, pilot := range solution {
+			value -= float64(pilot.AssignedLength)
+		}
+	case UncoveredMinutes:
+		for _, pair := range al.PairsArray[1:] {
+			value += pair.Duration
+		}
+		for _, pilot := range solution {
+			value -= pilot.FlightTime
+		}
+	case Deviation:
+		for _, pilot := range solution {
+			deviation := math.Abs(al.AverageWorkload - pilot.FlightTime)
+			if term.Norm == "L2" {
+				value += deviation * deviation
+			} else if term.Norm == "max" {
+				value = math.Max(value, deviation)
+			} else {
+				value += deviation
+			}
+		}
+		if term.Norm == "L2" {
+			value = math.Sqrt(value)
+		}
+	case DaysOffSurplus:
+		minimumDaysOff := al.MinimumDaysOff() * al.ScheduleDuration / al.Timespan()
+		for _, pilot := range solution {
+			value += math.Max(0, float64(pilot.DaysOff()-minimumDaysOff))
+		}
+	case ExcessRest:
+		for _, pilot := range solution {
+			value += pilot.TotalRestPeriod(al)
+		}
+	case Fairness:
+		value = metrics.Indicator(term.Indicator, metrics.DimensionValues(term.Dimension, solution, al))
+	}
+	return value
+}
@@ -0,0 +1,118 @@
+package fitness_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"go-airline-crew-rostering/airline"
+	"go-airline-crew-rostering/fitness"
+	"go-airline-crew-rostering/graph"
+	"go-airline-crew-rostering/input"
+	"go-airline-crew-rostering/problem"
+)
+
+func instance(endDate time.Time, pilots int) *airline.Airline {
+	// returns an airline with the pairings from 2011-11-01 to "endDate"
+	return input.ReadInstance("../Pairings.csv", time.Date(2011, 11, 1, 0, 0, 0, 0, time.UTC), endDate, pilots)
+}
+
+func baselineFitness(solution []*airline.Pilot, totalPairs int, averageWorkload float64) (float64, float64) {
+	// fitness function used before the objective could be configured
+	pairsCovered := 0
+	deviation := 0.0
+	for _, pilot := range solution {
+		pairsCovered += pilot.AssignedLength
+		deviation += math.Abs(averageWorkload - pilot.FlightTime)
+	}
+	cost := deviation
+	deviation += 1
+	deviation /= 750
+	fitness := 1/deviation + 1/float64(totalPairs-pairsCovered+1)*0.75
+	return fitness, cost
+}
+
+func TestDefaultObjective(t *testing.T) {
+	// the default objective gives the fitness and the cost of the baseline fitness function
+	tests := []struct {
+		name   string
+		end    time.Time
+		pilots int
+	}{
+		{"two weeks", time.Date(2011, 11, 15, 0, 0, 0, 0, time.UTC), 45},
+		{"few pilots", time.Date(2011, 11, 15, 0, 0, 0, 0, time.UTC), 20},
+		{"four weeks", time.Date(2011, 11, 29, 0, 0, 0, 0, time.UTC), 60},
+	}
+	objective := fitness.DefaultObjective()
+	for _, test := range tests {
+		al := instance(test.end, test.pilots)
+		pairGraph := new(graph.Graph)
+		pairGraph.Initialization(1)
+		pairGraph.Populate(al.PairsArray)
+		// the empty roster and rosters built with different seeds
+		solutions := [][]*airline.Pilot{al.PilotsArray}
+		for seed := int64(1); seed <= 5; seed++ {
+			solution, _, _ := problem.ConstructSolution(al, pairGraph, 0, rand.New(rand.NewSource(seed)))
+			solutions = append(solutions, solution)
+		}
+		for i, solution := range solutions {
+			expectedFitness, expectedCost := baselineFitness(solution, len(al.PairsArray)-1, al.AverageWorkload)
+			fitness, cost := objective.Evaluate(solution, al)
+			if math.Abs(fitness-expectedFitness) > 1e-9*expectedFitness || math.Abs(cost-expectedCost) > 1e-9*expectedCost {
+				t.Errorf("%s, solution %d: fitness and cost = %v %v, expected %v %v", test.name, i, fitness, cost, expectedFitness, expectedCost)
+			}
+		}
+	}
+}
+
+func TestTerms(t *testing.T) {
+	// value of every term for a roster with a single pilot flying the first pairing
+	al := instance(time.Date(2011, 11, 15, 0, 0, 0, 0, time.UTC), 2)
+	first := al.PairsArray[1]
+	al.PilotsArray[0].Add(first, 1)
+	pairs, minutes := float64(len(al.PairsArray)-2), -first.Duration
+	for _, pair := range al.PairsArray[1:] {
+		minutes += pair.Duration
+	}
+	flown := al.PilotsArray[0].FlightTime
+	tests := []struct {
+		term  *fitness.Term
+		value float64
+	}{
+		{&fitness.Term{Name: fitness.UncoveredPairs}, pairs},
+		{&fitness.Term{Name: fitness.UncoveredMinutes}, minutes},
+		{&fitness.Term{Name: fitness.Deviation, Norm: "L1"}, math.Abs(al.AverageWorkload-flown) + al.AverageWorkload},
+		{&fitness.Term{Name: fitness.Deviation, Norm: "L2"}, math.Hypot(al.AverageWorkload-flown, al.AverageWorkload)},
+		{&fitness.Term{Name: fitness.Deviation, Norm: "max"}, math.Max(math.Abs(al.AverageWorkload-flown), al.AverageWorkload)},
+	}
+	objective := &fitness.Objective{UnitCost: 1}
+	for _, test := range tests {
+		if value := objective.Value(test.term, al.PilotsArray, al); math.Abs(value-test.value) > 1e-9 {
+			t.Errorf("%s %s = %v, expected %v", test.term.Name, test.term.Norm, value, test.value)
+		}
+	}
+
+	// every term adds weight*normalizer/(value+1) to the fitness and costWeight*value to the cost
+	objective.Terms = []*fitness.Term{
+		{Name: fitness.UncoveredPairs, Weight: 2, Normalizer: 10, CostWeight: 3},
+		{Name: fitness.UncoveredMinutes, Weight: 1, Normalizer: 1, CostWeight: 0.5},
+	}
+	fitnessValue, cost := objective.Evaluate(al.PilotsArray, al)
+	if math.Abs(fitnessValue-(20/(pairs+1)+1/(minutes+1))) > 1e-9 || math.Abs(cost-(3*pairs+0.5*minutes)) > 1e-9 {
+		t.Errorf("fitness and cost = %v %v, expected %v %v", fitnessValue, cost, 20/(pairs+1)+1/(minutes+1), 3*pairs+0.5*minutes)
+	}
+}
+
+func TestObjectiveFile(t *testing.T) {
+	// the example objective file is the default objective
+	objective, expected := input.ReadObjective("../objective.json"), fitness.DefaultObjective()
+	if objective.UnitCost != expected.UnitCost || len(objective.Terms) != len(expected.Terms) {
+		t.Fatalf("unit cost %v and %d terms, expected %v and %d", objective.UnitCost, len(objective.Terms), expected.UnitCost, len(expected.Terms))
+	}
+	for i, term := range objective.Terms {
+		if *term != *expected.Terms[i] {
+			t.Errorf("term %d = %+v, expected %+v", i, *term, *expected.Terms[i])
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package input
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"go-airline-crew-rostering/airline"
+	"go-airline-crew-rostering/disruption"
+)
+
+func ReadEvents(fileName string, scheduleStartDate time.Time) *disruption.Events {
+	// Read a csv file containing the events that disrupt a published roster,
+	// where each line has one of the forms
+	// "unavailable;pilot;YYYY-MM-DD[;YYYY-MM-DD]" (pilot unavailable from the first to the last date),
+	// "cancelled;pairing" and
+	// "new;<flight leg of a new pairing, in the format of the pairings file>"
+	// Returns the events
+	events := &disruption.Events{Unavailable: make(map[int][]int), Cancelled: make(map[int]bool), NewPairs: []*airline.Pair{}}
+	newPairs := make(map[int]*airline.Pair)
+	file, err := os.Open(fileName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+	reader := csv.NewReader(file)
+	reader.Comma = ';'
+	reader.FieldsPerRecord = -1
+	for {
+		event, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			log.Fatal(err)
+		}
+		line, _ := reader.FieldPos(0)
+		switch event[0] {
+		case "unavailable":
+			if len(event) < 3 {
+				log.Fatalf("%s:%d: invalid event %v (expected \"unavailable;pilot;YYYY-MM-DD[;YYYY-MM-DD]\")", fileName, line, event)
+			}
+			pilotId := parseInt(event[1], fileName, line)
+			from := parseDate(event[2], fileName, line)
+			to := from
+			if len(event) > 3 {
+				to = parseDate(event[3], fileName, line)
+			}
+			if to.Before(from) {
+				log.Fatalf("%s:%d: invalid event %v (the last date is before the first)", fileName, line, event)
+			}
+			for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+				events.Unavailable[pilotId] = append(events.Unavailable[pilotId], int(date.Sub(scheduleStartDate).Hours()/24))
+			}
+		case "cancelled":
+			if len(event) < 2 {
+				log.Fatalf("%s:%d: invalid event %v (expected \"cancelled;pairing\")", fileName, line, event)
+			}
+			pairId := parseInt(event[1], fileName, line)
+			events.Cancelled[pairId] = true
+		case "new":
+			if len(event) < 9 {
+				log.Fatalf("%s:%d: invalid event %v (expected \"new;pairing;leg;source;destination;YYYY-MM-DD;HH:MM;YYYY-MM-DD;HH:MM\")", fileName, line, event)
+			}
+			pairId := parseInt(event[1], fileName, line)
+			legId := parseInt(event[2], fileName, line)                                            // flight number
+			start := parseDate(event[5], fileName, line).Add(parseClock(event[6], fileName, line)) // start date and time
+			end := parseDate(event[7], fileName, line).Add(parseClock(event[8], fileName, line))   // end date and time
+			if !end.After(start) {
+				log.Fatalf("%s:%d: invalid event %v (the leg ends before it starts)", fileName, line, event)
+			}
+			pair, exists := newPairs[pairId]
+			if !exists {
+				pair = new(airline.Pair)
+				pair.Initialization(pairId, scheduleStartDate)
+				newPairs[pairId] = pair
+				events.NewPairs = append(events.NewPairs, pair)
+			}
+			pair.AddLeg(pairId, &airline.Leg{Number: legId, Source: event[3], Destination: event[4], Start: start, End: end}, scheduleStartDate)
+		default:
+			log.Fatalf("%s:%d: unknown event %q (options are \"unavailable\", \"cancelled\" or \"new\")", fileName, line, event[0])
+		}
+	}
+	return events
+}
+
+func parseClock(field string, fileName string, line int) time.Duration {
+	// Returns the time of day "HH:MM" in "field" of the given line of a csv file
+	// Terminates the application if the field is not a valid time of day
+	var hour, minute int
+	var rest string
+	if n, _ := fmt.Sscanf(strings.TrimSpace(field), "%d:%d%s", &hour, &minute, &rest); n != 2 || hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		log.Fatalf("%s:%d: invalid time %q (expected HH:MM)", fileName, line, field)
+	}
+	return time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute
+}
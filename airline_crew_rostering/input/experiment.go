@@ -0,0 +1,27 @@
+package input
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+
+	"go-airline-crew-rostering/experiment"
+)
+
+func ReadExperiment(fileName string) *experiment.Experiment {
+	// Read a json file written by the experiment command
+	// Returns the experiment with the measures of every run
+	e := new(experiment.Experiment)
+	file, err := os.Open(fileName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+	if err := json.NewDecoder(file).Decode(e); err != nil {
+		log.Fatal(err)
+	}
+	if len(e.Configurations) == 0 {
+		log.Fatalf("%s does not contain any configuration", fileName)
+	}
+	return e
+}
@@ -1,78 +1,207 @@
-package input
-
-import (
-	"encoding/csv"
-	"fmt"
-	"io"
-	"log"
-	"os"
-	"sort"
-	"strconv"
-	"time"
-
-	"go-airline-crew-rostering/airline"
-
-	"golang.org/x/exp/slices"
-)
-
-func ReadFile(fileName string, scheduleStartDate time.Time) []*airline.Pair {
-	// Read a csv file containing pairings and create a list of pairings
-	// The pairings in the file must be sorted by Id from smallest to biggest
-	// Returns the list of pairings
-	pairs := []*airline.Pair{}
-	file, err := os.Open(fileName)
-	var year, month, day, hour, minute int
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer file.Close()
-	reader := csv.NewReader(file)
-	reader.Comma = ';'
-	for {
-		flightLeg, err := reader.Read()
-		if err == io.EOF {
-			break
-		}
-		pairId, _ := strconv.Atoi(flightLeg[0]) // Id of pairing
-		// legId, _ := strconv.Atoi(flightLeg[1])
-		// source := flightLeg[2]
-		// destination := flightLeg[3]
-		fmt.Sscanf(flightLeg[4], "%d-%d-%d", &year, &month, &day)                      // start date
-		fmt.Sscanf(flightLeg[5], "%d:%d", &hour, &minute)                              // start time
-		start := time.Date(year, time.Month(month), day, hour, minute, 0, 0, time.UTC) // start date and time
-		fmt.Sscanf(flightLeg[6], "%d-%d-%d", &year, &month, &day)                      // end date
-		fmt.Sscanf(flightLeg[7], "%d:%d", &hour, &minute)                              // end time
-		end := time.Date(year, time.Month(month), day, hour, minute, 0, 0, time.UTC)   // end date and time
-		if pairId > len(pairs) {
-			// Check if the pairing already exists and create a new one if it does not
-			pair := new(airline.Pair)
-			pair.Initialization(pairId, scheduleStartDate)
-			pair.Add(pairId, start, end, scheduleStartDate)
-			pairs = slices.Insert(pairs, pairId-1, pair)
-		} else {
-			// Otherwise add the flightleg to the appropriate pairing
-			pairs[pairId-1].Add(pairId, start, end, scheduleStartDate)
-		}
-	}
-	return pairs
-}
-
-func FilterPairs(pairsArray []*airline.Pair, scheduleStart time.Time, scheduleEnd time.Time) []*airline.Pair {
-	// Filter list of pairings based on their start and end datetimes, using "scheduleStart"
-	// and "scheduleEnd" as cutoffs, and return the filtered list
-	filteredPairs := []*airline.Pair{}
-	for _, pair := range pairsArray {
-		if pair.Start.After(scheduleStart) && pair.End.Before(scheduleEnd) {
-			filteredPairs = append(filteredPairs, pair)
-		}
-	}
-	return filteredPairs
-}
-
-func SortPairs(pairsArray []*airline.Pair) []*airline.Pair {
-	// Sort pairings by their start datetime, from oldest to most recent
-	sort.Slice(pairsArray, func(i, j int) bool {
-		return pairsArray[i].Start.Before(pairsArray[j].Start)
-	})
-	return pairsArray
-}
+package input
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-airline-crew-rostering/airline"
+	"go-airline-crew-rostering/fitness"
+	"go-airline-crew-rostering/metrics"
+
+	"golang.org/x/exp/slices"
+)
+
+func ReadFile(fileName string, scheduleStartDate time.Time) []*airline.Pair {
+	// Read a csv file containing pairings and create a list of pairings
+	// The pairings in the file must be sorted by Id from smallest to biggest
+	// Returns the list of pairings
+	pairs := []*airline.Pair{}
+	file, err := os.Open(fileName)
+	var year, month, day, hour, minute int
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+	reader := csv.NewReader(file)
+	reader.Comma = ';'
+	for {
+		flightLeg, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		pairId, _ := strconv.Atoi(flightLeg[0])                                        // Id of pairing
+		legId, _ := strconv.Atoi(flightLeg[1])                                         // flight number
+		source := flightLeg[2]                                                         // departure airport
+		destination := flightLeg[3]                                                    // arrival airport
+		fmt.Sscanf(flightLeg[4], "%d-%d-%d", &year, &month, &day)                      // start date
+		fmt.Sscanf(flightLeg[5], "%d:%d", &hour, &minute)                              // start time
+		start := time.Date(year, time.Month(month), day, hour, minute, 0, 0, time.UTC) // start date and time
+		fmt.Sscanf(flightLeg[6], "%d-%d-%d", &year, &month, &day)                      // end date
+		fmt.Sscanf(flightLeg[7], "%d:%d", &hour, &minute)                              // end time
+		end := time.Date(year, time.Month(month), day, hour, minute, 0, 0, time.UTC)   // end date and time
+		leg := &airline.Leg{Number: legId, Source: source, Destination: destination, Start: start, End: end}
+		if pairId > len(pairs) {
+			// Check if the pairing already exists and create a new one if it does not
+			pair := new(airline.Pair)
+			pair.Initialization(pairId, scheduleStartDate)
+			pair.AddLeg(pairId, leg, scheduleStartDate)
+			pairs = slices.Insert(pairs, pairId-1, pair)
+		} else {
+			// Otherwise add the flightleg to the appropriate pairing
+			pairs[pairId-1].AddLeg(pairId, leg, scheduleStartDate)
+		}
+	}
+	return pairs
+}
+
+func FilterPairs(pairsArray []*airline.Pair, scheduleStart time.Time, scheduleEnd time.Time) []*airline.Pair {
+	// Filter list of pairings based on their start and end datetimes, using "scheduleStart"
+	// and "scheduleEnd" as cutoffs, and return the filtered list
+	filteredPairs := []*airline.Pair{}
+	for _, pair := range pairsArray {
+		if pair.Start.After(scheduleStart) && pair.End.Before(scheduleEnd) {
+			filteredPairs = append(filteredPairs, pair)
+		}
+	}
+	return filteredPairs
+}
+
+func SortPairs(pairsArray []*airline.Pair) []*airline.Pair {
+	// Sort pairings by their start datetime, from oldest to most recent
+	sort.Slice(pairsArray, func(i, j int) bool {
+		return pairsArray[i].Start.Before(pairsArray[j].Start)
+	})
+	return pairsArray
+}
+
+func ReadInstance(fileName string, startDate time.Time, endDate time.Time, pilots int) *airline.Airline {
+	// Create an airline instance with the pairings of "fileName" between the
+	// start and the end date and "pilots" pilots
+	// returns pointer to the airline
+	al := new(airline.Airline)
+	al.Initialization(660, 7, 2, startDate, endDate, pilots)
+
+	// create pairings
+	al.PairsArray = ReadFile(fileName, al.ScheduleStart)
+	al.PairsArray = FilterPairs(al.PairsArray, al.ScheduleStart, al.ScheduleEnd)
+	al.PairsArray = SortPairs(al.PairsArray)
+	root := new(airline.Pair) // create special root pair
+	root.Initialization(0, al.ScheduleStart)
+	al.PairsArray = slices.Insert(al.PairsArray, 0, root)
+
+	// create array of pilots
+	for i := 0; i < al.NumberOfPilots; i++ {
+		pilot := new(airline.Pilot)
+		pilot.Initialization(i, al.ScheduleDuration, root)
+		al.PilotsArray = append(al.PilotsArray, pilot)
+	}
+	al.CalculateAverageWorkload()
+	return al
+}
+
+func ReadPreferences(fileName string, scheduleStartDate time.Time) map[int][]int {
+	// Read a csv file containing the requested days off of the pilots,
+	// where each line has the form "pilot;YYYY-MM-DD"
+	// Returns a map from each pilot's id to its requested days off
+	// (as days from the start of the schedule)
+	preferences := make(map[int][]int)
+	file, err := os.Open(fileName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+	reader := csv.NewReader(file)
+	reader.Comma = ';'
+	reader.FieldsPerRecord = -1
+	for {
+		request, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			log.Fatal(err)
+		}
+		line, _ := reader.FieldPos(0)
+		if len(request) < 2 {
+			log.Fatalf("%s:%d: invalid request %v (expected \"pilot;YYYY-MM-DD\")", fileName, line, request)
+		}
+		pilotId := parseInt(request[0], fileName, line)
+		date := parseDate(request[1], fileName, line)
+		preferences[pilotId] = append(preferences[pilotId], int(date.Sub(scheduleStartDate).Hours()/24))
+	}
+	return preferences
+}
+
+func parseInt(field string, fileName string, line int) int {
+	// Returns the integer in "field" of the given line of a csv file
+	// Terminates the application if the field is not an integer
+	value, err := strconv.Atoi(strings.TrimSpace(field))
+	if err != nil {
+		log.Fatalf("%s:%d: invalid number %q", fileName, line, field)
+	}
+	return value
+}
+
+func parseDate(field string, fileName string, line int) time.Time {
+	// Returns the date "YYYY-MM-DD" in "field" of the given line of a csv file
+	// Terminates the application if the field is not a valid date
+	var year, month, day int
+	var rest string
+	if n, _ := fmt.Sscanf(strings.TrimSpace(field), "%d-%d-%d%s", &year, &month, &day, &rest); n != 3 {
+		log.Fatalf("%s:%d: invalid date %q (expected YYYY-MM-DD)", fileName, line, field)
+	}
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if date.Year() != year || int(date.Month()) != month || date.Day() != day {
+		log.Fatalf("%s:%d: invalid date %q (expected YYYY-MM-DD)", fileName, line, field)
+	}
+	return date
+}
+
+func ReadObjective(fileName string) *fitness.Objective {
+	// Read a json file containing the configuration of the objective
+	// (its unit cost and its weighted terms)
+	// Returns the objective
+	objective := new(fitness.Objective)
+	file, err := os.Open(fileName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+	if err := json.NewDecoder(file).Decode(objective); err != nil {
+		log.Fatal(err)
+	}
+	if objective.UnitCost == 0 {
+		objective.UnitCost = fitness.DefaultObjective().UnitCost
+	}
+	if len(objective.Terms) == 0 {
+		log.Fatalf("%s: the objective has no terms", fileName)
+	}
+	for _, term := range objective.Terms {
+		switch term.Name {
+		case fitness.UncoveredPairs, fitness.UncoveredMinutes, fitness.Deviation, fitness.DaysOffSurplus, fitness.ExcessRest:
+		case fitness.Fairness:
+			if !slices.Contains(metrics.Dimensions, term.Dimension) {
+				log.Fatalf("unknown dimension %q of the fairness term (options are %v)", term.Dimension, metrics.Dimensions)
+			}
+			if term.Indicator != metrics.Gini && term.Indicator != metrics.Spread && term.Indicator != metrics.StandardDeviation {
+				log.Fatalf("unknown indicator %q of the fairness term (options are \"gini\", \"spread\" or \"std\")", term.Indicator)
+			}
+		default:
+			log.Fatalf("unknown objective term %q", term.Name)
+		}
+		if term.Name == fitness.Deviation && term.Norm != "L1" && term.Norm != "L2" && term.Norm != "max" {
+			log.Fatalf("unknown norm %q of the deviation term (options are \"L1\", \"L2\" or \"max\")", term.Norm)
+		}
+		if term.Normalizer <= 0 {
+			log.Fatalf("%s: the normalizer of the %q term must be positive", fileName, term.Name)
+		}
+	}
+	return objective
+}
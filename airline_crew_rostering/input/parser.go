@@ -1,82 +1,366 @@
-package input
-
-import (
-	"fmt"
-	"os"
-	"time"
-
-	"github.com/akamensky/argparse"
-)
-
-// container for all possible arguments used by the application
-type ArgumentCollection struct {
-	Filename    *string   // name of the input file (relative or absolute path)
-	ResultsFile *string   // name of file to write the results of the application (only the name)
-	StartDate   time.Time // start date of the schedule
-	EndDate     time.Time // end date of the schedule
-	Pilots      *int      // number of available pilots
-	Seed        *int      // seed for random number generator
-	Generations *int      // maximum iterations of the optimization algorithm
-	Agents      *int      // number of agents of the optimization algorithm
-	FL          *float64  // FL parameter used by multi-step CSO
-	Constants   []float64 // list of parameters (C1, C2, C3, C4) used by AOA
-	Algorithm   string    // name of optimization algorithm to be used (options are "multiCSO" or "AOA")
-}
-
-func SetUpParser() *ArgumentCollection {
-	// Process all arguments given by the command line
-
-	args := new(ArgumentCollection)
-
-	// Set up all shared arguments
-	parser := argparse.NewParser("main", "Solve the airline crew rostering problem!")
-	args.Filename = parser.String("f", "filename", &argparse.Options{Help: "Name of the file that contains the pairs", Required: true})
-	args.ResultsFile = parser.String("", "results", &argparse.Options{Help: "Name of the file to write the results", Required: false, Default: "Output.xlsx"})
-	startDateArg := parser.String("", "startDate", &argparse.Options{Help: "Start date of the airline schedule given as YYYY-MM-DD", Required: false, Default: "1999-12-31"})
-	endDateArg := parser.String("", "endDate", &argparse.Options{Help: "End date of the airline schedule given as YYYY-MM-DD", Required: false, Default: "2021-1-1"})
-	args.Pilots = parser.Int("p", "pilots", &argparse.Options{Help: "Number of pilots available", Required: false, Default: 45})
-	args.Seed = parser.Int("", "seed", &argparse.Options{Help: "Seed for random number generator", Required: false, Default: -1})
-
-	args.Generations = parser.Int("g", "generations", &argparse.Options{Help: "Maximum iterations", Required: false, Default: 150})
-
-	// Set up multi-step CSO specific arguments
-	multiCSOParser := parser.NewCommand("multiCSO", "Use chicken swarm optimization to solve the problem")
-	chickens := multiCSOParser.Int("", "chickens", &argparse.Options{Help: "Number of chickens in swarm", Required: false, Default: 20})
-	args.FL = multiCSOParser.Float("", "FL", &argparse.Options{Help: "Parameter for CSO algorithm", Required: false, Default: 0.5})
-
-	// Set up AOA specific arguments
-	AOAParser := parser.NewCommand("AOA", "Use Archimedes optimization algorithm to solve the problem")
-	objects := AOAParser.Int("", "objects", &argparse.Options{Help: "Number of objects in the object collection", Required: false, Default: 20})
-	C1 := AOAParser.Float("", "C1", &argparse.Options{Help: "C1 Parameter for AOA algorithm", Required: false, Default: 2.0})
-	C2 := AOAParser.Float("", "C2", &argparse.Options{Help: "C2 Parameter for AOA algorithm", Required: false, Default: 6.0})
-	C3 := AOAParser.Float("", "C3", &argparse.Options{Help: "C3 Parameter for AOA algorithm", Required: false, Default: 1.0})
-	C4 := AOAParser.Float("", "C4", &argparse.Options{Help: "C4 Parameter for AOA algorithm", Required: false, Default: 0.5})
-
-	err := parser.Parse(os.Args) // parse arguments
-	if err != nil {
-		fmt.Println(parser.Usage(err))
-		return nil
-	}
-
-	// form the start and end of the schedule
-	var year, month, day int
-	fmt.Sscanf(*startDateArg, "%d-%d-%d", &year, &month, &day)
-	args.StartDate = time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
-	fmt.Sscanf(*endDateArg, "%d-%d-%d", &year, &month, &day)
-	args.EndDate = time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
-
-	// Adjust the non shared arguments based on the optimization algorithm selection
-	if multiCSOParser.Happened() {
-		args.Algorithm = "multiCSO"
-		args.Agents = chickens
-	} else if AOAParser.Happened() {
-		args.Algorithm = "AOA"
-		args.Agents = objects
-		args.Constants = []float64{*C1, *C2, *C3, *C4}
-	}
-
-	// Store the path to the output file (it will be saved in the output subfolder)
-	*args.ResultsFile = "./output/" + *args.ResultsFile
-	os.Mkdir("output", 0777) // create the subfolder, if it does not exist
-	return args
-}
+package input
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akamensky/argparse"
+)
+
+// container for all possible arguments used by the application
+type ArgumentCollection struct {
+	Filename    *string   // name of the input file (relative or absolute path)
+	ResultsFile *string   // name of file to write the results of the application (with the path of the output directory)
+	Output      *string   // directory of the results files
+	Suffixes    *[]string // suffixes added to the name of the results file ("timestamp", "seed" or "algorithm")
+	StartDate   time.Time // start date of the schedule
+	EndDate     time.Time // end date of the schedule
+	Pilots      *int      // number of available pilots
+	Seed        *int      // seed for random number generator
+	Objective   *string   // name of the file that contains the configuration of the objective
+	Generations *int      // maximum iterations of the optimization algorithm
+	Agents      *int      // number of agents of the optimization algorithm
+	FL          *float64  // FL parameter used by multi-step CSO
+	Constants   []float64 // list of parameters (C1, C2, C3, C4) used by AOA
+	Columns     *int      // maximum number of columns added per iteration of the column generation
+	Penalty     *float64  // cost of an uncovered pairing used by the column generation (in minutes)
+	Mutation    *float64  // probability of mutating the position of an edge used by NSGA-II
+	Preferences *string   // name of the file that contains the requested days off of the pilots
+	Workers     *int      // maximum number of solutions built concurrently
+	TimeLimit   *float64  // maximum execution time of the optimization algorithm (in minutes)
+	TargetCost  *float64  // cost that stops the optimization algorithm when it is reached
+	Stagnation  *int      // number of generations without improvement that stops the optimization algorithm
+	Coverage    *bool     // stop the optimization algorithm when all pairings are covered
+	Checkpoint  *string   // name of the file to save checkpoints of the run
+	Every       *int      // number of generations between two checkpoints
+	Resume      *string   // name of the checkpoint file to continue the run from
+	WarmStart   *string   // name of a results file whose roster is used as a starting point
+	WarmWeight  *float64  // position of the graph edges used by the roster of the warm start
+	Roster      *string   // name of the results file with the roster to repair (replan) or to score (score)
+	Events      *string   // name of the file that contains the events that disrupt the roster (replan only)
+	Cutoff      time.Time // assignments of pairings that start before the cutoff are frozen (replan only)
+	Islands     *int      // number of islands of the island model
+	IslandType  *string   // optimization algorithm of the islands ("multiCSO", "AOA" or "mixed")
+	Migration   *int      // number of generations between two migrations of the island model
+	TuneType    *string   // optimization algorithm whose parameters are tuned ("multiCSO" or "AOA")
+	Budget      *int      // maximum number of runs of the algorithm executed by the tuning
+	Candidates  *int      // number of configurations raced in every iteration of the tuning
+	Experiment  *string   // optimization algorithm of the experiment ("multiCSO" or "AOA")
+	Runs        *int      // number of seeds run for every configuration of the experiment
+	Experiments *[]string // names of the json files written by the experiment command (compare only)
+	Measure     *string   // measure of the runs compared by the statistical tests (compare only)
+	Alpha       *float64  // significance level of the statistical tests (compare only)
+	OldRoster   *string   // name of the results file with the old roster (diff only)
+	NewRoster   *string   // name of the results file with the new roster (diff only)
+	Formats     *[]string // formats of the results files ("xlsx", "json", "csv", "ics" or "html")
+	Calendar    *string   // calendar files written by the ics format ("pilot" for one file per pilot or "combined")
+	ReportTime  *int      // minutes between the report time of a pairing and its first departure (ics only)
+	Colouring   *string   // colour of the pairings of the Schedule sheet ("duration", "base" or "none")
+	Plots       *string   // format of the standalone plot files kept next to the results file ("none", "png" or "svg")
+	Algorithm   string    // name of optimization algorithm to be used (options are "multiCSO", "AOA", "columnGeneration", "NSGA", "islands", "replan", "score", "diff", "tune", "experiment" or "compare")
+	// instances used by the tuning (default is the shared file, dates and pilots)
+	Instances []*Instance
+	// values of each parameter of the experiment (FL for multiCSO, C1 to C4 for AOA)
+	Grid [][]float64
+}
+
+// problem instance used by the tuning of the algorithm parameters
+type Instance struct {
+	Filename  string    // name of the file that contains the pairs
+	StartDate time.Time // start date of the schedule
+	EndDate   time.Time // end date of the schedule
+	Pilots    int       // number of available pilots
+}
+
+func SetUpParser() *ArgumentCollection {
+	// Process all arguments given by the command line
+
+	args := new(ArgumentCollection)
+
+	// Set up all shared arguments
+	parser := argparse.NewParser("main", "Solve the airline crew rostering problem!")
+	args.Filename = parser.String("f", "filename", &argparse.Options{Help: "Name of the file that contains the pairs (required by all commands except compare)", Required: false, Default: ""})
+	args.ResultsFile = parser.String("", "results", &argparse.Options{Help: "Name of the file to write the results (relative to the output directory, unless it is an absolute path)", Required: false, Default: "Output.xlsx"})
+	args.Output = parser.String("o", "output", &argparse.Options{Help: "Directory of the results files (created along with its parents if it does not exist)", Required: false, Default: "output"})
+	args.Suffixes = parser.StringList("", "suffix", &argparse.Options{Help: "Suffix added to the name of the results files: timestamp, seed or algorithm (can be repeated, so that runs do not overwrite each other)", Required: false, Default: []string{},
+		Validate: func(suffixes []string) error {
+			for _, suffix := range suffixes {
+				if suffix != "timestamp" && suffix != "seed" && suffix != "algorithm" {
+					return fmt.Errorf("unknown suffix %q (options are timestamp, seed or algorithm)", suffix)
+				}
+			}
+			return nil
+		}})
+	startDateArg := parser.String("", "startDate", &argparse.Options{Help: "Start date of the airline schedule given as YYYY-MM-DD", Required: false, Default: "1999-12-31"})
+	endDateArg := parser.String("", "endDate", &argparse.Options{Help: "End date of the airline schedule given as YYYY-MM-DD", Required: false, Default: "2021-1-1"})
+	args.Pilots = parser.Int("p", "pilots", &argparse.Options{Help: "Number of pilots available", Required: false, Default: 45})
+	args.Seed = parser.Int("", "seed", &argparse.Options{Help: "Seed for random number generator", Required: false, Default: -1})
+
+	args.Objective = parser.String("", "objective", &argparse.Options{Help: "Name of the json file that contains the weighted terms of the objective", Required: false, Default: ""})
+
+	args.Generations = parser.Int("g", "generations", &argparse.Options{Help: "Maximum iterations", Required: false, Default: 150})
+	args.TimeLimit = parser.Float("", "timeLimit", &argparse.Options{Help: "Maximum execution time of the optimization algorithm in minutes (0 for no limit)", Required: false, Default: 0.0})
+	args.TargetCost = parser.Float("", "targetCost", &argparse.Options{Help: "Stop when a solution with at most this cost is found (negative for no target)", Required: false, Default: -1.0})
+	args.Stagnation = parser.Int("", "noImprovement", &argparse.Options{Help: "Stop after this number of generations without improvement (0 for no limit)", Required: false, Default: 0})
+	args.Coverage = parser.Flag("", "fullCoverage", &argparse.Options{Help: "Stop when a solution covering all pairings is found"})
+	args.Checkpoint = parser.String("", "checkpoint", &argparse.Options{Help: "Name of the file to save checkpoints of the run (also saved when the run is interrupted)", Required: false, Default: ""})
+	args.Every = parser.Int("", "checkpointEvery", &argparse.Options{Help: "Number of generations between two checkpoints", Required: false, Default: 50})
+	args.Resume = parser.String("", "resume", &argparse.Options{Help: "Name of the checkpoint file to continue the run from", Required: false, Default: ""})
+	args.WarmStart = parser.String("", "warmStart", &argparse.Options{Help: "Name of a results file (xlsx or json) whose roster is used as the starting point of the search", Required: false, Default: ""})
+	args.WarmWeight = parser.Float("", "warmStartPosition", &argparse.Options{Help: "Position of the graph edges used by the roster of the warm start (the rest of the edges start at 1)", Required: false, Default: 2.0})
+	args.Formats = parser.StringList("", "format", &argparse.Options{Help: "Format of the results: xlsx, json, csv, ics or html (can be repeated, the files are named after the results file; replan, diff, tune, experiment and compare do not support html, diff, tune, experiment and compare do not support ics)", Required: false, Default: []string{"xlsx"},
+		Validate: func(formats []string) error {
+			for _, format := range formats {
+				if format != "xlsx" && format != "json" && format != "csv" && format != "ics" && format != "html" {
+					return fmt.Errorf("unknown format %q (options are xlsx, json, csv, ics or html)", format)
+				}
+			}
+			return nil
+		}})
+	args.Calendar = parser.Selector("", "calendar", []string{"pilot", "combined"}, &argparse.Options{Help: "Calendars written by the ics format: one file per pilot or one combined feed", Required: false, Default: "pilot"})
+	args.ReportTime = parser.Int("", "reportTime", &argparse.Options{Help: "Minutes between the report time of a pairing and its first departure (ics format)", Required: false, Default: 60})
+	args.Colouring = parser.Selector("", "colouring", []string{"duration", "base", "none"}, &argparse.Options{Help: "Colour of the pairings of the Schedule sheet: by duration in days, by base (departure airport) or none", Required: false, Default: "duration"})
+	args.Plots = parser.Selector("", "plots", []string{"none", "png", "svg"}, &argparse.Options{Help: "Keep the plots of the results file as standalone files of this format", Required: false, Default: "none"})
+	args.Workers = parser.Int("w", "workers", &argparse.Options{Help: "Maximum number of solutions built concurrently", Required: false, Default: runtime.NumCPU()})
+
+	// Set up multi-step CSO specific arguments
+	multiCSOParser := parser.NewCommand("multiCSO", "Use chicken swarm optimization to solve the problem")
+	chickens := multiCSOParser.Int("", "chickens", &argparse.Options{Help: "Number of chickens in swarm", Required: false, Default: 20})
+	args.FL = multiCSOParser.Float("", "FL", &argparse.Options{Help: "Parameter for CSO algorithm", Required: false, Default: 0.5})
+
+	// Set up AOA specific arguments
+	AOAParser := parser.NewCommand("AOA", "Use Archimedes optimization algorithm to solve the problem")
+	objects := AOAParser.Int("", "objects", &argparse.Options{Help: "Number of objects in the object collection", Required: false, Default: 20})
+	C1 := AOAParser.Float("", "C1", &argparse.Options{Help: "C1 Parameter for AOA algorithm", Required: false, Default: 2.0})
+	C2 := AOAParser.Float("", "C2", &argparse.Options{Help: "C2 Parameter for AOA algorithm", Required: false, Default: 6.0})
+	C3 := AOAParser.Float("", "C3", &argparse.Options{Help: "C3 Parameter for AOA algorithm", Required: false, Default: 1.0})
+	C4 := AOAParser.Float("", "C4", &argparse.Options{Help: "C4 Parameter for AOA algorithm", Required: false, Default: 0.5})
+
+	// Set up column generation specific arguments
+	columnGenerationParser := parser.NewCommand("columnGeneration", "Use column generation to find a lower bound and a solution of the problem")
+	args.Columns = columnGenerationParser.Int("", "columns", &argparse.Options{Help: "Maximum number of columns added per iteration", Required: false, Default: 20})
+	args.Penalty = columnGenerationParser.Float("", "penalty", &argparse.Options{Help: "Cost of an uncovered pairing (in minutes)", Required: false, Default: 10000.0})
+
+	// Set up NSGA-II specific arguments
+	NSGAParser := parser.NewCommand("NSGA", "Use NSGA-II to find the trade-off between the objectives of the problem")
+	individuals := NSGAParser.Int("", "population", &argparse.Options{Help: "Number of individuals in the population", Required: false, Default: 20})
+	args.Mutation = NSGAParser.Float("", "mutation", &argparse.Options{Help: "Probability of mutating the position of an edge", Required: false, Default: 0.1})
+	args.Preferences = NSGAParser.String("", "preferences", &argparse.Options{Help: "Name of the file that contains the requested days off of the pilots", Required: false, Default: ""})
+
+	// Set up island model specific arguments
+	islandsParser := parser.NewCommand("islands", "Use several swarms that exchange their best solutions to solve the problem")
+	args.Islands = islandsParser.Int("", "islands", &argparse.Options{Help: "Number of islands", Required: false, Default: 4,
+		Validate: func(values []string) error { return atLeastOne("islands", values) }})
+	args.IslandType = islandsParser.Selector("", "islandAlgorithm", []string{"multiCSO", "AOA", "mixed"}, &argparse.Options{Help: "Optimization algorithm of the islands (mixed alternates multiCSO and AOA)", Required: false, Default: "mixed"})
+	args.Migration = islandsParser.Int("", "migrationInterval", &argparse.Options{Help: "Number of generations between two migrations (bigger than the generations for no migration)", Required: false, Default: 10,
+		Validate: func(values []string) error { return atLeastOne("migrationInterval", values) }})
+	islandAgents := islandsParser.Int("", "agents", &argparse.Options{Help: "Number of agents of each island", Required: false, Default: 20})
+	islandFL := islandsParser.Float("", "FL", &argparse.Options{Help: "Parameter for CSO algorithm", Required: false, Default: 0.5})
+	islandC1 := islandsParser.Float("", "C1", &argparse.Options{Help: "C1 Parameter for AOA algorithm", Required: false, Default: 2.0})
+	islandC2 := islandsParser.Float("", "C2", &argparse.Options{Help: "C2 Parameter for AOA algorithm", Required: false, Default: 6.0})
+	islandC3 := islandsParser.Float("", "C3", &argparse.Options{Help: "C3 Parameter for AOA algorithm", Required: false, Default: 1.0})
+	islandC4 := islandsParser.Float("", "C4", &argparse.Options{Help: "C4 Parameter for AOA algorithm", Required: false, Default: 0.5})
+
+	// Set up the arguments of the repair of a published roster
+	replanParser := parser.NewCommand("replan", "Repair a published roster after disruptions, changing as few assignments as possible")
+	args.Roster = replanParser.String("", "roster", &argparse.Options{Help: "Name of the results file (xlsx or json) with the published roster", Required: true})
+	args.Events = replanParser.String("", "events", &argparse.Options{Help: "Name of the file that contains the unavailable pilots, the cancelled pairings and the new pairings", Required: true})
+	cutoffArg := replanParser.String("", "cutoff", &argparse.Options{Help: "Assignments of pairings that start before this time are frozen, given as YYYY-MM-DD HH:MM (default is the start date)", Required: false, Default: ""})
+
+	// Set up the arguments of the validation of a roster
+	scoreParser := parser.NewCommand("score", "Validate and score the roster of a results file, for example after editing it by hand")
+	scoreRoster := scoreParser.String("", "roster", &argparse.Options{Help: "Name of the results file (xlsx or json) with the roster (the Pilot column of the Pairings sheet is read if it exists, otherwise the Schedule sheet)", Required: true})
+
+	// Set up the arguments of the differences between two rosters
+	diffParser := parser.NewCommand("diff", "Report the changes of every pilot between two rosters over the same pairings")
+	args.OldRoster = diffParser.String("", "old", &argparse.Options{Help: "Name of the results file (xlsx or json) with the old roster", Required: true})
+	args.NewRoster = diffParser.String("", "new", &argparse.Options{Help: "Name of the results file (xlsx or json) with the new roster", Required: true})
+
+	// Set up the arguments of the tuning of the algorithm parameters
+	tuneParser := parser.NewCommand("tune", "Race configurations of the algorithm parameters to find the best one (iterated F-race)")
+	args.TuneType = tuneParser.Selector("", "tuneAlgorithm", []string{"multiCSO", "AOA"}, &argparse.Options{Help: "Optimization algorithm whose parameters are tuned", Required: false, Default: "multiCSO"})
+	args.Budget = tuneParser.Int("", "budget", &argparse.Options{Help: "Maximum number of runs of the algorithm", Required: false, Default: 200})
+	args.Candidates = tuneParser.Int("", "candidates", &argparse.Options{Help: "Number of configurations raced in every iteration", Required: false, Default: 10})
+	tuneAgents := tuneParser.Int("", "agents", &argparse.Options{Help: "Number of agents of every run", Required: false, Default: 20})
+	instancesArg := tuneParser.StringList("", "instance", &argparse.Options{Help: "Instance given as file;YYYY-MM-DD;YYYY-MM-DD[;pilots] (can be repeated, default is the shared file, dates and pilots)", Required: false})
+
+	// Set up the arguments of the experiments with several seeds
+	experimentParser := parser.NewCommand("experiment", "Run an algorithm with several seeds for every configuration of a parameter grid")
+	args.Experiment = experimentParser.Selector("", "experimentAlgorithm", []string{"multiCSO", "AOA"}, &argparse.Options{Help: "Optimization algorithm of the experiment", Required: false, Default: "multiCSO"})
+	args.Runs = experimentParser.Int("", "runs", &argparse.Options{Help: "Number of seeds run for every configuration (the same seeds for all configurations)", Required: false, Default: 10})
+	experimentAgents := experimentParser.Int("", "agents", &argparse.Options{Help: "Number of agents of every run", Required: false, Default: 20})
+	gridFL := experimentParser.StringList("", "FL", &argparse.Options{Help: "Values of FL given as numbers or ranges from:to:step (can be repeated)", Required: false, Default: []string{"0.5"}})
+	gridC1 := experimentParser.StringList("", "C1", &argparse.Options{Help: "Values of C1 given as numbers or ranges from:to:step (can be repeated)", Required: false, Default: []string{"2"}})
+	gridC2 := experimentParser.StringList("", "C2", &argparse.Options{Help: "Values of C2 given as numbers or ranges from:to:step (can be repeated)", Required: false, Default: []string{"6"}})
+	gridC3 := experimentParser.StringList("", "C3", &argparse.Options{Help: "Values of C3 given as numbers or ranges from:to:step (can be repeated)", Required: false, Default: []string{"1"}})
+	gridC4 := experimentParser.StringList("", "C4", &argparse.Options{Help: "Values of C4 given as numbers or ranges from:to:step (can be repeated)", Required: false, Default: []string{"0.5"}})
+
+	// Set up the arguments of the comparison of experiments
+	compareParser := parser.NewCommand("compare", "Compare the runs of experiments with statistical tests and averaged convergence curves")
+	args.Experiments = compareParser.StringList("", "experiments", &argparse.Options{Help: "Name of a json file written by the experiment command (can be repeated, every configuration is a result set)", Required: true})
+	args.Measure = compareParser.Selector("", "measure", []string{"cost", "coverage", "jumps", "similarity", "time"}, &argparse.Options{Help: "Measure of the runs compared by the tests (higher coverage, jumps and similarity are better)", Required: false, Default: "cost"})
+	args.Alpha = compareParser.Float("", "alpha", &argparse.Options{Help: "Significance level of the tests", Required: false, Default: 0.05})
+
+	err := parser.Parse(os.Args) // parse arguments
+	if err != nil {
+		fmt.Println(parser.Usage(err))
+		return nil
+	}
+
+	// form the start and end of the schedule
+	var year, month, day int
+	fmt.Sscanf(*startDateArg, "%d-%d-%d", &year, &month, &day)
+	args.StartDate = time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	fmt.Sscanf(*endDateArg, "%d-%d-%d", &year, &month, &day)
+	args.EndDate = time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	args.Cutoff = args.StartDate
+	if *cutoffArg != "" {
+		var hour, minute int
+		var rest string
+		n, _ := fmt.Sscanf(*cutoffArg, "%d-%d-%d %d:%d%s", &year, &month, &day, &hour, &minute, &rest)
+		args.Cutoff = time.Date(year, time.Month(month), day, hour, minute, 0, 0, time.UTC)
+		if n != 5 || args.Cutoff.Month() != time.Month(month) || args.Cutoff.Day() != day || args.Cutoff.Hour() != hour || args.Cutoff.Minute() != minute {
+			log.Fatalf("invalid cutoff %q (expected YYYY-MM-DD HH:MM)", *cutoffArg)
+		}
+	}
+
+	// form the instances of the tuning
+	args.Instances = []*Instance{}
+	for _, instanceArg := range *instancesArg {
+		fields := strings.Split(instanceArg, ";")
+		if len(fields) < 3 {
+			fmt.Println("invalid instance:", instanceArg)
+			return nil
+		}
+		instance := &Instance{Filename: fields[0], Pilots: *args.Pilots}
+		fmt.Sscanf(fields[1], "%d-%d-%d", &year, &month, &day)
+		instance.StartDate = time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+		fmt.Sscanf(fields[2], "%d-%d-%d", &year, &month, &day)
+		instance.EndDate = time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+		if len(fields) > 3 {
+			fmt.Sscanf(fields[3], "%d", &instance.Pilots)
+		}
+		args.Instances = append(args.Instances, instance)
+	}
+	if len(args.Instances) == 0 {
+		args.Instances = append(args.Instances, &Instance{Filename: *args.Filename, StartDate: args.StartDate, EndDate: args.EndDate, Pilots: *args.Pilots})
+	}
+
+	// Adjust the non shared arguments based on the optimization algorithm selection
+	if multiCSOParser.Happened() {
+		args.Algorithm = "multiCSO"
+		args.Agents = chickens
+	} else if AOAParser.Happened() {
+		args.Algorithm = "AOA"
+		args.Agents = objects
+		args.Constants = []float64{*C1, *C2, *C3, *C4}
+	} else if columnGenerationParser.Happened() {
+		args.Algorithm = "columnGeneration"
+		args.Agents = new(int)
+		*args.Agents = 1 // the master problem is the only agent
+	} else if NSGAParser.Happened() {
+		args.Algorithm = "NSGA"
+		args.Agents = individuals
+	} else if islandsParser.Happened() {
+		args.Algorithm = "islands"
+		args.Agents = islandAgents
+		args.FL = islandFL
+		args.Constants = []float64{*islandC1, *islandC2, *islandC3, *islandC4}
+	} else if replanParser.Happened() {
+		args.Algorithm = "replan"
+		args.Agents = new(int)
+		*args.Agents = 1
+	} else if scoreParser.Happened() {
+		args.Algorithm = "score"
+		args.Roster = scoreRoster
+		args.Agents = new(int)
+		*args.Agents = 1
+	} else if diffParser.Happened() {
+		args.Algorithm = "diff"
+		args.Agents = new(int)
+		*args.Agents = 1
+	} else if tuneParser.Happened() {
+		args.Algorithm = "tune"
+		args.Agents = tuneAgents
+	} else if experimentParser.Happened() {
+		args.Algorithm = "experiment"
+		args.Agents = experimentAgents
+		args.Grid = [][]float64{parseGrid(*gridFL)}
+		if *args.Experiment == "AOA" {
+			args.Grid = [][]float64{parseGrid(*gridC1), parseGrid(*gridC2), parseGrid(*gridC3), parseGrid(*gridC4)}
+		}
+	} else if compareParser.Happened() {
+		args.Algorithm = "compare"
+		args.Agents = new(int)
+		*args.Agents = 1
+	}
+	if args.Algorithm != "compare" && *args.Filename == "" {
+		fmt.Println(parser.Usage("[-f|--filename] is required"))
+		return nil
+	}
+
+	// Store the path to the output file (it will be saved in the output directory)
+	if !filepath.IsAbs(*args.ResultsFile) {
+		*args.ResultsFile = filepath.Join(*args.Output, *args.ResultsFile)
+	}
+	// create the directory of the output file, if it does not exist
+	if err := os.MkdirAll(filepath.Dir(*args.ResultsFile), 0777); err != nil {
+		log.Fatal("cannot create the output directory: ", err)
+	}
+	return args
+}
+
+func ResultsName(resultsFile string, suffixes []string, algorithm string, seed int64, start time.Time) string {
+	// returns the name of the results file followed by the requested suffixes
+	// (for example "Output_multiCSO_42.xlsx")
+	extension := filepath.Ext(resultsFile)
+	name := strings.TrimSuffix(resultsFile, extension)
+	for _, suffix := range suffixes {
+		switch suffix {
+		case "timestamp":
+			name += fmt.Sprintf("_%s-%03d", start.Format("20060102-150405"), start.Nanosecond()/int(time.Millisecond))
+		case "seed":
+			name += "_" + strconv.FormatInt(seed, 10)
+		case "algorithm":
+			name += "_" + algorithm
+		}
+	}
+	return name + extension
+}
+
+func atLeastOne(name string, values []string) error {
+	// returns an error if a value of the integer argument "name" is smaller than 1
+	for _, value := range values {
+		if number, err := strconv.Atoi(value); err == nil && number < 1 {
+			return fmt.Errorf("%s must be at least 1", name)
+		}
+	}
+	return nil
+}
+
+func parseGrid(values []string) []float64 {
+	// returns the values of a parameter of the experiment, where every
+	// value is either a number or a range given as from:to:step
+	grid := []float64{}
+	for _, value := range values {
+		var from, to, step float64
+		if n, _ := fmt.Sscanf(value, "%g:%g:%g", &from, &to, &step); n == 3 && step > 0 {
+			// the values are rounded to avoid the accumulated error of the step
+			for i := 0; from+float64(i)*step <= to+step/2; i++ {
+				grid = append(grid, math.Round((from+float64(i)*step)*1e6)/1e6)
+			}
+		} else if n >= 1 {
+			grid = append(grid, from)
+		} else {
+			fmt.Println("invalid parameter value:", value)
+		}
+	}
+	return grid
+}
@@ -0,0 +1,30 @@
+package input_test
+
+import (
+	"os"
+	"testing"
+
+	"go-airline-crew-rostering/input"
+)
+
+func TestIslandArguments(t *testing.T) {
+	// the island model needs at least one island and one generation between migrations
+	tests := []struct {
+		arguments []string
+		valid     bool
+	}{
+		{[]string{}, true},
+		{[]string{"--islands", "1", "--migrationInterval", "1"}, true},
+		{[]string{"--islands", "0"}, false},
+		{[]string{"--islands", "-2"}, false},
+		{[]string{"--migrationInterval", "0"}, false},
+	}
+	arguments := os.Args
+	defer func() { os.Args = arguments }()
+	for _, test := range tests {
+		os.Args = append([]string{"main", "islands", "-f", "../Pairings.csv", "-o", t.TempDir()}, test.arguments...)
+		if args := input.SetUpParser(); (args != nil) != test.valid {
+			t.Errorf("arguments %v are valid %v, expected %v", test.arguments, args != nil, test.valid)
+		}
+	}
+}
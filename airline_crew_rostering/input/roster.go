@@ -0,0 +1,229 @@
+package input
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"go-airline-crew-rostering/airline"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Assignment of a roster that cannot be part of the schedule
+type Violation struct {
+	Pilot int
+	Pair  int
+	Rule  string // rule broken by the assignment
+}
+
+// Assignment of a pairing to a pilot, as read from a roster
+type assignment struct {
+	pilot int
+	pair  int
+	start time.Time // start of the pairing (zero if the roster does not store it)
+}
+
+// Roster of the json results file (only the fields needed to rebuild the assignments)
+type rosterRecord struct {
+	Roster []struct {
+		Pilot    int `json:"pilot"`
+		Pairings []struct {
+			Id    int       `json:"id"`
+			Start time.Time `json:"start"`
+		} `json:"pairings"`
+	} `json:"roster"`
+}
+
+func ReadRoster(fileName string, al *airline.Airline) []*airline.Pilot {
+	// Read the roster of a results file and assign its pairings to the pilots of "al"
+	// (the assignments that cannot be part of the schedule of "al" are ignored)
+	// Returns the list of pilots with their assigned pairings
+	pilots, violations := ValidateRoster(fileName, al)
+	if len(violations) > 0 {
+		fmt.Println(len(violations), "assignments of the roster break the rules and are ignored")
+	}
+	return pilots
+}
+
+func ValidateRoster(fileName string, al *airline.Airline) ([]*airline.Pilot, []*Violation) {
+	// Read the roster of a results file, either the json file or the excel file,
+	// and assign its pairings to the pilots of "al" in chronological order
+	// Returns the list of pilots and the assignments that are ignored because they
+	// refer to unknown pilots or pairings or break the rules of the schedule
+	return buildRoster(fileName, al, true)
+}
+
+func ReadRawRoster(fileName string, al *airline.Airline) ([]*airline.Pilot, []*Violation) {
+	// Read the roster of a results file as it is stored, assigning its pairings to the pilots
+	// of "al" even when they break the rules of the schedule
+	// Returns the list of pilots and the assignments that break the rules (which are kept)
+	// or refer to unknown pilots or pairings (which are ignored)
+	return buildRoster(fileName, al, false)
+}
+
+func buildRoster(fileName string, al *airline.Airline, enforce bool) ([]*airline.Pilot, []*Violation) {
+	// Read the roster of a results file and assign its pairings to the pilots of "al"
+	// (the assignments that break the rules are ignored only if "enforce" is true)
+	var assignments []*assignment
+	if strings.EqualFold(filepath.Ext(fileName), ".json") {
+		assignments = readJSONRoster(fileName)
+	} else {
+		assignments = readWorkbookRoster(fileName)
+	}
+
+	pairs := make(map[int]*airline.Pair)
+	for _, pair := range al.PairsArray[1:] {
+		pairs[pair.Id] = pair
+	}
+	violations := []*Violation{}
+	assigned := make(map[int]int)               // pilot of each assigned pairing
+	pilotPairs := make(map[int][]*airline.Pair) // pairings of each pilot
+	for _, a := range assignments {
+		pair, pairExists := pairs[a.pair]
+		if a.pilot < 0 || a.pilot >= al.NumberOfPilots {
+			violations = append(violations, &Violation{a.pilot, a.pair, "unknown pilot"})
+		} else if !pairExists {
+			violations = append(violations, &Violation{a.pilot, a.pair, "pairing is not part of the schedule"})
+		} else if !a.start.IsZero() && !a.start.Equal(pair.Start) {
+			violations = append(violations, &Violation{a.pilot, a.pair, "pairing differs from the pairings file"})
+		} else if pilot, exists := assigned[a.pair]; exists {
+			violations = append(violations, &Violation{a.pilot, a.pair, fmt.Sprintf("pairing is already assigned to pilot %d", pilot)})
+			if !enforce {
+				pilotPairs[a.pilot] = append(pilotPairs[a.pilot], pair)
+			}
+		} else {
+			assigned[a.pair] = a.pilot
+			pilotPairs[a.pilot] = append(pilotPairs[a.pilot], pair)
+		}
+	}
+
+	pilots := []*airline.Pilot{}
+	for i := 0; i < al.NumberOfPilots; i++ {
+		pilot := new(airline.Pilot)
+		pilot.Initialization(i, al.ScheduleDuration, al.PairsArray[0])
+		sort.Slice(pilotPairs[i], func(a int, b int) bool {
+			return pilotPairs[i][a].Start.Before(pilotPairs[i][b].Start)
+		})
+		// the rules are checked against the valid assignments of the pilot, while the
+		// pilot of the roster keeps the assignments that break them unless "enforce" is true
+		valid := pilot
+		if !enforce {
+			valid = new(airline.Pilot)
+			valid.Initialization(i, al.ScheduleDuration, al.PairsArray[0])
+		}
+		for _, pair := range pilotPairs[i] {
+			index := al.RestPeriodRule(valid, pair)
+			if index < 0 {
+				violations = append(violations, &Violation{i, pair.Id, "rest period"})
+			} else if !al.DaysOffRule(valid, pair, true) {
+				violations = append(violations, &Violation{i, pair.Id, "days off"})
+			} else {
+				valid.Add(pair, index)
+			}
+			if !enforce {
+				// the pairings are sorted, so the pairing is the last one of the pilot
+				pilot.Add(pair, pilot.AssignedLength+1)
+			}
+		}
+		pilots = append(pilots, pilot)
+	}
+	return pilots, violations
+}
+
+func readJSONRoster(fileName string) []*assignment {
+	// returns the assignments of the roster of a json results file
+	record := new(rosterRecord)
+	file, err := os.Open(fileName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+	if err := json.NewDecoder(file).Decode(record); err != nil {
+		log.Fatal(err)
+	}
+	assignments := []*assignment{}
+	for _, pilot := range record.Roster {
+		for _, pair := range pilot.Pairings {
+			assignments = append(assignments, &assignment{pilot.Pilot, pair.Id, pair.Start})
+		}
+	}
+	return assignments
+}
+
+func readWorkbookRoster(fileName string) []*assignment {
+	// returns the assignments of the roster of an excel results file
+	// The "Pilot" column of the "Pairings" sheet is read if it exists, since it is the
+	// easiest to edit, otherwise the assignments are read from the "Schedule" sheet
+	f, err := excelize.OpenFile(fileName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+	if header, _ := f.GetCellValue("Pairings", "J4"); header == "Pilot" {
+		return readPairingsSheet(f, "Pairings")
+	}
+	return readScheduleSheet(f, "Schedule")
+}
+
+func readPairingsSheet(f *excelize.File, sheetName string) []*assignment {
+	// returns the assignments of the "Pairings" sheet, where every row has the id
+	// of a pairing in the column G and the id of its pilot in the column J
+	// (the pairings without a pilot are not assigned)
+	rows, err := f.GetRows(sheetName, excelize.Options{RawCellValue: true})
+	if err != nil {
+		log.Fatal(err)
+	}
+	assignments := []*assignment{}
+	for i, row := range rows {
+		var pairId, pilotId int
+		if i < 4 || len(row) < 10 || strings.TrimSpace(row[9]) == "" {
+			continue
+		}
+		if _, err := fmt.Sscanf(row[6], "%d", &pairId); err != nil {
+			continue
+		}
+		if _, err := fmt.Sscanf(row[9], "%d", &pilotId); err != nil {
+			continue
+		}
+		assignments = append(assignments, &assignment{pilot: pilotId, pair: pairId})
+	}
+	return assignments
+}
+
+func readScheduleSheet(f *excelize.File, sheetName string) []*assignment {
+	// returns the assignments of the "Schedule" sheet, where every assigned pairing
+	// has an input message titled "Pair <id>" on its start cell
+	dataValidations, err := f.GetDataValidations(sheetName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	assignments := []*assignment{}
+	for _, dataValidation := range dataValidations {
+		var pairId, pilotId int
+		if dataValidation.PromptTitle == nil {
+			continue
+		}
+		if _, err := fmt.Sscanf(*dataValidation.PromptTitle, "Pair %d", &pairId); err != nil {
+			continue
+		}
+		// the pilot's id is in the column B of the row of the cell
+		cell := strings.Split(dataValidation.Sqref, ":")[0]
+		_, row, err := excelize.CellNameToCoordinates(cell)
+		if err != nil {
+			continue
+		}
+		pilotCell, _ := excelize.CoordinatesToCellName(2, row)
+		value, _ := f.GetCellValue(sheetName, pilotCell)
+		if _, err := fmt.Sscanf(value, "%d", &pilotId); err != nil {
+			continue
+		}
+		assignments = append(assignments, &assignment{pilot: pilotId, pair: pairId})
+	}
+	return assignments
+}
@@ -0,0 +1,231 @@
+package island
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"go-airline-crew-rostering/airline"
+	"go-airline-crew-rostering/graph"
+	"go-airline-crew-rostering/metrics"
+	"go-airline-crew-rostering/stopping"
+)
+
+// Container for the functions related to the island model
+type IslandModelRepo interface {
+	Initialization() *IslandModel
+	Add()
+	Run() []*airline.Pilot
+	best() (float64, []*airline.Pilot)
+	migrate()
+	aggregate()
+}
+
+// Optimization algorithm that can run on an island
+type Algorithm interface {
+	Begin()                                                                 // calculate the metrics of the initial solutions
+	Generation(al *airline.Airline, pairGraph *graph.Graph, generation int) // execute one generation
+	Finish(al *airline.Airline, reason string, generations int)             // optimize the solutions and complete the metrics
+	Best() ([]*airline.Pilot, float64)                                      // best solution and its fitness
+	Immigrate(al *airline.Airline, solution []*airline.Pilot)               // replace the worst solution
+}
+
+// Island that runs an optimization algorithm on its own graph
+type Island struct {
+	Algorithm Algorithm
+	Graph     *graph.Graph     // graph used only by the agents of the island
+	Mtr       *metrics.Metrics // metrics of the island's algorithm
+	metrics   *metrics.Island  // metrics reported for the island
+}
+
+// Independent swarms that periodically exchange their best solutions
+type IslandModel struct {
+	Islands        []*Island
+	maxGenerations int                // Maximum number of generations executed by every island
+	interval       int                // Number of generations between two migrations (0 for no migration)
+	criteria       *stopping.Criteria // Criteria used to stop all islands before "maxGenerations"
+	Mtr            *metrics.Metrics   // Metrics aggregated over all islands
+}
+
+func (model *IslandModel) Initialization(maxGenerations int, interval int, Mtr *metrics.Metrics, criteria *stopping.Criteria) *IslandModel {
+	// Initialize an island model without islands
+	model.Islands = []*Island{}
+	model.maxGenerations = maxGenerations
+	model.interval = interval
+	model.criteria = criteria
+	model.Mtr = Mtr
+	return model
+}
+
+func (model *IslandModel) Add(name string, algorithm Algorithm, pairGraph *graph.Graph, Mtr *metrics.Metrics) {
+	// Add an island that runs "algorithm" on "pairGraph"
+	island := &Island{Algorithm: algorithm, Graph: pairGraph, Mtr: Mtr}
+	island.metrics = &metrics.Island{Algorithm: name, Immigrants: 0, Mtr: Mtr}
+	model.Islands = append(model.Islands, island)
+}
+
+func (model *IslandModel) Run(ctx context.Context, al *airline.Airline) []*airline.Pilot {
+	// Main body of the island model: every island executes one generation of its
+	// algorithm at a time and the best solutions migrate every "interval" generations
+	// Returns the best solution of all islands
+	for _, island := range model.Islands {
+		island.Algorithm.Begin()
+	}
+	bestCost, bestSolution := model.best()
+	reason := model.criteria.Stop(ctx, bestCost, bestSolution, al)
+
+	generations := 1
+	for t := 1; t < model.maxGenerations && reason == ""; t++ {
+		generations++
+		for _, island := range model.Islands {
+			island.Algorithm.Generation(al, island.Graph, t)
+		}
+		if model.interval > 0 && t%model.interval == 0 {
+			model.migrate(al)
+		}
+		bestCost, bestSolution = model.best()
+		reason = model.criteria.Stop(ctx, bestCost, bestSolution, al)
+	}
+	if reason == "" {
+		reason = stopping.MaxGenerations
+	}
+
+	bestFitness := math.Inf(-1)
+	for _, island := range model.Islands {
+		island.Algorithm.Finish(al, reason, generations)
+		solution, fitness := island.Algorithm.Best()
+		if fitness > bestFitness {
+			bestFitness = fitness
+			bestSolution = solution
+		}
+	}
+	model.aggregate(reason, generations)
+	return bestSolution
+}
+
+func (model *IslandModel) best() (float64, []*airline.Pilot) {
+	// returns the cost of the best solution found by the islands and the solution
+	bestCost := math.Inf(1)
+	var bestSolution []*airline.Pilot
+	for _, island := range model.Islands {
+		if island.Mtr.GlobalBestSolutionCost < bestCost {
+			bestCost = island.Mtr.GlobalBestSolutionCost
+			bestSolution, _ = island.Algorithm.Best()
+		}
+	}
+	return bestCost, bestSolution
+}
+
+func (model *IslandModel) migrate(al *airline.Airline) {
+	// Send a copy of the best solution of every island to the next island (ring topology),
+	// where it replaces the worst solution, and move the positions of the next island's
+	// edges used by the solution halfway to the average positions of the sending island
+	migrants := [][]*airline.Pilot{}
+	for _, island := range model.Islands {
+		solution, _ := island.Algorithm.Best()
+		migrants = append(migrants, airline.CopySolution(solution))
+	}
+	for i, source := range model.Islands {
+		destination := model.Islands[(i+1)%len(model.Islands)]
+		if destination == source {
+			continue
+		}
+		for _, pilot := range migrants[i] {
+			for j := 0; j < pilot.AssignedLength; j++ {
+				sourcePairId := pilot.AssignedPairs[j].Id
+				goalPairId := pilot.AssignedPairs[j+1].Id
+				sourceEdge, edgeExists := source.Graph.Nodes[sourcePairId].Edges[goalPairId]
+				if !edgeExists {
+					continue
+				}
+				average := 0.0
+				for _, position := range sourceEdge.Position {
+					average += position
+				}
+				average /= float64(len(sourceEdge.Position))
+				edge, edgeExists := destination.Graph.Nodes[sourcePairId].Edges[goalPairId]
+				if !edgeExists {
+					destination.Graph.AddEdge(sourcePairId, goalPairId)
+					edge = destination.Graph.Nodes[sourcePairId].Edges[goalPairId]
+				}
+				for k := range edge.Position {
+					edge.Position[k] = (edge.Position[k] + average) / 2
+				}
+			}
+		}
+		destination.Algorithm.Immigrate(al, migrants[i])
+		destination.metrics.Immigrants++
+	}
+}
+
+func (model *IslandModel) aggregate(reason string, generations int) {
+	// Calculate the metrics of the island model from the metrics of its islands
+	m := model.Mtr
+	m.StopReason = reason
+	m.Generations = generations
+	uniqueSolutions := []string{}
+	bestIsland := model.Islands[0]
+	for _, island := range model.Islands {
+		m.TotalSolutions += island.Mtr.TotalSolutions
+		m.ValidSolutions += island.Mtr.ValidSolutions
+		m.AverageSimilarity += island.Mtr.AverageSimilarity / float64(len(model.Islands))
+		uniqueSolutions = append(uniqueSolutions, island.Mtr.UniqueSolutions()...)
+		if island.Mtr.GlobalBestSolutionCost < bestIsland.Mtr.GlobalBestSolutionCost {
+			bestIsland = island
+		}
+		m.Islands = append(m.Islands, island.metrics)
+	}
+	m.RestoreUniqueSolutions(uniqueSolutions)
+	m.UniqueCount = len(m.UniqueSolutions())
+	m.GlobalBestSolutionCost = bestIsland.Mtr.GlobalBestSolutionCost
+	m.GlobalBestString = bestIsland.Mtr.GlobalBestString
+
+	// the best cost of a generation is the best cost of all islands
+	// and a jump is a generation that improves the best cost of all islands
+	record := math.Inf(1)
+	for t := 0; t < generations; t++ {
+		best, worst, average := math.Inf(1), math.Inf(-1), 0.0
+		for _, island := range model.Islands {
+			best = math.Min(best, island.Mtr.IterBestCost[t])
+			worst = math.Max(worst, island.Mtr.IterWorstCost[t])
+			average += island.Mtr.IterAverageCost[t] / float64(len(model.Islands))
+		}
+		if best < record {
+			record = best
+			m.Jumps++
+		}
+		m.IterBestCost = append(m.IterBestCost, best)
+		m.IterWorstCost = append(m.IterWorstCost, worst)
+		m.IterAverageCost = append(m.IterAverageCost, average)
+
+		// the similarity is averaged over the islands, while the different solutions
+		// are summed and the uncovered pairs are those of the best island
+		similarity, unique, uncovered := 0.0, 0, math.MaxInt
+		for _, island := range model.Islands {
+			if t >= len(island.Mtr.IterSimilarity) {
+				continue
+			}
+			similarity += island.Mtr.IterSimilarity[t] / float64(len(model.Islands))
+			unique += island.Mtr.IterUnique[t]
+			if island.Mtr.IterUncovered[t] < uncovered {
+				uncovered = island.Mtr.IterUncovered[t]
+			}
+		}
+		if uncovered < math.MaxInt {
+			m.IterSimilarity = append(m.IterSimilarity, similarity)
+			m.IterUnique = append(m.IterUnique, unique)
+			m.IterUncovered = append(m.IterUncovered, uncovered)
+		}
+	}
+	fmt.Printf("Island model: best cost %.0f found by island %d (%s)\n", m.GlobalBestSolutionCost, indexOf(model.Islands, bestIsland)+1, bestIsland.metrics.Algorithm)
+}
+
+func indexOf(islands []*Island, island *Island) int {
+	// returns the position of "island" in the list of islands
+	for i := range islands {
+		if islands[i] == island {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,144 @@
+package island_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"go-airline-crew-rostering/airline"
+	"go-airline-crew-rostering/graph"
+	"go-airline-crew-rostering/island"
+	"go-airline-crew-rostering/metrics"
+	"go-airline-crew-rostering/stopping"
+
+	"golang.org/x/exp/slices"
+)
+
+// Algorithm whose generations have the given costs
+type scripted struct {
+	costs      []float64 // cost of the best solution of each generation
+	generation int
+	solution   []*airline.Pilot
+	Mtr        *metrics.Metrics
+	immigrants int
+	finished   int // generations reported by Finish
+}
+
+func newScripted(al *airline.Airline, costs []float64) *scripted {
+	// returns an algorithm with the costs "costs" and a solution without assignments
+	algorithm := &scripted{costs: costs, Mtr: new(metrics.Metrics)}
+	algorithm.Mtr.Initialization(len(costs), 1)
+	pilot := new(airline.Pilot)
+	pilot.Initialization(0, al.ScheduleDuration, al.PairsArray[0])
+	algorithm.solution = []*airline.Pilot{pilot}
+	return algorithm
+}
+
+func (algorithm *scripted) record() {
+	// store the metrics of the current generation
+	cost := algorithm.costs[algorithm.generation]
+	algorithm.Mtr.IterBestCost = append(algorithm.Mtr.IterBestCost, cost)
+	algorithm.Mtr.IterWorstCost = append(algorithm.Mtr.IterWorstCost, cost+10)
+	algorithm.Mtr.IterAverageCost = append(algorithm.Mtr.IterAverageCost, cost+5)
+	algorithm.Mtr.IterSimilarity = append(algorithm.Mtr.IterSimilarity, 50)
+	algorithm.Mtr.IterUnique = append(algorithm.Mtr.IterUnique, 1)
+	algorithm.Mtr.IterUncovered = append(algorithm.Mtr.IterUncovered, algorithm.generation)
+	if algorithm.generation == 0 || cost < algorithm.Mtr.GlobalBestSolutionCost {
+		algorithm.Mtr.GlobalBestSolutionCost = cost
+	}
+}
+
+func (algorithm *scripted) Begin() {
+	algorithm.record()
+}
+
+func (algorithm *scripted) Generation(al *airline.Airline, pairGraph *graph.Graph, generation int) {
+	algorithm.generation = generation
+	algorithm.record()
+}
+
+func (algorithm *scripted) Finish(al *airline.Airline, reason string, generations int) {
+	algorithm.finished = generations
+}
+
+func (algorithm *scripted) Best() ([]*airline.Pilot, float64) {
+	return algorithm.solution, -algorithm.Mtr.GlobalBestSolutionCost
+}
+
+func (algorithm *scripted) Immigrate(al *airline.Airline, solution []*airline.Pilot) {
+	algorithm.immigrants++
+}
+
+func TestRun(t *testing.T) {
+	start := time.Date(2011, 11, 1, 0, 0, 0, 0, time.UTC)
+	al := new(airline.Airline)
+	al.Initialization(660, 7, 2, start, start.AddDate(0, 0, 14), 1)
+	root := new(airline.Pair)
+	root.Initialization(0, start)
+	al.PairsArray = []*airline.Pair{root}
+
+	tests := []struct {
+		name        string
+		costs       [][]float64 // costs of each island
+		interval    int
+		targetCost  float64
+		generations int     // generations executed by the islands
+		best        float64 // best cost of all islands
+		bestCosts   []float64
+		jumps       int
+		immigrants  int // solutions received by every island
+		reason      string
+	}{
+		{"two islands", [][]float64{{9, 8, 8, 3, 3, 3}, {7, 7, 5, 5, 4, 1}}, 2, -1, 6, 1, []float64{7, 7, 5, 3, 3, 1}, 4, 2, stopping.MaxGenerations},
+		{"no migration", [][]float64{{9, 8, 8, 3, 3, 3}, {7, 7, 5, 5, 4, 1}}, 0, -1, 6, 1, []float64{7, 7, 5, 3, 3, 1}, 4, 0, stopping.MaxGenerations},
+		{"target cost", [][]float64{{9, 8, 8, 3, 3, 3}, {7, 7, 5, 5, 4, 1}}, 1, 4, 4, 3, []float64{7, 7, 5, 3}, 3, 3, stopping.TargetCost},
+		{"single island", [][]float64{{5, 4, 6, 2}}, 1, -1, 4, 2, []float64{5, 4, 6, 2}, 3, 0, stopping.MaxGenerations},
+	}
+	for _, test := range tests {
+		Mtr := new(metrics.Metrics)
+		Mtr.Initialization(0, 1)
+		criteria := new(stopping.Criteria)
+		criteria.Initialization(0, test.targetCost, 0, false)
+		model := new(island.IslandModel)
+		model.Initialization(len(test.costs[0]), test.interval, Mtr, criteria)
+		algorithms := []*scripted{}
+		for _, costs := range test.costs {
+			algorithm := newScripted(al, costs)
+			pairGraph := new(graph.Graph)
+			pairGraph.Initialization(1)
+			pairGraph.Populate(al.PairsArray)
+			model.Add("scripted", algorithm, pairGraph, algorithm.Mtr)
+			algorithms = append(algorithms, algorithm)
+		}
+		model.Run(context.Background(), al)
+
+		if Mtr.Generations != test.generations || Mtr.StopReason != test.reason {
+			t.Errorf("%s: %d generations stopped by %q, expected %d and %q", test.name, Mtr.Generations, Mtr.StopReason, test.generations, test.reason)
+		}
+		if Mtr.GlobalBestSolutionCost != test.best || !slices.Equal(Mtr.IterBestCost, test.bestCosts) || Mtr.Jumps != test.jumps {
+			t.Errorf("%s: best cost %v, costs %v and %d jumps, expected %v, %v and %d", test.name,
+				Mtr.GlobalBestSolutionCost, Mtr.IterBestCost, Mtr.Jumps, test.best, test.bestCosts, test.jumps)
+		}
+		// the worst cost is the worst of all islands and the average cost is averaged over the islands
+		for g := range Mtr.IterBestCost {
+			worst, average := math.Inf(-1), 0.0
+			for _, costs := range test.costs {
+				worst = math.Max(worst, costs[g]+10)
+				average += (costs[g] + 5) / float64(len(test.costs))
+			}
+			if Mtr.IterWorstCost[g] != worst || math.Abs(Mtr.IterAverageCost[g]-average) > 1e-9 {
+				t.Errorf("%s: generation %d has the worst and average costs %v %v, expected %v %v", test.name, g, Mtr.IterWorstCost[g], Mtr.IterAverageCost[g], worst, average)
+			}
+		}
+		if len(Mtr.Islands) != len(test.costs) {
+			t.Errorf("%s: metrics of %d islands, expected %d", test.name, len(Mtr.Islands), len(test.costs))
+		}
+		for i, algorithm := range algorithms {
+			if algorithm.immigrants != test.immigrants || Mtr.Islands[i].Immigrants != test.immigrants || algorithm.finished != test.generations {
+				t.Errorf("%s: island %d received %d solutions and finished after %d generations, expected %d and %d",
+					test.name, i, algorithm.immigrants, algorithm.finished, test.immigrants, test.generations)
+			}
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package metrics
+
+import (
+	"math"
+	"sort"
+
+	"go-airline-crew-rostering/airline"
+)
+
+// names of the dimensions of the pilots' schedules that should be balanced
+const (
+	NightsAway    = "nightsAway"    // nights away from base
+	WeekendDuties = "weekendDuties" // saturdays and sundays with duty
+	EarlyStarts   = "earlyStarts"   // pairings that start before "EarlyStartHour"
+	DaysOff       = "daysOff"       // days without duty
+)
+
+// names of the indicators used to measure the fairness of a dimension
+const (
+	Gini              = "gini"   // Gini coefficient
+	Spread            = "spread" // difference between the maximum and the minimum value
+	StandardDeviation = "std"    // standard deviation
+)
+
+var Dimensions = []string{NightsAway, WeekendDuties, EarlyStarts, DaysOff} // all dimensions in the order they are reported
+var EarlyStartHour int = 7                                                 // pairings that start before this hour are early starts
+
+// fairness indicators of one dimension of the pilots' schedules
+type Fairness struct {
+	Dimension         string
+	Gini              float64 // Gini coefficient of the pilots' values (0 is perfectly fair)
+	Spread            float64 // difference between the biggest and the smallest pilot's value
+	StandardDeviation float64 // standard deviation of the pilots' values
+}
+
+func DimensionValues(dimension string, solution []*airline.Pilot, al *airline.Airline) []float64 {
+	// returns the value of "dimension" for each pilot of the solution
+	values := []float64{}
+	for _, pilot := range solution {
+		value := 0
+		switch dimension {
+		case NightsAway:
+			value = pilot.NightsAway()
+		case WeekendDuties:
+			value = pilot.WeekendDuties(al)
+		case EarlyStarts:
+			value = pilot.EarlyStarts(EarlyStartHour)
+		case DaysOff:
+			value = pilot.DaysOff()
+		}
+		values = append(values, float64(value))
+	}
+	return values
+}
+
+func FairnessIndicators(solution []*airline.Pilot, al *airline.Airline) []*Fairness {
+	// Calculate the fairness indicators of every dimension for a solution
+	indicators := []*Fairness{}
+	for _, dimension := range Dimensions {
+		values := DimensionValues(dimension, solution, al)
+		indicators = append(indicators, &Fairness{
+			Dimension:         dimension,
+			Gini:              GiniCoefficient(values),
+			Spread:            MaxMinSpread(values),
+			StandardDeviation: StandardDeviationOf(values),
+		})
+	}
+	return indicators
+}
+
+func Indicator(indicator string, values []float64) float64 {
+	// returns the value of the indicator with name "indicator"
+	switch indicator {
+	case Gini:
+		return GiniCoefficient(values)
+	case Spread:
+		return MaxMinSpread(values)
+	case StandardDeviation:
+		return StandardDeviationOf(values)
+	}
+	return 0
+}
+
+func GiniCoefficient(values []float64) float64 {
+	// Calculate the Gini coefficient of a list of non negative values
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	sum := 0.0
+	weightedSum := 0.0
+	for i, value := range sorted {
+		sum += value
+		weightedSum += float64(i+1) * value
+	}
+	if sum == 0 {
+		return 0
+	}
+	n := float64(len(sorted))
+	return 2*weightedSum/(n*sum) - (n+1)/n
+}
+
+func MaxMinSpread(values []float64) float64 {
+	// Calculate the difference between the biggest and the smallest value of a list
+	if len(values) == 0 {
+		return 0
+	}
+	minimum, maximum := values[0], values[0]
+	for _, value := range values {
+		minimum = math.Min(minimum, value)
+		maximum = math.Max(maximum, value)
+	}
+	return maximum - minimum
+}
+
+func StandardDeviationOf(values []float64) float64 {
+	// Calculate the (population) standard deviation of a list of values
+	if len(values) == 0 {
+		return 0
+	}
+	mean := 0.0
+	for _, value := range values {
+		mean += value
+	}
+	mean /= float64(len(values))
+	variance := 0.0
+	for _, value := range values {
+		variance += (value - mean) * (value - mean)
+	}
+	return math.Sqrt(variance / float64(len(values)))
+}
@@ -0,0 +1,171 @@
+package metrics_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"go-airline-crew-rostering/airline"
+	"go-airline-crew-rostering/metrics"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestIndicators(t *testing.T) {
+	tests := []struct {
+		values []float64
+		gini   float64
+		spread float64
+		std    float64
+	}{
+		{[]float64{}, 0, 0, 0},
+		{[]float64{0, 0, 0}, 0, 0, 0},
+		{[]float64{3, 3, 3, 3}, 0, 0, 0},
+		// a single pilot with everything: (n-1)/n
+		{[]float64{0, 0, 0, 1}, 0.75, 1, math.Sqrt(3) / 4},
+		// 2*(1+4+9+16)/(4*10) - 5/4
+		{[]float64{4, 1, 3, 2}, 0.25, 3, math.Sqrt(1.25)},
+		// 2*214/(8*40) - 9/8
+		{[]float64{2, 4, 4, 4, 5, 5, 7, 9}, 0.2125, 7, 2},
+	}
+	for _, test := range tests {
+		indicators := map[string]float64{metrics.Gini: test.gini, metrics.Spread: test.spread, metrics.StandardDeviation: test.std}
+		for indicator, expected := range indicators {
+			if value := metrics.Indicator(indicator, test.values); math.Abs(value-expected) > 1e-9 {
+				t.Errorf("%s of %v = %v, expected %v", indicator, test.values, value, expected)
+			}
+		}
+	}
+	if value := metrics.Indicator("unknown", []float64{1, 2}); value != 0 {
+		t.Errorf("unknown indicator = %v, expected 0", value)
+	}
+}
+
+func roster() *airline.Airline {
+	// returns a week starting on saturday 2011-11-05 with three pairings:
+	// an early start on saturday, a pairing with a night away and an early start on wednesday
+	start := time.Date(2011, 11, 5, 0, 0, 0, 0, time.UTC)
+	al := new(airline.Airline)
+	al.Initialization(660, 7, 2, start, time.Date(2011, 11, 12, 0, 0, 0, 0, time.UTC), 3)
+	root := new(airline.Pair)
+	root.Initialization(0, start)
+	legs := [][2]time.Time{
+		{time.Date(2011, 11, 5, 6, 0, 0, 0, time.UTC), time.Date(2011, 11, 5, 10, 0, 0, 0, time.UTC)},
+		{time.Date(2011, 11, 7, 8, 0, 0, 0, time.UTC), time.Date(2011, 11, 8, 12, 0, 0, 0, time.UTC)},
+		{time.Date(2011, 11, 9, 5, 30, 0, 0, time.UTC), time.Date(2011, 11, 9, 9, 0, 0, 0, time.UTC)},
+	}
+	al.PairsArray = []*airline.Pair{root}
+	for i, leg := range legs {
+		pair := new(airline.Pair)
+		pair.Initialization(i+1, start)
+		pair.Add(i+1, leg[0], leg[1], start)
+		al.PairsArray = append(al.PairsArray, pair)
+	}
+	return al
+}
+
+func pilots(al *airline.Airline, assignments [][]int) []*airline.Pilot {
+	// returns the pilots of "al" with the pairings of "assignments" (in chronological order)
+	solution := []*airline.Pilot{}
+	for id, pairs := range assignments {
+		pilot := new(airline.Pilot)
+		pilot.Initialization(id, al.ScheduleDuration, al.PairsArray[0])
+		for _, pair := range pairs {
+			pilot.Add(al.PairsArray[pair], pilot.AssignedLength+1)
+		}
+		solution = append(solution, pilot)
+	}
+	return solution
+}
+
+func TestDimensionValues(t *testing.T) {
+	al := roster()
+	solution := pilots(al, [][]int{{1, 2}, {3}, {}})
+	tests := []struct {
+		dimension string
+		values    []float64
+	}{
+		{metrics.NightsAway, []float64{1, 0, 0}},
+		{metrics.WeekendDuties, []float64{1, 0, 0}},
+		{metrics.EarlyStarts, []float64{1, 1, 0}},
+	}
+	for _, test := range tests {
+		if values := metrics.DimensionValues(test.dimension, solution, al); !slices.Equal(values, test.values) {
+			t.Errorf("%s = %v, expected %v", test.dimension, values, test.values)
+		}
+	}
+	// the first pilot works on 3 days and the second one on 1 day
+	daysOff := metrics.DimensionValues(metrics.DaysOff, solution, al)
+	if daysOff[2]-daysOff[0] != 3 || daysOff[2]-daysOff[1] != 1 {
+		t.Errorf("%s = %v", metrics.DaysOff, daysOff)
+	}
+
+	indicators := metrics.FairnessIndicators(solution, al)
+	if len(indicators) != len(metrics.Dimensions) || indicators[0].Dimension != metrics.Dimensions[0] {
+		t.Fatalf("%d indicators, expected one per dimension", len(indicators))
+	}
+	// nights away: 1, 0, 0
+	if math.Abs(indicators[0].Gini-2.0/3) > 1e-9 || indicators[0].Spread != 1 {
+		t.Errorf("indicators of %s = %v %v", indicators[0].Dimension, indicators[0].Gini, indicators[0].Spread)
+	}
+}
+
+func TestSolutionEncoding(t *testing.T) {
+	// solutions that only swap the pilots have the same encoding
+	al := roster()
+	m := new(metrics.Metrics)
+	m.Initialization(3, 32)
+	first := m.SolutionEncoding([]int{0, 0, 1}, pilots(al, [][]int{{1, 2}, {3}, {}}))
+	swapped := m.SolutionEncoding([]int{2, 2, 0}, pilots(al, [][]int{{3}, {}, {1, 2}}))
+	other := m.SolutionEncoding([]int{0, 1, 1}, pilots(al, [][]int{{1}, {2, 3}, {}}))
+	if !slices.Equal(first, []string{"1", "1", "3"}) || !slices.Equal(swapped, first) {
+		t.Errorf("encodings = %v and %v, expected [1 1 3]", first, swapped)
+	}
+	if m.UniqueCount != 2 || !slices.Equal(m.UniqueSolutions(), []string{"1,1,3", "1,2,2"}) {
+		t.Errorf("unique solutions = %d %v, expected 2", m.UniqueCount, m.UniqueSolutions())
+	}
+
+	tests := []struct {
+		a          []string
+		b          []string
+		similarity float64
+	}{
+		{first, swapped, 100},
+		{first, other, 100.0 / 3},
+		{[]string{"1", "1"}, first, 200.0 / 3},
+		{first, []string{"2", "2", "2"}, 0},
+	}
+	for _, test := range tests {
+		if similarity := m.SolutionSimilarity(test.a, test.b); math.Abs(similarity-test.similarity) > 1e-9 {
+			t.Errorf("similarity of %v and %v = %v, expected %v", test.a, test.b, similarity, test.similarity)
+		}
+	}
+}
+
+func TestPilotStatistics(t *testing.T) {
+	// the rest slack is negative for a rest shorter than the rest period
+	// and missing for a pilot with fewer than two pairings
+	al := roster()
+	al.RestPeriod = 1200
+	tests := []struct {
+		pairs     []int
+		hasRest   bool
+		restSlack float64
+	}{
+		{[]int{1, 2}, true, 46*60 - 1200},
+		{[]int{2, 3}, true, 17.5*60 - 1200},
+		{[]int{3}, false, 0},
+		{[]int{}, false, 0},
+	}
+	assignments := [][]int{}
+	for _, test := range tests {
+		assignments = append(assignments, test.pairs)
+	}
+	statistics := metrics.PilotStatisticsOf(pilots(al, assignments), al)
+	for i, test := range tests {
+		if statistics[i].HasRest != test.hasRest || statistics[i].RestSlack != test.restSlack {
+			t.Errorf("pairings %v: rest slack %v (%v), expected %v (%v)", test.pairs,
+				statistics[i].RestSlack, statistics[i].HasRest, test.restSlack, test.hasRest)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"math"
+
+	"go-airline-crew-rostering/airline"
+)
+
+// statistics of the schedule of one pilot
+type PilotStatistics struct {
+	Pilot          int
+	FlightTime     float64 // total flight time (in minutes)
+	Deviation      float64 // difference between the flight time and the average workload (in minutes)
+	Pairings       int     // number of assigned pairings
+	MinDaysOff     int     // fewest days off in a period of "timespan" days
+	AverageDaysOff float64 // average days off in a period of "timespan" days
+	ExcessRest     float64 // total excess rest period (in minutes)
+	LongestStreak  int     // most consecutive days with duty
+	DaysOffSlack   int     // days off above the minimum in the period with the fewest days off
+	RestSlack      float64 // shortest rest above the minimum rest period (in minutes, negative if it is shorter)
+	HasRest        bool    // false if the pilot has fewer than two pairings (the rest slack is then 0)
+}
+
+func PilotStatisticsOf(solution []*airline.Pilot, al *airline.Airline) []*PilotStatistics {
+	// Calculate the statistics of the schedule of every pilot of a solution
+	statistics := []*PilotStatistics{}
+	for _, pilot := range solution {
+		minDaysOff, averageDaysOff := pilot.DaysOffPerTimespan(al.Timespan())
+		rest, hasRest := pilot.MinimumRest()
+		restSlack := 0.0
+		if hasRest {
+			restSlack = rest - float64(al.RestPeriod)
+		}
+		statistics = append(statistics, &PilotStatistics{
+			Pilot:          pilot.Id,
+			FlightTime:     pilot.FlightTime,
+			Deviation:      math.Abs(al.AverageWorkload - pilot.FlightTime),
+			Pairings:       pilot.AssignedLength,
+			MinDaysOff:     minDaysOff,
+			AverageDaysOff: averageDaysOff,
+			ExcessRest:     pilot.TotalRestPeriod(al),
+			LongestStreak:  pilot.LongestStreak(),
+			DaysOffSlack:   minDaysOff - al.MinimumDaysOff(),
+			RestSlack:      restSlack,
+			HasRest:        hasRest,
+		})
+	}
+	return statistics
+}
@@ -0,0 +1,48 @@
+package multicso
+
+import (
+	"go-airline-crew-rostering/airline"
+	"go-airline-crew-rostering/checkpoint"
+	"go-airline-crew-rostering/graph"
+)
+
+func (swarm *MultiCSO) SetCheckpoints(options *checkpoint.Options) {
+	// Save checkpoints during the execution based on "options"
+	swarm.checkpoints = options
+}
+
+func (swarm *MultiCSO) checkpoint(generation int, globalBest int, pairGraph *graph.Graph) *checkpoint.Checkpoint {
+	// returns the state of the swarm after "generation"
+	state := &checkpoint.Checkpoint{Algorithm: "multiCSO", Seed: swarm.Mtr.Seed, Generation: generation, GlobalBest: globalBest}
+	for _, chicken := range swarm.Swarm {
+		state.Agents = append(state.Agents, &checkpoint.Agent{
+			Fitness:           chicken.Fitness,
+			Cost:              chicken.Cost,
+			Solution:          checkpoint.EncodeSolution(chicken.Solution),
+			CondensedSolution: chicken.CondensedSolution,
+		})
+		state.Generators = append(state.Generators, checkpoint.EncodeGenerator(chicken.generator))
+	}
+	state.SetGraph(pairGraph)
+	state.SetMetrics(swarm.Mtr)
+	state.SetCriteria(swarm.criteria)
+	return state
+}
+
+func (swarm *MultiCSO) Resume(state *checkpoint.Checkpoint, al *airline.Airline, pairGraph *graph.Graph) {
+	// Restore the state of the swarm, so that the execution continues
+	// after the generation of the checkpoint
+	state.Check("multiCSO", swarm.population)
+	for i, chicken := range swarm.Swarm {
+		agent := state.Agents[i]
+		chicken.Fitness, chicken.Cost = agent.Fitness, agent.Cost
+		chicken.Solution = checkpoint.DecodeSolution(agent.Solution, al)
+		chicken.CondensedSolution = agent.CondensedSolution
+		chicken.generator.Restore(state.Generators[i].Seed, state.Generators[i].Draws)
+	}
+	state.RestoreGraph(pairGraph, al.PairsArray)
+	state.RestoreMetrics(swarm.Mtr)
+	state.RestoreCriteria(swarm.criteria)
+	swarm.start = state.Generation + 1
+	swarm.globalBest = state.GlobalBest
+}
@@ -1,187 +1,300 @@
-package multicso
-
-import (
-	"fmt"
-	"sort"
-
-	"go-airline-crew-rostering/airline"
-	"go-airline-crew-rostering/fitness"
-	"go-airline-crew-rostering/graph"
-	"go-airline-crew-rostering/metrics"
-)
-
-// Container for the functions related to a chicken swarm
-type MultiCSORepo interface {
-	Initialization() *MultiCSO
-	MultiCSO() []*Chicken
-	solution() []*airline.Pilot
-	swarmUpdate()
-	sort() []*Chicken
-}
-
-// Swarm of chickens
-type MultiCSO struct {
-	Swarm          []*Chicken       // List of the chickens of the swarm
-	population     int              // Number of objects in the swarm
-	maxGenerations int              // Maximum number of iterations executed by the optimization algorithm
-	FL             float64          // algorithm parameter shared by all chickens
-	Mtr            *metrics.Metrics // Metrics used to evaluate the algorithm's efficiency
-	costList       []float64        // List of solutions' cost found by all chickens of the swarm in the current generation
-}
-
-func (swarm *MultiCSO) Initialization(al *airline.Airline, pairGraph *graph.Graph,
-	population int, maxGenerations int, FL float64, Mtr *metrics.Metrics) *MultiCSO {
-	// Initialize an instance of a chicken swarm
-	swarm.Swarm = []*Chicken{}
-	swarm.population = population
-	swarm.maxGenerations = maxGenerations
-	swarm.FL = FL
-	swarm.costList = []float64{}
-	swarm.Mtr = Mtr
-
-	// Create the chickens and build the initial solutions for each one
-	for agent := 0; agent < population; agent++ {
-		chicken := new(Chicken)
-		chicken.Initialization(agent)
-		swarm.Swarm = append(swarm.Swarm, chicken)
-		swarm.solution(al, pairGraph, agent)
-	}
-
-	return swarm
-}
-
-func (swarm *MultiCSO) solution(al *airline.Airline, pairGraph *graph.Graph, chickenId int /*wg *sync.WaitGroup*/) {
-	// Build a new solution for the chicken with id "chickenId"
-	chicken := swarm.Swarm[chickenId]
-	validSolution := chicken.ConstructSolution(al, pairGraph)
-	if validSolution {
-		swarm.Mtr.ValidSolutions++
-	}
-	// Calculate the new solution's fitness and cost
-	chicken.NewFitness, chicken.NewCost = fitness.FitnessFunction(chicken.ProposedSolution, len(al.PairsArray)-1, al.AverageWorkload)
-	chicken.NewCost = chicken.NewCost * swarm.Mtr.UnitCost
-	swarm.costList = append(swarm.costList, chicken.NewCost)
-
-	// Adopt the new solution as the chicken's solution if the new solution is better
-	if chicken.NewFitness > chicken.Fitness {
-		chicken.Evaluate()
-	}
-}
-
-func (swarm *MultiCSO) swarmUpdate(pairGraph *graph.Graph) {
-	// Update all relevant edges of the graph for all chickens of the swarm
-
-	edgesToUpdate := make(map[int]*graph.Edge) // List of edges that will be updated
-
-	// Calculate individual parameters for each chicken
-	for _, chicken := range swarm.Swarm {
-		chicken.SetUpdateParameters(swarm.Swarm)
-	}
-
-	// Gather all edges that need to be updated by scanning the
-	// current solution of each chicken
-	for _, chicken := range swarm.Swarm {
-		for _, pilot := range chicken.Solution {
-			for i := 0; i < pilot.AssignedLength; i++ {
-				sourcePairId := pilot.AssignedPairs[i].Id
-				goalPairId := pilot.AssignedPairs[i+1].Id
-				edge, edgeExists := pairGraph.Nodes[sourcePairId].Edges[goalPairId]
-				// Create a new edge if a pair of successive pairings has not be
-				// found in another solution so far
-				if !edgeExists {
-					pairGraph.AddEdge(sourcePairId, goalPairId)
-					edge = pairGraph.Nodes[sourcePairId].Edges[goalPairId]
-				}
-				edgesToUpdate[edge.Id] = edge
-			}
-		}
-	}
-
-	// Update all relevant edges for all chickens
-	for _, edge := range edgesToUpdate {
-		for _, chicken := range swarm.Swarm {
-			chicken.UpdatePosition(edge, swarm.FL)
-		}
-	}
-
-}
-
-func (swarm *MultiCSO) sort() {
-	// sort the list of chickens by their fitness, from best to worst
-	sort.Slice(swarm.Swarm, func(i int, j int) bool {
-		return swarm.Swarm[i].Fitness > swarm.Swarm[j].Fitness
-	})
-}
-
-func (swarm *MultiCSO) MultiCSO(al *airline.Airline, pairGraph *graph.Graph) []*Chicken {
-	// Main body of the optimization algorithm
-	// Returns the list of chickens in the swarm
-
-	// Calculate Metrics for the initialization step
-	globalbestchicken, _ := swarm.Mtr.SetUpIterationMetrics(swarm.costList)
-	swarm.Mtr.Jumps++
-	chicken := swarm.Swarm[globalbestchicken]
-	swarm.Mtr.GlobalBestSolutionCost = chicken.Cost
-	swarm.Mtr.GlobalBestString = swarm.Mtr.SolutionEncoding(chicken.CondensedSolution, chicken.Solution)
-	for _, chicken := range swarm.Swarm {
-		if chicken.Id == globalbestchicken {
-			continue
-		}
-		normalisedSolution := swarm.Mtr.SolutionEncoding(chicken.CondensedSolution, chicken.Solution)
-		swarm.Mtr.AverageSimilarity += swarm.Mtr.SolutionSimilarity(swarm.Mtr.GlobalBestString, normalisedSolution)
-	}
-
-	// Execute the algorithm for iterations equal to "maxGenerations"
-	for t := 1; t < swarm.maxGenerations; t++ {
-
-		// Update the positions of the swarm
-		swarm.swarmUpdate(pairGraph)
-
-		swarm.costList = []float64{} // empty the cost list from the previous iteration
-
-		// build solutions for all chickens
-		for _, chicken := range swarm.Swarm {
-			swarm.solution(al, pairGraph, chicken.Id)
-		}
-
-		// Calculate the metrics of the current iteration
-		globalbestchicken = swarm.calculateMetrics(globalbestchicken, t)
-	}
-
-	// Try to optimize the solutions of each object
-	for _, chicken := range swarm.Swarm {
-		al.EqualizeWorkload(chicken.Solution)
-		chicken.Fitness, chicken.Cost = fitness.FitnessFunction(chicken.Solution, len(al.PairsArray)-1, al.AverageWorkload)
-		chicken.Cost *= swarm.Mtr.UnitCost
-	}
-	swarm.Mtr.AverageSimilarity = swarm.Mtr.AverageSimilarity / float64(swarm.population*swarm.maxGenerations)
-	swarm.sort()
-	return swarm.Swarm
-}
-
-func (swarm *MultiCSO) calculateMetrics(globalBest int, generation int) int {
-	// Calculate metrics of current iteration and update all metrics
-	globalbestchicken := globalBest
-	globalbestFitness := swarm.Swarm[globalbestchicken].Fitness
-	bestchicken, _ := swarm.Mtr.SetUpIterationMetrics(swarm.costList)
-	bestFitness := swarm.Swarm[bestchicken].Fitness
-	bestCost := swarm.costList[bestchicken]
-	bestSolutionString := []string{}
-	for _, chicken := range swarm.Swarm {
-		normalisedSolution := swarm.Mtr.SolutionEncoding(chicken.NewCondensedSolution, chicken.ProposedSolution)
-		swarm.Mtr.AverageSimilarity += swarm.Mtr.SolutionSimilarity(swarm.Mtr.GlobalBestString, normalisedSolution)
-		if chicken.Id == bestchicken {
-			bestSolutionString = normalisedSolution
-		}
-	}
-	if bestFitness > globalbestFitness {
-		globalbestchicken = bestchicken
-		swarm.Mtr.GlobalBestSolutionCost = bestCost
-		swarm.Mtr.GlobalBestString = bestSolutionString
-		swarm.Mtr.Jumps++
-	}
-	if generation%200 == 0 {
-		fmt.Println("Generation", generation)
-	}
-	return globalbestchicken
-}
+package multicso
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"go-airline-crew-rostering/airline"
+	"go-airline-crew-rostering/checkpoint"
+	"go-airline-crew-rostering/fitness"
+	"go-airline-crew-rostering/graph"
+	"go-airline-crew-rostering/metrics"
+	"go-airline-crew-rostering/problem"
+	"go-airline-crew-rostering/randomness"
+	"go-airline-crew-rostering/stopping"
+	"go-airline-crew-rostering/workers"
+)
+
+// Container for the functions related to a chicken swarm
+type MultiCSORepo interface {
+	Initialization() *MultiCSO
+	MultiCSO() []*Chicken
+	Begin()
+	Generation()
+	Finish()
+	Best() ([]*airline.Pilot, float64)
+	Immigrate()
+	solutions()
+	WarmStart()
+	swarmUpdate()
+	sort() []*Chicken
+}
+
+// Swarm of chickens
+type MultiCSO struct {
+	Swarm          []*Chicken          // List of the chickens of the swarm
+	population     int                 // Number of objects in the swarm
+	maxGenerations int                 // Maximum number of iterations executed by the optimization algorithm
+	FL             float64             // algorithm parameter shared by all chickens
+	Mtr            *metrics.Metrics    // Metrics used to evaluate the algorithm's efficiency
+	objective      *fitness.Objective  // Objective used to calculate the fitness and the cost of the solutions
+	costList       []float64           // List of solutions' cost found by all chickens of the swarm in the current generation
+	workers        int                 // Maximum number of solutions built concurrently
+	criteria       *stopping.Criteria  // Criteria used to stop the algorithm before "maxGenerations"
+	checkpoints    *checkpoint.Options // Options of the checkpoints saved during the execution
+	start          int                 // first generation executed (bigger than 1 if the run continues from a checkpoint)
+	globalBest     int                 // id of the chicken with the best solution found so far
+	pairs          int                 // number of pairings of the schedule
+}
+
+func (swarm *MultiCSO) Initialization(al *airline.Airline, pairGraph *graph.Graph,
+	population int, maxGenerations int, FL float64, Mtr *metrics.Metrics, objective *fitness.Objective, workers int, criteria *stopping.Criteria,
+	generator *randomness.Generator) *MultiCSO {
+	// Initialize an instance of a chicken swarm
+	swarm.Swarm = []*Chicken{}
+	swarm.population = population
+	swarm.maxGenerations = maxGenerations
+	swarm.FL = FL
+	swarm.costList = []float64{}
+	swarm.Mtr = Mtr
+	swarm.objective = objective
+	swarm.workers = workers
+	swarm.criteria = criteria
+	swarm.checkpoints = nil
+	swarm.start = 1
+	swarm.globalBest = 0
+	swarm.pairs = len(al.PairsArray) - 1
+
+	// Create the chickens and build the initial solutions for each one
+	// (every chicken gets its own random number generator, seeded by "generator")
+	for agent := 0; agent < population; agent++ {
+		chicken := new(Chicken)
+		chicken.Initialization(agent, randomness.New(generator.Int63()))
+		swarm.Swarm = append(swarm.Swarm, chicken)
+	}
+	swarm.solutions(al, pairGraph)
+
+	return swarm
+}
+
+func (swarm *MultiCSO) solutions(al *airline.Airline, pairGraph *graph.Graph) {
+	// Build a new solution for every chicken of the swarm
+	// The solutions are built concurrently, each one with the chicken's random generator,
+	// while the metrics are updated in the order of the chickens, so the results
+	// do not depend on the number of workers
+	validSolutions := make([]bool, len(swarm.Swarm))
+	workers.ForEach(swarm.workers, len(swarm.Swarm), func(i int) {
+		chicken := swarm.Swarm[i]
+		validSolutions[i] = chicken.ConstructSolution(al, pairGraph)
+		// Calculate the new solution's fitness and cost
+		chicken.NewFitness, chicken.NewCost = swarm.objective.Evaluate(chicken.ProposedSolution, al)
+		chicken.NewCost = chicken.NewCost * swarm.Mtr.UnitCost
+	})
+
+	for i, chicken := range swarm.Swarm {
+		if validSolutions[i] {
+			swarm.Mtr.ValidSolutions++
+		}
+		swarm.costList = append(swarm.costList, chicken.NewCost)
+
+		// Adopt the new solution as the chicken's solution if the new solution is better
+		if chicken.NewFitness > chicken.Fitness {
+			chicken.Evaluate()
+		}
+	}
+}
+
+func (swarm *MultiCSO) WarmStart(al *airline.Airline, solution []*airline.Pilot) {
+	// Replace the solution of the first chicken with "solution"
+	// (used to start the search from a known roster)
+	chicken := swarm.Swarm[0]
+	chicken.ProposedSolution = solution
+	chicken.NewCondensedSolution = problem.CondensedSolution(al, solution)
+	chicken.NewFitness, chicken.NewCost = swarm.objective.Evaluate(solution, al)
+	chicken.NewCost = chicken.NewCost * swarm.Mtr.UnitCost
+	chicken.Evaluate()
+	swarm.costList[0] = chicken.Cost
+}
+
+func (swarm *MultiCSO) swarmUpdate(pairGraph *graph.Graph) {
+	// Update all relevant edges of the graph for all chickens of the swarm
+
+	edgesToUpdate := make(map[int]*graph.Edge) // List of edges that will be updated
+
+	// Calculate individual parameters for each chicken
+	for _, chicken := range swarm.Swarm {
+		chicken.SetUpdateParameters(swarm.Swarm)
+	}
+
+	// Gather all edges that need to be updated by scanning the
+	// current solution of each chicken
+	for _, chicken := range swarm.Swarm {
+		for _, pilot := range chicken.Solution {
+			for i := 0; i < pilot.AssignedLength; i++ {
+				sourcePairId := pilot.AssignedPairs[i].Id
+				goalPairId := pilot.AssignedPairs[i+1].Id
+				edge, edgeExists := pairGraph.Nodes[sourcePairId].Edges[goalPairId]
+				// Create a new edge if a pair of successive pairings has not be
+				// found in another solution so far
+				if !edgeExists {
+					pairGraph.AddEdge(sourcePairId, goalPairId)
+					edge = pairGraph.Nodes[sourcePairId].Edges[goalPairId]
+				}
+				edgesToUpdate[edge.Id] = edge
+			}
+		}
+	}
+
+	// Update all relevant edges for all chickens
+	for _, edge := range graph.SortedEdges(edgesToUpdate) {
+		for _, chicken := range swarm.Swarm {
+			chicken.UpdatePosition(edge, swarm.FL)
+		}
+	}
+
+}
+
+func (swarm *MultiCSO) sort() {
+	// sort the list of chickens by their fitness, from best to worst
+	sort.Slice(swarm.Swarm, func(i int, j int) bool {
+		return swarm.Swarm[i].Fitness > swarm.Swarm[j].Fitness
+	})
+}
+
+func (swarm *MultiCSO) MultiCSO(ctx context.Context, al *airline.Airline, pairGraph *graph.Graph) []*Chicken {
+	// Main body of the optimization algorithm
+	// Returns the list of chickens in the swarm
+
+	// Calculate Metrics for the initialization step
+	// (unless the run continues from a checkpoint)
+	reason := ""
+	if swarm.start == 1 {
+		swarm.Begin()
+		reason = swarm.criteria.Stop(ctx, swarm.Mtr.GlobalBestSolutionCost, swarm.Swarm[swarm.globalBest].Solution, al)
+	}
+
+	// Execute the algorithm for iterations equal to "maxGenerations"
+	// or until one of the stopping criteria is met
+	generations := swarm.start
+	for t := swarm.start; t < swarm.maxGenerations && reason == ""; t++ {
+		generations++
+		swarm.Generation(al, pairGraph, t)
+
+		reason = swarm.criteria.Stop(ctx, swarm.Mtr.GlobalBestSolutionCost, swarm.Swarm[swarm.globalBest].Solution, al)
+		if swarm.checkpoints.Due(t, reason) {
+			swarm.checkpoints.Save(swarm.checkpoint(t, swarm.globalBest, pairGraph))
+		}
+	}
+	if reason == "" {
+		reason = stopping.MaxGenerations
+	}
+	swarm.Finish(al, reason, generations)
+	return swarm.Swarm
+}
+
+func (swarm *MultiCSO) Begin() {
+	// Calculate the metrics of the initial solutions
+	globalbestchicken, _ := swarm.Mtr.SetUpIterationMetrics(swarm.costList)
+	swarm.Mtr.Jumps++
+	chicken := swarm.Swarm[globalbestchicken]
+	swarm.Mtr.GlobalBestSolutionCost = chicken.Cost
+	swarm.Mtr.GlobalBestString = swarm.Mtr.SolutionEncoding(chicken.CondensedSolution, chicken.Solution)
+	similarities := []float64{}
+	for _, chicken := range swarm.Swarm {
+		if chicken.Id == globalbestchicken {
+			continue
+		}
+		normalisedSolution := swarm.Mtr.SolutionEncoding(chicken.CondensedSolution, chicken.Solution)
+		similarities = append(similarities, swarm.Mtr.SolutionSimilarity(swarm.Mtr.GlobalBestString, normalisedSolution))
+	}
+	swarm.Mtr.SetUpDiversityMetrics(similarities, swarm.pairs-len(chicken.CondensedSolution))
+	swarm.globalBest = globalbestchicken
+}
+
+func (swarm *MultiCSO) Generation(al *airline.Airline, pairGraph *graph.Graph, generation int) {
+	// Execute one generation of the algorithm
+
+	// Update the positions of the swarm
+	swarm.swarmUpdate(pairGraph)
+
+	swarm.costList = []float64{} // empty the cost list from the previous iteration
+
+	// build solutions for all chickens
+	swarm.solutions(al, pairGraph)
+
+	// Calculate the metrics of the current iteration
+	swarm.globalBest = swarm.calculateMetrics(swarm.globalBest, generation)
+}
+
+func (swarm *MultiCSO) Finish(al *airline.Airline, reason string, generations int) {
+	// Optimize the solutions of the chickens, complete the metrics after
+	// "generations" generations and sort the chickens from best to worst
+	swarm.Mtr.StopReason = reason
+
+	// Try to optimize the solutions of each object
+	for _, chicken := range swarm.Swarm {
+		al.EqualizeWorkload(chicken.Solution)
+		chicken.Fitness, chicken.Cost = swarm.objective.Evaluate(chicken.Solution, al)
+		chicken.Cost *= swarm.Mtr.UnitCost
+	}
+	swarm.Mtr.Generations = generations
+	swarm.Mtr.TotalSolutions = swarm.population * generations
+	swarm.Mtr.AverageSimilarity = swarm.Mtr.AverageSimilarity / float64(swarm.population*generations)
+	swarm.sort()
+	swarm.globalBest = 0
+}
+
+func (swarm *MultiCSO) Best() ([]*airline.Pilot, float64) {
+	// returns the best solution of the swarm and its fitness
+	chicken := swarm.Swarm[swarm.globalBest]
+	return chicken.Solution, chicken.Fitness
+}
+
+func (swarm *MultiCSO) Immigrate(al *airline.Airline, solution []*airline.Pilot) {
+	// Replace the solution of the worst chicken with "solution"
+	// (a solution found by another swarm of the island model)
+	worst := swarm.Swarm[0]
+	for _, chicken := range swarm.Swarm {
+		if chicken.Fitness < worst.Fitness {
+			worst = chicken
+		}
+	}
+	if worst.Id == swarm.globalBest {
+		return
+	}
+	worst.ProposedSolution = solution
+	worst.NewCondensedSolution = problem.CondensedSolution(al, solution)
+	worst.NewFitness, worst.NewCost = swarm.objective.Evaluate(solution, al)
+	worst.NewCost = worst.NewCost * swarm.Mtr.UnitCost
+	worst.Evaluate()
+}
+
+func (swarm *MultiCSO) calculateMetrics(globalBest int, generation int) int {
+	// Calculate metrics of current iteration and update all metrics
+	globalbestchicken := globalBest
+	globalbestFitness := swarm.Swarm[globalbestchicken].Fitness
+	bestchicken, _ := swarm.Mtr.SetUpIterationMetrics(swarm.costList)
+	bestFitness := swarm.Swarm[bestchicken].Fitness
+	bestCost := swarm.costList[bestchicken]
+	bestSolutionString := []string{}
+	similarities := []float64{}
+	for _, chicken := range swarm.Swarm {
+		normalisedSolution := swarm.Mtr.SolutionEncoding(chicken.NewCondensedSolution, chicken.ProposedSolution)
+		similarities = append(similarities, swarm.Mtr.SolutionSimilarity(swarm.Mtr.GlobalBestString, normalisedSolution))
+		if chicken.Id == bestchicken {
+			bestSolutionString = normalisedSolution
+		}
+	}
+	swarm.Mtr.SetUpDiversityMetrics(similarities, swarm.pairs-len(swarm.Swarm[bestchicken].NewCondensedSolution))
+	if bestFitness > globalbestFitness {
+		globalbestchicken = bestchicken
+		swarm.Mtr.GlobalBestSolutionCost = bestCost
+		swarm.Mtr.GlobalBestString = bestSolutionString
+		swarm.Mtr.Jumps++
+	}
+	if generation%200 == 0 {
+		fmt.Println("Generation", generation)
+	}
+	return globalbestchicken
+}
@@ -0,0 +1,100 @@
+package multicso_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go-airline-crew-rostering/airline"
+	"go-airline-crew-rostering/checkpoint"
+	"go-airline-crew-rostering/fitness"
+	"go-airline-crew-rostering/graph"
+	"go-airline-crew-rostering/input"
+	"go-airline-crew-rostering/metrics"
+	"go-airline-crew-rostering/multicso"
+	"go-airline-crew-rostering/randomness"
+	"go-airline-crew-rostering/stopping"
+
+	"golang.org/x/exp/slices"
+)
+
+func instance() *airline.Airline {
+	// returns an airline with two weeks of pairings and 45 pilots
+	return input.ReadInstance("../Pairings.csv", time.Date(2011, 11, 1, 0, 0, 0, 0, time.UTC), time.Date(2011, 11, 15, 0, 0, 0, 0, time.UTC), 45)
+}
+
+func swarm(al *airline.Airline, agents int, generations int, workers int, seed int64) (*multicso.MultiCSO, *graph.Graph) {
+	// returns a chicken swarm for "al" and its graph
+	pairGraph := new(graph.Graph)
+	pairGraph.Initialization(agents)
+	pairGraph.Populate(al.PairsArray)
+	objective := fitness.DefaultObjective()
+	generator := randomness.New(seed)
+	Mtr := new(metrics.Metrics)
+	Mtr.Initialization(generations*agents, objective.UnitCost)
+	Mtr.Seed, _ = generator.State()
+	criteria := new(stopping.Criteria)
+	criteria.Initialization(0, -1, 0, false)
+	s := new(multicso.MultiCSO)
+	s.Initialization(al, pairGraph, agents, generations, 0.5, Mtr, objective, workers, criteria, generator)
+	return s, pairGraph
+}
+
+func TestResume(t *testing.T) {
+	// a run continued from a checkpoint gives the results of the uninterrupted run
+	tests := []struct {
+		name        string
+		generations int
+		every       int // generations between two checkpoints (the last one is resumed)
+		workers     int
+	}{
+		{"first generation", 6, 1, 1},
+		{"middle", 8, 4, 1},
+		{"last generation", 6, 5, 1},
+		{"concurrent", 8, 3, 4},
+	}
+	for _, test := range tests {
+		al := instance()
+		uninterrupted, pairGraph := swarm(al, 6, test.generations, test.workers, 7)
+		uninterrupted.MultiCSO(context.Background(), al, pairGraph)
+
+		// the checkpoint of the last multiple of "every" is resumed by a new swarm
+		filename := filepath.Join(t.TempDir(), "checkpoint.gz")
+		al = instance()
+		interrupted, pairGraph := swarm(al, 6, test.generations, test.workers, 7)
+		interrupted.SetCheckpoints(&checkpoint.Options{Filename: filename, Every: test.every})
+		interrupted.MultiCSO(context.Background(), al, pairGraph)
+		al = instance()
+		resumed, pairGraph := swarm(al, 6, test.generations, test.workers, 7)
+		resumed.Resume(checkpoint.Load(filename), al, pairGraph)
+		resumed.MultiCSO(context.Background(), al, pairGraph)
+
+		expected, got := uninterrupted.Mtr, resumed.Mtr
+		if got.GlobalBestSolutionCost != expected.GlobalBestSolutionCost {
+			t.Errorf("%s: best cost = %v, expected %v", test.name, got.GlobalBestSolutionCost, expected.GlobalBestSolutionCost)
+		}
+		if !slices.Equal(got.IterBestCost, expected.IterBestCost) || !slices.Equal(got.IterAverageCost, expected.IterAverageCost) {
+			t.Errorf("%s: costs of the generations = %v, expected %v", test.name, got.IterBestCost, expected.IterBestCost)
+		}
+		if got.Generations != expected.Generations || got.ValidSolutions != expected.ValidSolutions ||
+			got.Jumps != expected.Jumps || got.UniqueCount != expected.UniqueCount {
+			t.Errorf("%s: generations, valid solutions, jumps and unique solutions = %d %d %d %d, expected %d %d %d %d", test.name,
+				got.Generations, got.ValidSolutions, got.Jumps, got.UniqueCount,
+				expected.Generations, expected.ValidSolutions, expected.Jumps, expected.UniqueCount)
+		}
+		for i, chicken := range resumed.Swarm {
+			encoded := checkpoint.EncodeSolution(chicken.Solution)
+			expectedEncoded := checkpoint.EncodeSolution(uninterrupted.Swarm[i].Solution)
+			if chicken.Cost != uninterrupted.Swarm[i].Cost || len(encoded) != len(expectedEncoded) {
+				t.Errorf("%s: chicken %d has the cost %v, expected %v", test.name, i, chicken.Cost, uninterrupted.Swarm[i].Cost)
+				continue
+			}
+			for pilot := range encoded {
+				if !slices.Equal(encoded[pilot], expectedEncoded[pilot]) {
+					t.Errorf("%s: chicken %d assigns %v to pilot %d, expected %v", test.name, i, encoded[pilot], pilot, expectedEncoded[pilot])
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package nsga
+
+import (
+	"go-airline-crew-rostering/airline"
+	"go-airline-crew-rostering/checkpoint"
+	"go-airline-crew-rostering/graph"
+)
+
+func (nsga *NSGA) SetCheckpoints(options *checkpoint.Options) {
+	// Save checkpoints during the execution based on "options"
+	nsga.checkpoints = options
+}
+
+func (nsga *NSGA) checkpoint(generation int, globalBest *Individual, pairGraph *graph.Graph) *checkpoint.Checkpoint {
+	// returns the state of the population after "generation" (only the parents are
+	// stored, since the offspring are replaced in the next generation)
+	// the generator used for the selection is stored after the generators of the ids
+	state := &checkpoint.Checkpoint{Algorithm: "NSGA", Seed: nsga.Mtr.Seed, Generation: generation, GlobalBest: globalBest.Id}
+	for _, individual := range nsga.Population[:nsga.population] {
+		state.Agents = append(state.Agents, encodeIndividual(individual))
+	}
+	for _, generator := range nsga.generators {
+		state.Generators = append(state.Generators, checkpoint.EncodeGenerator(generator))
+	}
+	state.Generators = append(state.Generators, checkpoint.EncodeGenerator(nsga.generator))
+	state.Best = encodeIndividual(globalBest)
+	state.SetGraph(pairGraph)
+	state.SetMetrics(nsga.Mtr)
+	state.SetCriteria(nsga.criteria)
+	return state
+}
+
+func encodeIndividual(individual *Individual) *checkpoint.Agent {
+	// returns the state of an individual
+	return &checkpoint.Agent{
+		Cost:              individual.Cost,
+		Objectives:        individual.Objectives,
+		Solution:          checkpoint.EncodeSolution(individual.Solution),
+		CondensedSolution: individual.CondensedSolution,
+		Rank:              individual.rank,
+		Crowding:          individual.crowding,
+	}
+}
+
+func decodeIndividual(individual *Individual, agent *checkpoint.Agent, al *airline.Airline) {
+	// Restore the state of an individual
+	individual.Cost = agent.Cost
+	individual.Objectives = agent.Objectives
+	individual.Solution = checkpoint.DecodeSolution(agent.Solution, al)
+	individual.CondensedSolution = agent.CondensedSolution
+	individual.rank, individual.crowding = agent.Rank, agent.Crowding
+}
+
+func (nsga *NSGA) Resume(state *checkpoint.Checkpoint, al *airline.Airline, pairGraph *graph.Graph) {
+	// Restore the state of the population, so that the execution continues
+	// after the generation of the checkpoint
+	state.Check("NSGA", nsga.population)
+	for i, agent := range state.Agents {
+		decodeIndividual(nsga.Population[i], agent, al)
+	}
+	for i, generator := range nsga.generators {
+		generator.Restore(state.Generators[i].Seed, state.Generators[i].Draws)
+	}
+	last := state.Generators[len(state.Generators)-1]
+	nsga.generator.Restore(last.Seed, last.Draws)
+	nsga.globalBest = new(Individual)
+	nsga.globalBest.Initialization(state.GlobalBest)
+	decodeIndividual(nsga.globalBest, state.Best, al)
+	state.RestoreGraph(pairGraph, al.PairsArray)
+	state.RestoreMetrics(nsga.Mtr)
+	state.RestoreCriteria(nsga.criteria)
+	nsga.start = state.Generation + 1
+}
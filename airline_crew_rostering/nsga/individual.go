@@ -0,0 +1,97 @@
+package nsga
+
+import (
+	"go-airline-crew-rostering/airline"
+	"go-airline-crew-rostering/graph"
+	"go-airline-crew-rostering/problem"
+	"go-airline-crew-rostering/randomness"
+)
+
+var reinforcement float64 = 0.5 // fraction of the distance to maxPosition added to the positions of the edges used by a solution
+var maxPosition float64 = 4     // position approached by the edges that are used by every solution
+
+// Container for the functions related to an individual
+type IndividualRepo interface {
+	Initialization() *Individual
+	Dominates() bool
+	Crossover()
+	ConstructSolution() bool
+	Reinforce()
+}
+
+type Individual struct {
+	Id                int
+	Objectives        []float64        // objective values of the solution (all of them are minimized)
+	Cost              float64          // cost of the solution
+	Solution          []*airline.Pilot // Array of pilots representing the solution found by the individual
+	CondensedSolution []int            // solution in another form (used for easier calculation of metrics)
+	rank              int              // index of the non dominated front of the individual
+	crowding          float64          // crowding distance of the individual in its front
+}
+
+func (individual *Individual) Initialization(id int) *Individual {
+	// Initialization of an instance of an individual
+	individual.Id = id
+	individual.Objectives = []float64{}
+	individual.Cost = 0
+	individual.Solution = []*airline.Pilot{}
+	individual.CondensedSolution = []int{}
+	individual.rank, individual.crowding = 0, 0
+	return individual
+}
+
+func (individual *Individual) Dominates(other *Individual) bool {
+	// returns true if the individual is not worse than "other" in any
+	// objective and strictly better in at least one
+	better := false
+	for i, objective := range individual.Objectives {
+		if objective > other.Objectives[i] {
+			return false
+		} else if objective < other.Objectives[i] {
+			better = true
+		}
+	}
+	return better
+}
+
+func (individual *Individual) Crossover(edge *graph.Edge, parent1 *Individual, parent2 *Individual, mutation float64, generator *randomness.Generator) {
+	// Set the position of a graph edge by taking the position of a random
+	// parent (uniform crossover) and mutating it with probability "mutation"
+	position := edge.Position[parent1.Id]
+	if generator.Float64() < 0.5 {
+		position = edge.Position[parent2.Id]
+	}
+	if generator.Float64() < mutation {
+		position = position * (1 + generator.NormFloat64())
+	}
+	edge.Position[individual.Id] = position
+}
+
+func (individual *Individual) ConstructSolution(al *airline.Airline, pairGraph *graph.Graph, generator *randomness.Generator) bool {
+	// Build a new solution for the individual
+	// Returns true if the solution covers all given pairings, false otherwise
+	pilotsArray, condensedSolution, validSolution := problem.ConstructSolution(al, pairGraph, individual.Id, generator.Rand)
+	individual.Solution = append([]*airline.Pilot(nil), pilotsArray...)
+	individual.CondensedSolution = append([]int{}, condensedSolution...)
+	return validSolution
+}
+
+func (individual *Individual) Reinforce(pairGraph *graph.Graph) {
+	// Move the positions of the edges used by the individual's solution towards
+	// maxPosition, so that its offspring inherit the structure of the solution
+	// without a single edge dominating the selection of the pilots
+	for _, pilot := range individual.Solution {
+		for i := 0; i < pilot.AssignedLength; i++ {
+			sourcePairId := pilot.AssignedPairs[i].Id
+			goalPairId := pilot.AssignedPairs[i+1].Id
+			edge, edgeExists := pairGraph.Nodes[sourcePairId].Edges[goalPairId]
+			// Create a new edge if a pair of successive pairings has not be
+			// found in another solution so far
+			if !edgeExists {
+				pairGraph.AddEdge(sourcePairId, goalPairId)
+				edge = pairGraph.Nodes[sourcePairId].Edges[goalPairId]
+			}
+			edge.Position[individual.Id] += reinforcement * (maxPosition - edge.Position[individual.Id])
+		}
+	}
+}
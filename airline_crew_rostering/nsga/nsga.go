@@ -0,0 +1,376 @@
+package nsga
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"go-airline-crew-rostering/airline"
+	"go-airline-crew-rostering/checkpoint"
+	"go-airline-crew-rostering/fitness"
+	"go-airline-crew-rostering/graph"
+	"go-airline-crew-rostering/metrics"
+	"go-airline-crew-rostering/problem"
+	"go-airline-crew-rostering/randomness"
+	"go-airline-crew-rostering/stopping"
+	"go-airline-crew-rostering/workers"
+)
+
+// Container for the functions related to NSGA-II
+type NSGARepo interface {
+	Initialization() *NSGA
+	NSGA() []*Individual
+	solutions()
+	WarmStart()
+	offspring()
+	selection()
+	rank() [][]*Individual
+	nonDominatedSort() [][]*Individual
+	crowdingDistance()
+	tournament() *Individual
+	paretoFront() []*Individual
+}
+
+// Population of individuals evolved by the non dominated sorting genetic algorithm (NSGA-II).
+// The individuals with ids smaller than "population" are the parents and the rest
+// are their offspring, so the graph holds positions for twice the population.
+type NSGA struct {
+	Population     []*Individual           // List of the individuals (parents and offspring)
+	Front          []*Individual           // Non dominated individuals of the final population
+	population     int                     // Number of parents in the population
+	maxGenerations int                     // Maximum number of iterations executed by the optimization algorithm
+	mutation       float64                 // probability of mutating the position of an edge
+	Mtr            *metrics.Metrics        // Metrics used to evaluate the algorithm's efficiency
+	objective      *fitness.Objective      // Objective used to calculate the cost of the solutions
+	costList       []float64               // List of solutions' cost found by the offspring in the current generation
+	workers        int                     // Maximum number of solutions built concurrently
+	criteria       *stopping.Criteria      // Criteria used to stop the algorithm before "maxGenerations"
+	generator      *randomness.Generator   // random number generator used for the selection of the parents
+	generators     []*randomness.Generator // random number generator used by the individual of each id
+	checkpoints    *checkpoint.Options     // Options of the checkpoints saved during the execution
+	start          int                     // first generation executed (bigger than 1 if the run continues from a checkpoint)
+	globalBest     *Individual             // individual with the best solution when the run continues from a checkpoint
+}
+
+func (nsga *NSGA) Initialization(al *airline.Airline, pairGraph *graph.Graph,
+	population int, maxGenerations int, mutation float64, Mtr *metrics.Metrics, objective *fitness.Objective, workers int, criteria *stopping.Criteria,
+	generator *randomness.Generator) *NSGA {
+	// Initialize an instance of NSGA-II
+	nsga.Population = []*Individual{}
+	nsga.Front = []*Individual{}
+	nsga.population = population
+	nsga.maxGenerations = maxGenerations
+	nsga.mutation = mutation
+	nsga.costList = []float64{}
+	nsga.Mtr = Mtr
+	nsga.objective = objective
+	nsga.workers = workers
+	nsga.criteria = criteria
+	nsga.generator = generator
+	nsga.generators = []*randomness.Generator{}
+	nsga.checkpoints = nil
+	nsga.start = 1
+	nsga.globalBest = nil
+
+	// Create the individuals and build the initial solutions for the parents
+	for agent := 0; agent < 2*population; agent++ {
+		individual := new(Individual)
+		individual.Initialization(agent)
+		nsga.Population = append(nsga.Population, individual)
+		nsga.generators = append(nsga.generators, randomness.New(generator.Int63()))
+	}
+	nsga.solutions(al, pairGraph, nsga.Population[:population])
+	return nsga
+}
+
+func (nsga *NSGA) solutions(al *airline.Airline, pairGraph *graph.Graph, individuals []*Individual) {
+	// Build new solutions for "individuals" and calculate their objectives
+	// The solutions are built concurrently, each one with the random generator of
+	// the individual's id, while the metrics and the graph are updated in the order
+	// of the individuals, so the results do not depend on the number of workers
+	validSolutions := make([]bool, len(individuals))
+	workers.ForEach(nsga.workers, len(individuals), func(i int) {
+		individual := individuals[i]
+		validSolutions[i] = individual.ConstructSolution(al, pairGraph, nsga.generators[individual.Id])
+		individual.Objectives = fitness.Objectives(individual.Solution, al)
+		_, individual.Cost = nsga.objective.Evaluate(individual.Solution, al)
+		individual.Cost *= nsga.Mtr.UnitCost
+	})
+
+	for i, individual := range individuals {
+		if validSolutions[i] {
+			nsga.Mtr.ValidSolutions++
+		}
+		nsga.costList = append(nsga.costList, individual.Cost)
+		individual.Reinforce(pairGraph)
+	}
+}
+
+func (nsga *NSGA) WarmStart(al *airline.Airline, pairGraph *graph.Graph, solution []*airline.Pilot) {
+	// Replace the solution of the first parent with "solution"
+	// (used to start the search from a known roster)
+	individual := nsga.Population[0]
+	individual.Solution = solution
+	individual.CondensedSolution = problem.CondensedSolution(al, solution)
+	individual.Objectives = fitness.Objectives(solution, al)
+	_, individual.Cost = nsga.objective.Evaluate(solution, al)
+	individual.Cost *= nsga.Mtr.UnitCost
+	nsga.costList[0] = individual.Cost
+	individual.Reinforce(pairGraph)
+}
+
+func (nsga *NSGA) offspring(al *airline.Airline, pairGraph *graph.Graph) {
+	// Create the offspring of the parents, by crossover and
+	// mutation of the positions of the graph edges
+	for agent := nsga.population; agent < 2*nsga.population; agent++ {
+		child := new(Individual)
+		child.Initialization(agent)
+		nsga.Population[agent] = child
+		parent1 := nsga.tournament()
+		parent2 := nsga.tournament()
+		for _, edge := range pairGraph.Edges {
+			child.Crossover(edge, parent1, parent2, nsga.mutation, nsga.generators[agent])
+		}
+	}
+	nsga.solutions(al, pairGraph, nsga.Population[nsga.population:])
+}
+
+func (nsga *NSGA) tournament() *Individual {
+	// Select a parent with binary tournament, preferring the individual
+	// with the smallest rank and then the one with the biggest crowding distance
+	individual1 := nsga.Population[nsga.generator.Intn(nsga.population)]
+	individual2 := nsga.Population[nsga.generator.Intn(nsga.population)]
+	if individual2.rank < individual1.rank ||
+		(individual2.rank == individual1.rank && individual2.crowding > individual1.crowding) {
+		return individual2
+	}
+	return individual1
+}
+
+func (nsga *NSGA) nonDominatedSort(individuals []*Individual) [][]*Individual {
+	// Sort the individuals into non dominated fronts and store their rank
+	// returns the list of fronts, from best to worst
+	fronts := [][]*Individual{{}}
+	dominated := make(map[int][]*Individual) // individuals dominated by each individual
+	dominationCount := make(map[int]int)     // number of individuals that dominate each individual
+	for _, individual := range individuals {
+		for _, other := range individuals {
+			if individual.Dominates(other) {
+				dominated[individual.Id] = append(dominated[individual.Id], other)
+			} else if other.Dominates(individual) {
+				dominationCount[individual.Id]++
+			}
+		}
+		if dominationCount[individual.Id] == 0 {
+			individual.rank = 0
+			fronts[0] = append(fronts[0], individual)
+		}
+	}
+	for i := 0; len(fronts[i]) > 0; i++ {
+		nextFront := []*Individual{}
+		for _, individual := range fronts[i] {
+			for _, other := range dominated[individual.Id] {
+				dominationCount[other.Id]--
+				if dominationCount[other.Id] == 0 {
+					other.rank = i + 1
+					nextFront = append(nextFront, other)
+				}
+			}
+		}
+		fronts = append(fronts, nextFront)
+	}
+	return fronts[:len(fronts)-1]
+}
+
+func (nsga *NSGA) crowdingDistance(front []*Individual) {
+	// Calculate the crowding distance of the individuals of a front
+	for _, individual := range front {
+		individual.crowding = 0
+	}
+	if len(front) == 0 {
+		return
+	}
+	for objective := range front[0].Objectives {
+		sort.SliceStable(front, func(i int, j int) bool {
+			return front[i].Objectives[objective] < front[j].Objectives[objective]
+		})
+		// the boundary individuals are always preferred
+		front[0].crowding = math.Inf(1)
+		front[len(front)-1].crowding = math.Inf(1)
+		valueRange := front[len(front)-1].Objectives[objective] - front[0].Objectives[objective]
+		if valueRange == 0 {
+			continue
+		}
+		for i := 1; i < len(front)-1; i++ {
+			front[i].crowding += (front[i+1].Objectives[objective] - front[i-1].Objectives[objective]) / valueRange
+		}
+	}
+}
+
+func (nsga *NSGA) rank(individuals []*Individual) [][]*Individual {
+	// Calculate the rank and the crowding distance of the individuals
+	// returns the list of non dominated fronts, from best to worst
+	fronts := nsga.nonDominatedSort(individuals)
+	for _, front := range fronts {
+		nsga.crowdingDistance(front)
+	}
+	return fronts
+}
+
+func (nsga *NSGA) selection(pairGraph *graph.Graph) {
+	// Select the parents of the next generation from the parents and the offspring
+	// of the current generation and move their positions to the parents' ids
+	survivors := []*Individual{}
+	for _, front := range nsga.rank(nsga.Population) {
+		if len(survivors)+len(front) > nsga.population {
+			sort.SliceStable(front, func(i int, j int) bool {
+				return front[i].crowding > front[j].crowding
+			})
+			front = front[:nsga.population-len(survivors)]
+		}
+		survivors = append(survivors, front...)
+		if len(survivors) == nsga.population {
+			break
+		}
+	}
+
+	positions := make([]float64, nsga.population)
+	for _, edge := range pairGraph.Edges {
+		for i, individual := range survivors {
+			positions[i] = edge.Position[individual.Id]
+		}
+		copy(edge.Position, positions)
+	}
+	for i, individual := range survivors {
+		individual.Id = i
+		nsga.Population[i] = individual
+	}
+}
+
+func (nsga *NSGA) paretoFront() []*Individual {
+	// Find the distinct non dominated individuals of the parents
+	// returns them sorted by their uncovered pairs and then by their cost
+	front := []*Individual{}
+	for _, individual := range nsga.nonDominatedSort(nsga.Population[:nsga.population])[0] {
+		duplicate := false
+		for _, other := range front {
+			if slicesEqual(individual.Objectives, other.Objectives) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			front = append(front, individual)
+		}
+	}
+	sort.SliceStable(front, func(i int, j int) bool {
+		if front[i].Objectives[0] != front[j].Objectives[0] {
+			return front[i].Objectives[0] < front[j].Objectives[0]
+		}
+		return front[i].Objectives[1] < front[j].Objectives[1]
+	})
+	return front
+}
+
+func slicesEqual(a []float64, b []float64) bool {
+	// returns true if the two lists have the same values
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return len(a) == len(b)
+}
+
+func (nsga *NSGA) NSGA(ctx context.Context, al *airline.Airline, pairGraph *graph.Graph) []*Individual {
+	// Main body of the optimization algorithm
+	// Returns the non dominated individuals of the final population
+
+	// Calculate Metrics for the initialization step
+	// (unless the run continues from a checkpoint)
+	globalBest := nsga.globalBest
+	reason := ""
+	if nsga.start == 1 {
+		nsga.rank(nsga.Population[:nsga.population])
+		globalBest = nsga.calculateMetrics(nil, 0)
+		reason = nsga.criteria.Stop(ctx, nsga.Mtr.GlobalBestSolutionCost, globalBest.Solution, al)
+	}
+
+	// Execute the algorithm for iterations equal to "maxGenerations"
+	// or until one of the stopping criteria is met
+	generations := nsga.start
+	for t := nsga.start; t < nsga.maxGenerations && reason == ""; t++ {
+		generations++
+
+		nsga.costList = []float64{} // empty the cost list from the previous iteration
+
+		// build solutions for the offspring
+		nsga.offspring(al, pairGraph)
+
+		// Calculate the metrics of the current iteration
+		globalBest = nsga.calculateMetrics(globalBest, t)
+
+		// Keep the best individuals as parents of the next generation
+		nsga.selection(pairGraph)
+
+		reason = nsga.criteria.Stop(ctx, nsga.Mtr.GlobalBestSolutionCost, globalBest.Solution, al)
+		if nsga.checkpoints.Due(t, reason) {
+			nsga.checkpoints.Save(nsga.checkpoint(t, globalBest, pairGraph))
+		}
+	}
+	if reason == "" {
+		reason = stopping.MaxGenerations
+	}
+	nsga.Mtr.StopReason = reason
+
+	nsga.Front = nsga.paretoFront()
+	for _, individual := range nsga.Front {
+		nsga.Mtr.ParetoFront = append(nsga.Mtr.ParetoFront, individual.Solution)
+		nsga.Mtr.ParetoObjectives = append(nsga.Mtr.ParetoObjectives, individual.Objectives)
+	}
+	nsga.Mtr.GlobalBestSolutionCost = nsga.Front[0].Cost
+	nsga.Mtr.Generations = generations
+	nsga.Mtr.TotalSolutions = nsga.population * generations
+	nsga.Mtr.AverageSimilarity = nsga.Mtr.AverageSimilarity / float64(nsga.population*generations)
+	return nsga.Front
+}
+
+func (nsga *NSGA) calculateMetrics(globalBest *Individual, generation int) *Individual {
+	// Calculate metrics of current iteration and update all metrics
+	// returns the individual with the fewest uncovered pairs and then the smallest cost
+	individuals := nsga.Population[:nsga.population]
+	if generation > 0 {
+		individuals = nsga.Population[nsga.population:]
+	}
+	nsga.Mtr.SetUpIterationMetrics(nsga.costList)
+	best := individuals[0]
+	for _, individual := range individuals {
+		if individual.Objectives[0] < best.Objectives[0] ||
+			(individual.Objectives[0] == best.Objectives[0] && individual.Cost < best.Cost) {
+			best = individual
+		}
+	}
+	bestSolutionString := nsga.Mtr.SolutionEncoding(best.CondensedSolution, best.Solution)
+	if globalBest == nil || best.Objectives[0] < globalBest.Objectives[0] ||
+		(best.Objectives[0] == globalBest.Objectives[0] && best.Cost < globalBest.Cost) {
+		globalBest = best
+		nsga.Mtr.GlobalBestSolutionCost = best.Cost
+		nsga.Mtr.GlobalBestString = bestSolutionString
+		nsga.Mtr.Jumps++
+	}
+	similarities := []float64{}
+	for _, individual := range individuals {
+		if individual == best && generation == 0 {
+			continue
+		}
+		normalisedSolution := nsga.Mtr.SolutionEncoding(individual.CondensedSolution, individual.Solution)
+		similarities = append(similarities, nsga.Mtr.SolutionSimilarity(nsga.Mtr.GlobalBestString, normalisedSolution))
+	}
+	// the first objective of an individual is the number of its uncovered pairs
+	nsga.Mtr.SetUpDiversityMetrics(similarities, int(best.Objectives[0]))
+	if generation%200 == 0 {
+		fmt.Println("Generation", generation)
+	}
+	return globalBest
+}
@@ -0,0 +1,188 @@
+package nsga_test
+
+import (
+	"context"
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go-airline-crew-rostering/airline"
+	"go-airline-crew-rostering/checkpoint"
+	"go-airline-crew-rostering/fitness"
+	"go-airline-crew-rostering/graph"
+	"go-airline-crew-rostering/input"
+	"go-airline-crew-rostering/metrics"
+	"go-airline-crew-rostering/nsga"
+	"go-airline-crew-rostering/randomness"
+	"go-airline-crew-rostering/stopping"
+
+	"golang.org/x/exp/slices"
+)
+
+func instance() *airline.Airline {
+	// returns an airline with two weeks of pairings and 45 pilots
+	return input.ReadInstance("../Pairings.csv", time.Date(2011, 11, 1, 0, 0, 0, 0, time.UTC), time.Date(2011, 11, 15, 0, 0, 0, 0, time.UTC), 45)
+}
+
+func population(al *airline.Airline, agents int, generations int, workers int, seed int64) (*nsga.NSGA, *graph.Graph) {
+	// returns a population for "al" and its graph (with positions for the parents and the offspring)
+	pairGraph := new(graph.Graph)
+	pairGraph.Initialization(2 * agents)
+	pairGraph.Populate(al.PairsArray)
+	objective := fitness.DefaultObjective()
+	generator := randomness.New(seed)
+	Mtr := new(metrics.Metrics)
+	Mtr.Initialization(generations*agents, objective.UnitCost)
+	Mtr.Seed, _ = generator.State()
+	criteria := new(stopping.Criteria)
+	criteria.Initialization(0, -1, 0, false)
+	p := new(nsga.NSGA)
+	p.Initialization(al, pairGraph, agents, generations, 0.1, Mtr, objective, workers, criteria, generator)
+	return p, pairGraph
+}
+
+func TestDominates(t *testing.T) {
+	tests := []struct {
+		a         []float64
+		b         []float64
+		dominates bool
+	}{
+		{[]float64{0, 1}, []float64{0, 2}, true},
+		{[]float64{0, 2}, []float64{0, 1}, false},
+		{[]float64{0, 1}, []float64{0, 1}, false},
+		{[]float64{1, 0}, []float64{0, 1}, false},
+		{[]float64{0, 0, 0}, []float64{1, 1, 1}, true},
+	}
+	for _, test := range tests {
+		a, b := new(nsga.Individual), new(nsga.Individual)
+		a.Objectives, b.Objectives = test.a, test.b
+		if dominates := a.Dominates(b); dominates != test.dominates {
+			t.Errorf("%v dominates %v = %v, expected %v", test.a, test.b, dominates, test.dominates)
+		}
+	}
+}
+
+func TestReinforce(t *testing.T) {
+	// the edges used by a solution approach the maximum position without reaching it
+	al := instance()
+	p, pairGraph := population(al, 2, 1, 1, 1)
+	individual := p.Population[0]
+	for i := 0; i < 50; i++ {
+		individual.Reinforce(pairGraph)
+	}
+	for _, edge := range pairGraph.Edges {
+		if edge.Position[0] > 4 || math.IsNaN(edge.Position[0]) {
+			t.Errorf("edge %d has the position %v", edge.Id, edge.Position[0])
+		}
+	}
+}
+
+func TestFront(t *testing.T) {
+	// the front has distinct non dominated individuals sorted by their uncovered pairs
+	// and it does not depend on the number of workers
+	tests := []struct {
+		name        string
+		agents      int
+		generations int
+		seed        int64
+	}{
+		{"small population", 4, 4, 1},
+		{"bigger population", 8, 3, 2},
+	}
+	for _, test := range tests {
+		fronts := [][][]float64{}
+		for _, workers := range []int{1, 4} {
+			al := instance()
+			p, pairGraph := population(al, test.agents, test.generations, workers, test.seed)
+			front := p.NSGA(context.Background(), al, pairGraph)
+			if len(front) == 0 || len(p.Mtr.ParetoFront) != len(front) || len(p.Mtr.ParetoObjectives) != len(front) {
+				t.Fatalf("%s: front of %d individuals, %d stored", test.name, len(front), len(p.Mtr.ParetoFront))
+			}
+			objectives := [][]float64{}
+			for i, individual := range front {
+				if !slices.Equal(individual.Objectives, fitness.Objectives(individual.Solution, al)) {
+					t.Errorf("%s: individual %d has the objectives %v, expected those of its solution", test.name, i, individual.Objectives)
+				}
+				for j, other := range front {
+					if other.Dominates(individual) || (i != j && slices.Equal(other.Objectives, individual.Objectives)) {
+						t.Errorf("%s: individual %d is dominated by or equal to %d", test.name, i, j)
+					}
+				}
+				if i > 0 && (individual.Objectives[0] < front[i-1].Objectives[0] ||
+					(individual.Objectives[0] == front[i-1].Objectives[0] && individual.Objectives[1] < front[i-1].Objectives[1])) {
+					t.Errorf("%s: individual %d is out of order", test.name, i)
+				}
+				objectives = append(objectives, individual.Objectives)
+			}
+			if p.Mtr.GlobalBestSolutionCost != front[0].Cost || p.Mtr.Generations != test.generations || p.Mtr.StopReason != stopping.MaxGenerations {
+				t.Errorf("%s: best cost %v after %d generations (%s), expected %v after %d", test.name,
+					p.Mtr.GlobalBestSolutionCost, p.Mtr.Generations, p.Mtr.StopReason, front[0].Cost, test.generations)
+			}
+			fronts = append(fronts, objectives)
+		}
+		if len(fronts[0]) != len(fronts[1]) {
+			t.Errorf("%s: fronts of %d and %d individuals with 1 and 4 workers", test.name, len(fronts[0]), len(fronts[1]))
+			continue
+		}
+		for i := range fronts[0] {
+			if !slices.Equal(fronts[0][i], fronts[1][i]) {
+				t.Errorf("%s: individual %d has the objectives %v and %v with 1 and 4 workers", test.name, i, fronts[0][i], fronts[1][i])
+			}
+		}
+	}
+}
+
+func TestResume(t *testing.T) {
+	// a run continued from a checkpoint gives the results of the uninterrupted run
+	tests := []struct {
+		name        string
+		generations int
+		every       int // generations between two checkpoints (the last one is resumed)
+		workers     int
+	}{
+		{"first generation", 5, 1, 1},
+		{"middle", 6, 3, 1},
+		{"last generation", 5, 4, 1},
+		{"concurrent", 6, 2, 4},
+	}
+	for _, test := range tests {
+		al := instance()
+		uninterrupted, pairGraph := population(al, 4, test.generations, test.workers, 7)
+		expectedFront := uninterrupted.NSGA(context.Background(), al, pairGraph)
+
+		// the checkpoint of the last multiple of "every" is resumed by a new population
+		filename := filepath.Join(t.TempDir(), "checkpoint.gz")
+		al = instance()
+		interrupted, pairGraph := population(al, 4, test.generations, test.workers, 7)
+		interrupted.SetCheckpoints(&checkpoint.Options{Filename: filename, Every: test.every})
+		interrupted.NSGA(context.Background(), al, pairGraph)
+		al = instance()
+		resumed, pairGraph := population(al, 4, test.generations, test.workers, 7)
+		resumed.Resume(checkpoint.Load(filename), al, pairGraph)
+		front := resumed.NSGA(context.Background(), al, pairGraph)
+
+		expected, got := uninterrupted.Mtr, resumed.Mtr
+		if got.GlobalBestSolutionCost != expected.GlobalBestSolutionCost {
+			t.Errorf("%s: best cost = %v, expected %v", test.name, got.GlobalBestSolutionCost, expected.GlobalBestSolutionCost)
+		}
+		if !slices.Equal(got.IterBestCost, expected.IterBestCost) || !slices.Equal(got.IterAverageCost, expected.IterAverageCost) {
+			t.Errorf("%s: costs of the generations = %v, expected %v", test.name, got.IterBestCost, expected.IterBestCost)
+		}
+		if got.Generations != expected.Generations || got.ValidSolutions != expected.ValidSolutions ||
+			got.Jumps != expected.Jumps || got.UniqueCount != expected.UniqueCount {
+			t.Errorf("%s: generations, valid solutions, jumps and unique solutions = %d %d %d %d, expected %d %d %d %d", test.name,
+				got.Generations, got.ValidSolutions, got.Jumps, got.UniqueCount,
+				expected.Generations, expected.ValidSolutions, expected.Jumps, expected.UniqueCount)
+		}
+		if len(front) != len(expectedFront) {
+			t.Errorf("%s: front of %d individuals, expected %d", test.name, len(front), len(expectedFront))
+			continue
+		}
+		for i, individual := range front {
+			if !slices.Equal(individual.Objectives, expectedFront[i].Objectives) {
+				t.Errorf("%s: individual %d of the front has the objectives %v, expected %v", test.name, i, individual.Objectives, expectedFront[i].Objectives)
+			}
+		}
+	}
+}
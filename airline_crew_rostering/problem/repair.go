@@ -0,0 +1,102 @@
+package problem
+
+import (
+	"go-airline-crew-rostering/airline"
+	"go-airline-crew-rostering/metrics"
+)
+
+// Rules that can prevent the assignment of a pairing
+const (
+	RestPeriodConstraint = "rest period"
+	DaysOffConstraint    = "days off"
+	NoConstraint         = "none" // a pilot can fly the pairing without breaking any rule
+)
+
+const chainLength = 2 // maximum number of pilots whose schedules change to cover a pairing
+
+func Repair(al *airline.Airline, pilots []*airline.Pilot, uncovered []*airline.Pair) []*airline.Pair {
+	// Try to assign each uncovered pairing, using ejection chains (a pairing that
+	// blocks the assignment is moved from pilot A to pilot B to make room in A's schedule)
+	// returns the pairings that remain uncovered
+	remaining := []*airline.Pair{}
+	for _, pair := range uncovered {
+		if !insert(al, pilots, pair, chainLength-1, nil) {
+			remaining = append(remaining, pair)
+		}
+	}
+	return remaining
+}
+
+func insert(al *airline.Airline, pilots []*airline.Pilot, pair *airline.Pair, depth int, excluded *airline.Pilot) bool {
+	// Assign "pair" to a pilot other than "excluded", moving up to "depth"
+	// blocking pairings to other pilots
+	// returns true on success
+	for _, pilot := range pilots {
+		if pilot == excluded {
+			continue
+		}
+		index := al.RestPeriodRule(pilot, pair)
+		if index > -1 && al.DaysOffRule(pilot, pair) {
+			pilot.Add(pair, index)
+			return true
+		}
+	}
+	if depth == 0 {
+		return false
+	}
+	for _, pilot := range pilots {
+		if pilot == excluded {
+			continue
+		}
+		for i := 1; i <= pilot.AssignedLength; i++ {
+			// only the pairings near "pair" can block it
+			blocking := pilot.AssignedPairs[i]
+			if blocking.StartDay > pair.EndDay+al.Timespan() || blocking.EndDay < pair.StartDay-al.Timespan() {
+				continue
+			}
+			pilot.Remove(blocking)
+			index := al.RestPeriodRule(pilot, pair)
+			if index > -1 && al.DaysOffRule(pilot, pair) {
+				pilot.Add(pair, index)
+				if insert(al, pilots, blocking, depth-1, pilot) {
+					return true
+				}
+				pilot.Remove(pair)
+			}
+			pilot.Add(blocking, al.RestPeriodRule(pilot, blocking))
+		}
+	}
+	return false
+}
+
+func UncoveredPairs(al *airline.Airline, solution []*airline.Pilot) []*metrics.Uncovered {
+	// returns the pairings that are not covered by "solution" along with the binding
+	// constraint of each one ("rest period" if no pilot has enough rest around the
+	// pairing, "days off" if the pilots with enough rest would lose their days off
+	// and "none" if a pilot could fly the pairing without breaking either rule)
+	covered := make(map[int]bool)
+	for _, pilot := range solution {
+		for _, pair := range pilot.AssignedPairs[1 : pilot.AssignedLength+1] {
+			covered[pair.Id] = true
+		}
+	}
+	uncovered := []*metrics.Uncovered{}
+	for _, pair := range al.PairsArray[1:] {
+		if covered[pair.Id] {
+			continue
+		}
+		constraint := RestPeriodConstraint
+		for _, pilot := range solution {
+			if al.RestPeriodRule(pilot, pair) == -1 {
+				continue
+			}
+			if al.DaysOffRule(pilot, pair) {
+				constraint = NoConstraint
+				break
+			}
+			constraint = DaysOffConstraint
+		}
+		uncovered = append(uncovered, &metrics.Uncovered{Pair: pair, Constraint: constraint})
+	}
+	return uncovered
+}
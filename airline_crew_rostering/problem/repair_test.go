@@ -0,0 +1,109 @@
+package problem_test
+
+import (
+	"testing"
+	"time"
+
+	"go-airline-crew-rostering/airline"
+	"go-airline-crew-rostering/problem"
+
+	"golang.org/x/exp/slices"
+)
+
+func schedule(pairs [][2]time.Time) *airline.Airline {
+	// returns two weeks from 2011-11-01 with the pairings "pairs" (pairing i+1 is pairs[i])
+	start := time.Date(2011, 11, 1, 0, 0, 0, 0, time.UTC)
+	al := new(airline.Airline)
+	al.Initialization(660, 7, 2, start, start.AddDate(0, 0, 14), 2)
+	root := new(airline.Pair)
+	root.Initialization(0, start)
+	al.PairsArray = []*airline.Pair{root}
+	for i, times := range pairs {
+		pair := new(airline.Pair)
+		pair.Initialization(i+1, start)
+		pair.Add(i+1, times[0], times[1], start)
+		al.PairsArray = append(al.PairsArray, pair)
+	}
+	return al
+}
+
+func roster(al *airline.Airline, assignments [][]int) []*airline.Pilot {
+	// returns the pilots of "al" with the pairings of "assignments" (in chronological order)
+	pilots := []*airline.Pilot{}
+	for id, pairs := range assignments {
+		pilot := new(airline.Pilot)
+		pilot.Initialization(id, al.ScheduleDuration, al.PairsArray[0])
+		for _, pair := range pairs {
+			pilot.Add(al.PairsArray[pair], pilot.AssignedLength+1)
+		}
+		pilots = append(pilots, pilot)
+	}
+	return pilots
+}
+
+func day(days int, hour int) time.Time {
+	return time.Date(2011, 11, 1+days, hour, 0, 0, 0, time.UTC)
+}
+
+func TestUncoveredPairs(t *testing.T) {
+	// pairing 1 is on day 6 and the others fill days 0 to 5
+	pairs := [][2]time.Time{{day(6, 8), day(6, 12)}}
+	for d := 0; d < 6; d++ {
+		pairs = append(pairs, [2]time.Time{day(d, 8), day(d, 12)})
+	}
+	pairs = append(pairs, [2]time.Time{day(6, 10), day(6, 14)})
+	al := schedule(pairs)
+	tests := []struct {
+		name        string
+		assignments [][]int
+		constraint  string
+	}{
+		{"overlapping pairing", [][]int{{8}, {8}}, problem.RestPeriodConstraint},
+		{"no days off", [][]int{{2, 3, 4, 5, 6, 7}, {8}}, problem.DaysOffConstraint},
+		{"free pilot", [][]int{{2, 3, 4, 5, 6, 7}, {}}, problem.NoConstraint},
+		{"free pilot first", [][]int{{}, {2, 3, 4, 5, 6, 7}}, problem.NoConstraint},
+	}
+	for _, test := range tests {
+		uncovered := problem.UncoveredPairs(al, roster(al, test.assignments))
+		var constraint string
+		for _, pair := range uncovered {
+			if pair.Pair.Id == 1 {
+				constraint = pair.Constraint
+			}
+		}
+		if constraint != test.constraint {
+			t.Errorf("%s: binding constraint %q, expected %q", test.name, constraint, test.constraint)
+		}
+	}
+}
+
+func TestRepair(t *testing.T) {
+	// pairing 1 overlaps pairing 2 of the first pilot and is too close to pairing 3
+	// of the second pilot, so it is covered by moving pairing 2 to the second pilot
+	al := schedule([][2]time.Time{{day(3, 8), day(3, 12)}, {day(3, 10), day(3, 14)}, {day(2, 18), day(2, 22)}})
+	pilots := roster(al, [][]int{{2}, {3}})
+	remaining := problem.Repair(al, pilots, []*airline.Pair{al.PairsArray[1]})
+	if len(remaining) != 0 {
+		t.Fatalf("%d pairings remain uncovered", len(remaining))
+	}
+	expected := [][]int{{1}, {3, 2}}
+	for i, pilot := range pilots {
+		ids := []int{}
+		for _, pair := range pilot.AssignedPairs[1 : pilot.AssignedLength+1] {
+			ids = append(ids, pair.Id)
+		}
+		if !slices.Equal(ids, expected[i]) {
+			t.Errorf("pilot %d has the pairings %v, expected %v", i, ids, expected[i])
+		}
+	}
+
+	// without a pilot to take pairing 2 the pairing stays uncovered
+	al = schedule([][2]time.Time{{day(3, 8), day(3, 12)}, {day(3, 10), day(3, 14)}, {day(3, 6), day(3, 7)}})
+	pilots = roster(al, [][]int{{2}, {3}})
+	if remaining := problem.Repair(al, pilots, []*airline.Pair{al.PairsArray[1]}); len(remaining) != 1 {
+		t.Errorf("%d pairings remain uncovered, expected 1", len(remaining))
+	}
+	if pilots[0].AssignedLength != 1 || pilots[0].AssignedPairs[1].Id != 2 || pilots[1].AssignedLength != 1 {
+		t.Errorf("a failed repair changes the roster")
+	}
+}
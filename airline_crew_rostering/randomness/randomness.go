@@ -0,0 +1,61 @@
+package randomness
+
+import "math/rand"
+
+// Container for the functions related to a random number generator
+type GeneratorRepo interface {
+	State() (int64, uint64)
+	Restore()
+}
+
+// Random number generator that keeps track of its state,
+// so that it can be saved and restored later
+type Generator struct {
+	*rand.Rand
+	source *source
+}
+
+// Source of random numbers that counts the numbers it has produced
+type source struct {
+	seed   int64
+	draws  uint64
+	source rand.Source64
+}
+
+func New(seed int64) *Generator {
+	// Create a new random number generator using "seed"
+	generator := new(Generator)
+	generator.source = &source{seed: seed, draws: 0, source: rand.NewSource(seed).(rand.Source64)}
+	generator.Rand = rand.New(generator.source)
+	return generator
+}
+
+func (generator *Generator) State() (int64, uint64) {
+	// returns the seed of the generator and the number of values it has produced
+	return generator.source.seed, generator.source.draws
+}
+
+func (generator *Generator) Restore(seed int64, draws uint64) {
+	// Bring the generator to the state described by "seed" and "draws"
+	generator.Seed(seed)
+	for i := uint64(0); i < draws; i++ {
+		generator.source.source.Uint64()
+	}
+	generator.source.draws = draws
+}
+
+func (src *source) Int63() int64 {
+	src.draws++
+	return src.source.Int63()
+}
+
+func (src *source) Uint64() uint64 {
+	src.draws++
+	return src.source.Uint64()
+}
+
+func (src *source) Seed(seed int64) {
+	src.seed = seed
+	src.draws = 0
+	src.source.Seed(seed)
+}
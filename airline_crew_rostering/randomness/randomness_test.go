@@ -0,0 +1,51 @@
+package randomness_test
+
+import (
+	"testing"
+
+	"go-airline-crew-rostering/randomness"
+)
+
+func TestRestore(t *testing.T) {
+	// a restored generator continues with the values of the original one
+	tests := []struct {
+		seed  int64
+		draws int
+	}{
+		{1, 0},
+		{1, 10},
+		{42, 1000},
+		{-7, 3},
+	}
+	for _, test := range tests {
+		generator := randomness.New(test.seed)
+		for i := 0; i < test.draws; i++ {
+			// the methods draw different amounts of values from the source
+			switch i % 4 {
+			case 0:
+				generator.Intn(10)
+			case 1:
+				generator.Float64()
+			case 2:
+				generator.NormFloat64()
+			case 3:
+				generator.Perm(5)
+			}
+		}
+		seed, draws := generator.State()
+		if seed != test.seed || (test.draws > 0 && draws == 0) {
+			t.Errorf("seed %d after %d calls: state = %d %d", test.seed, test.draws, seed, draws)
+		}
+		restored := randomness.New(0)
+		restored.Restore(seed, draws)
+		if restoredSeed, restoredDraws := restored.State(); restoredSeed != seed || restoredDraws != draws {
+			t.Errorf("seed %d: restored state = %d %d, expected %d %d", test.seed, restoredSeed, restoredDraws, seed, draws)
+		}
+		for i := 0; i < 20; i++ {
+			if value, expected := restored.Int63(), generator.Int63(); value != expected {
+				t.Errorf("seed %d after %d calls: value %d = %d, expected %d", test.seed, test.draws, i, value, expected)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package results
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"go-airline-crew-rostering/comparison"
+	"go-airline-crew-rostering/input"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Comparison, as stored by the json and the csv writers
+type comparisonRecord struct {
+	Measure  string          `json:"measure"` // compared measure of the runs
+	Alpha    float64         `json:"alpha"`   // significance level of the tests
+	Sets     []*setRecord    `json:"sets"`
+	Tests    []*testRecord   `json:"tests"`    // tests of every pair of sets
+	Manifest *manifestRecord `json:"manifest"` // configuration and provenance of the comparison
+}
+
+// Result set of the comparison
+type setRecord struct {
+	Name   string    `json:"name"`
+	Values []float64 `json:"values"` // compared measure of every run
+	Mean   float64   `json:"mean"`
+	Median float64   `json:"median"`
+	Std    float64   `json:"std"`
+	Best   float64   `json:"best"`
+	Worst  float64   `json:"worst"`
+	Curve  []float64 `json:"curve"` // best cost of each generation averaged over the runs
+}
+
+// Test of a pair of result sets
+type testRecord struct {
+	A           string  `json:"a"`
+	B           string  `json:"b"`
+	U           float64 `json:"u"`      // Mann-Whitney U statistic of A
+	PValue      float64 `json:"pValue"` // two-sided p-value of the Wilcoxon rank-sum test
+	A12         float64 `json:"a12"`    // Vargha-Delaney effect size
+	Delta       float64 `json:"delta"`  // Cliff's delta effect size
+	Magnitude   string  `json:"magnitude"`
+	Significant bool    `json:"significant"`
+	Better      string  `json:"better"` // name of the better set ("" if the difference is not significant)
+}
+
+func PrintComparison(c *comparison.Comparison, args *input.ArgumentCollection) {
+	// Store the statistical tests of every pair of result sets
+	// and their convergence curves in every requested format
+	for _, format := range *args.Formats {
+		writer, ok := Writers[format].(ComparisonWriter)
+		if !ok {
+			fmt.Printf("the %s format cannot store a comparison\n", format)
+			continue
+		}
+		writer.WriteComparison(FileName(*args.ResultsFile, Writers[format].Extension()), c, args)
+	}
+}
+
+func newComparisonRecord(c *comparison.Comparison, args *input.ArgumentCollection) *comparisonRecord {
+	// returns the comparison, as stored by the json and the csv writers
+	record := &comparisonRecord{Measure: c.Measure, Alpha: c.Alpha, Sets: []*setRecord{}, Tests: []*testRecord{}, Manifest: newManifest(0, args, nil)}
+	for _, set := range c.Sets {
+		record.Sets = append(record.Sets, &setRecord{set.Name, set.Values, set.Mean, set.Median, set.Std, set.Best, set.Worst, set.Curve})
+	}
+	for _, test := range c.Tests {
+		better := ""
+		if test.Significant {
+			better = test.Better.Name
+		}
+		record.Tests = append(record.Tests, &testRecord{test.A.Name, test.B.Name, test.U, test.PValue, test.A12, test.Delta,
+			test.Magnitude, test.Significant, better})
+	}
+	return record
+}
+
+func (writer *JSONWriter) WriteComparison(fileName string, c *comparison.Comparison, args *input.ArgumentCollection) {
+	// Create a json file with every result set, the tests of every pair of sets and the manifest
+	writeJSON(fileName, newComparisonRecord(c, args))
+}
+
+func (writer *CSVWriter) WriteComparison(fileName string, c *comparison.Comparison, args *input.ArgumentCollection) {
+	// Create a csv file with the tests of every pair of sets (one row per pair), and the summary
+	// of every set and the manifest in files with the same name and a suffix
+	record := newComparisonRecord(c, args)
+	tests := [][]string{{"a", "b", "u", "pValue", "a12", "delta", "magnitude", "significant", "better"}}
+	for _, test := range record.Tests {
+		tests = append(tests, []string{test.A, test.B, formatFloat(test.U), formatFloat(test.PValue), formatFloat(test.A12),
+			formatFloat(test.Delta), test.Magnitude, strconv.FormatBool(test.Significant), test.Better})
+	}
+	sets := [][]string{{"set", "runs", record.Measure + "_mean", record.Measure + "_median", record.Measure + "_std",
+		record.Measure + "_best", record.Measure + "_worst"}}
+	for _, set := range record.Sets {
+		sets = append(sets, []string{set.Name, strconv.Itoa(len(set.Values)), formatFloat(set.Mean), formatFloat(set.Median),
+			formatFloat(set.Std), formatFloat(set.Best), formatFloat(set.Worst)})
+	}
+	writeCSV(fileName, tests)
+	writeCSV(strings.TrimSuffix(fileName, ".csv")+"_sets.csv", sets)
+	writeManifestCSV(strings.TrimSuffix(fileName, ".csv")+"_manifest.csv", record.Manifest)
+}
+
+func (writer *XLSXWriter) WriteComparison(fileName string, c *comparison.Comparison, args *input.ArgumentCollection) {
+	// Creates an excel file to store the statistical tests of every pair
+	// of result sets and their convergence curves
+	f := excelize.NewFile()
+	defer func() {
+		if err := f.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	docProperties, _ := f.GetDocProps()
+	docProperties.Language = "en-UK"
+	f.SetDocProps(docProperties)
+
+	f.SetDefaultFont("Arial")
+
+	comparisonSheetName := "Comparison"
+	convergenceSheetName := "Convergence"
+	manifestSheetName := "Manifest"
+	f.SetSheetName("Sheet1", comparisonSheetName)
+	if _, err := f.NewSheet(convergenceSheetName); err != nil {
+		fmt.Println(err)
+		return
+	}
+	if _, err := f.NewSheet(manifestSheetName); err != nil {
+		fmt.Println(err)
+		return
+	}
+	drawComparisonSheet(f, comparisonSheetName, c)
+	drawConvergenceSheet(f, convergenceSheetName, c, fileName)
+	drawManifestSheet(f, newManifest(0, args, nil))
+
+	if err := f.SaveAs(fileName); err != nil {
+		fmt.Println(err)
+	}
+}
+
+func drawComparisonSheet(f *excelize.File, sheetName string, c *comparison.Comparison) {
+	// create an excel sheet containing the summary of every result set
+	// and the tests of every pair of sets
+	setView(f, sheetName, 100.0)
+
+	headerStyleId, _ := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Size: 12, Color: "FFFFFF", Bold: true},
+		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"4F81BD"}},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center", WrapText: true},
+	})
+	cellStyleId, _ := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Size: 12},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center", WrapText: true},
+	})
+	enable := true
+	f.SetColWidth(sheetName, "B", "C", 30)
+	f.SetColWidth(sheetName, "D", "I", 14)
+	f.SetColWidth(sheetName, "J", "J", 30)
+
+	// summary of the measure of every set
+	measure := strings.ToUpper(c.Measure[:1]) + c.Measure[1:]
+	headers := []string{"Set", "Runs", measure + " Mean", measure + " Median", measure + " Std", measure + " Best", measure + " Worst"}
+	for i, header := range headers {
+		cell, _ := excelize.CoordinatesToCellName(2+i, 2)
+		f.SetCellValue(sheetName, cell, header)
+	}
+	f.SetRowHeight(sheetName, 2, 40)
+	f.SetCellStyle(sheetName, "B2", "H2", headerStyleId)
+	for i, set := range c.Sets {
+		row := 3 + i
+		values := []interface{}{set.Name, len(set.Values), set.Mean, set.Median, set.Std, set.Best, set.Worst}
+		for j, value := range values {
+			if number, isNumber := value.(float64); isNumber {
+				value = math.Round(number*100) / 100
+			}
+			cell, _ := excelize.CoordinatesToCellName(2+j, row)
+			f.SetCellValue(sheetName, cell, value)
+		}
+		f.SetCellStyle(sheetName, fmt.Sprintf("B%d", row), fmt.Sprintf("H%d", row), cellStyleId)
+	}
+	f.AddTable(sheetName, &excelize.Table{
+		Range:          fmt.Sprintf("B2:H%d", 2+len(c.Sets)),
+		Name:           "Sets",
+		StyleName:      "TableStyleMedium9",
+		ShowRowStripes: &enable,
+	})
+
+	// Wilcoxon rank-sum test and effect sizes of every pair of sets
+	start := 5 + len(c.Sets)
+	headers = []string{"Set A", "Set B", "U", "p-value", "A12", "Cliff's Delta", "Magnitude", "Significant", "Better Set"}
+	for i, header := range headers {
+		cell, _ := excelize.CoordinatesToCellName(2+i, start)
+		f.SetCellValue(sheetName, cell, header)
+	}
+	f.SetRowHeight(sheetName, start, 40)
+	f.SetCellStyle(sheetName, fmt.Sprintf("B%d", start), fmt.Sprintf("J%d", start), headerStyleId)
+	for i, test := range c.Tests {
+		row := start + 1 + i
+		better := "-"
+		if test.Significant {
+			better = test.Better.Name
+		}
+		values := []interface{}{test.A.Name, test.B.Name, test.U, math.Round(test.PValue*10000) / 10000,
+			math.Round(test.A12*1000) / 1000, math.Round(test.Delta*1000) / 1000, test.Magnitude, test.Significant, better}
+		for j, value := range values {
+			cell, _ := excelize.CoordinatesToCellName(2+j, row)
+			f.SetCellValue(sheetName, cell, value)
+		}
+		f.SetCellStyle(sheetName, fmt.Sprintf("B%d", row), fmt.Sprintf("J%d", row), cellStyleId)
+	}
+	f.AddTable(sheetName, &excelize.Table{
+		Range:          fmt.Sprintf("B%d:J%d", start, start+int(math.Max(float64(len(c.Tests)), 1))),
+		Name:           "Tests",
+		StyleName:      "TableStyleMedium9",
+		ShowRowStripes: &enable,
+	})
+}
+
+func drawConvergenceSheet(f *excelize.File, sheetName string, c *comparison.Comparison, resultsFile string) {
+	// create an excel sheet containing the best cost of each generation
+	// averaged over the runs of every set, both as a plot and as a table
+	setView(f, sheetName, 100.0)
+
+	headerStyleId, _ := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Size: 12, Color: "FFFFFF", Bold: true},
+		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"4F81BD"}},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center", WrapText: true},
+	})
+	generations := 0
+	f.SetCellValue(sheetName, "M2", "Generation")
+	for i, set := range c.Sets {
+		cell, _ := excelize.CoordinatesToCellName(14+i, 2)
+		f.SetCellValue(sheetName, cell, set.Name)
+		for t, cost := range set.Curve {
+			cell, _ = excelize.CoordinatesToCellName(14+i, 3+t)
+			f.SetCellValue(sheetName, cell, math.Round(cost))
+		}
+		generations = int(math.Max(float64(generations), float64(len(set.Curve))))
+	}
+	for t := 0; t < generations; t++ {
+		f.SetCellValue(sheetName, fmt.Sprintf("M%d", 3+t), t)
+	}
+	last, _ := excelize.ColumnNumberToName(13 + len(c.Sets))
+	f.SetColWidth(sheetName, "M", last, 20)
+	f.SetRowHeight(sheetName, 2, 40)
+	f.SetCellStyle(sheetName, "M2", last+"2", headerStyleId)
+
+	plotFilename := strings.TrimSuffix(resultsFile, filepath.Ext(resultsFile)) + "_convergence.png"
+	drawConvergencePlot(plotFilename, c)
+	if err := f.AddPicture(sheetName, "B2", plotFilename, &excelize.GraphicOptions{AltText: "Convergence Curves"}); err != nil {
+		fmt.Println(err)
+	}
+	os.Remove(plotFilename)
+}
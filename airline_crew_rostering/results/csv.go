@@ -0,0 +1,153 @@
+package results
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go-airline-crew-rostering/airline"
+	"go-airline-crew-rostering/fitness"
+	"go-airline-crew-rostering/input"
+	"go-airline-crew-rostering/metrics"
+)
+
+// Writer of csv files with the roster (one row per assigned pairing), the metrics,
+// the metrics of each generation, the configuration and the manifest of the run
+type CSVWriter struct{}
+
+func (writer *CSVWriter) Extension() string {
+	return "csv"
+}
+
+func (writer *CSVWriter) Write(fileName string, m *metrics.Metrics, args *input.ArgumentCollection, al *airline.Airline, objective *fitness.Objective) {
+	// Create the csv files of the run: the roster is stored in "fileName"
+	// and the rest in files with the same name and a suffix
+	record := newRunRecord(m, args, al, objective)
+	name := strings.TrimSuffix(fileName, ".csv")
+
+	writeCSV(fileName, rosterRows(record.Roster))
+
+	r := record.Metrics
+	uncovered := []string{}
+	for _, id := range r.UncoveredPairs {
+		uncovered = append(uncovered, strconv.Itoa(id))
+	}
+	measures := [][]string{
+		{"measure", "value"},
+		{"totalTime", formatFloat(r.TotalTime)},
+		{"cost", formatFloat(r.Cost)},
+		{"bestCost", formatFloat(r.BestCost)},
+		{"validSolutions", strconv.Itoa(r.ValidSolutions)},
+		{"totalSolutions", strconv.Itoa(r.TotalSolutions)},
+		{"uniqueSolutions", strconv.Itoa(r.UniqueSolutions)},
+		{"assignedPairs", strconv.Itoa(r.AssignedPairs)},
+		{"uncoveredPairs", strings.Join(uncovered, " ")},
+		{"averageRestPeriod", formatFloat(r.AverageRestPeriod)},
+		{"averageDaysOff", formatFloat(r.AverageDaysOff)},
+		{"jumps", strconv.Itoa(r.Jumps)},
+		{"averageSimilarity", formatFloat(r.AverageSimilarity)},
+		{"generations", strconv.Itoa(r.Generations)},
+		{"stopReason", r.StopReason},
+	}
+	if args.Algorithm == "columnGeneration" {
+		measures = append(measures, []string{"lowerBound", formatFloat(r.LowerBound)})
+	}
+	for _, term := range r.Objective {
+		measures = append(measures, []string{"objective " + term.Term, formatFloat(term.Value)})
+	}
+	for _, fairness := range r.Fairness {
+		measures = append(measures,
+			[]string{"gini " + fairness.Dimension, formatFloat(fairness.Gini)},
+			[]string{"spread " + fairness.Dimension, formatFloat(fairness.Spread)},
+			[]string{"std " + fairness.Dimension, formatFloat(fairness.StandardDeviation)})
+	}
+	for i, island := range r.Islands {
+		measures = append(measures,
+			[]string{fmt.Sprintf("island %d %s bestCost", i+1, island.Algorithm), formatFloat(island.BestCost)},
+			[]string{fmt.Sprintf("island %d %s immigrants", i+1, island.Algorithm), strconv.Itoa(island.Immigrants)})
+	}
+	writeCSV(name+"_metrics.csv", measures)
+
+	// the lower bound of each iteration is stored only by the column generation
+	iterations := [][]string{{"generation", "bestCost", "worstCost", "averageCost"}}
+	if len(r.IterLowerBound) > 0 {
+		iterations[0] = append(iterations[0], "lowerBound")
+	}
+	for t := range r.IterBestCost {
+		row := []string{strconv.Itoa(t), formatFloat(r.IterBestCost[t]), "", ""}
+		if t < len(r.IterWorstCost) {
+			row[2] = formatFloat(r.IterWorstCost[t])
+		}
+		if t < len(r.IterAverageCost) {
+			row[3] = formatFloat(r.IterAverageCost[t])
+		}
+		if t < len(r.IterLowerBound) {
+			row = append(row, formatFloat(r.IterLowerBound[t]))
+		}
+		iterations = append(iterations, row)
+	}
+	writeCSV(name+"_iterations.csv", iterations)
+
+	c := record.Configuration
+	configuration := [][]string{
+		{"setting", "value"},
+		{"algorithm", c.Algorithm},
+		{"filename", c.Filename},
+		{"startDate", c.StartDate.Format("2006-01-02")},
+		{"endDate", c.EndDate.Format("2006-01-02")},
+		{"pilots", strconv.Itoa(c.Pilots)},
+		{"seed", strconv.FormatInt(c.Seed, 10)},
+		{"generations", strconv.Itoa(c.Generations)},
+		{"agents", strconv.Itoa(c.Agents)},
+	}
+	parameters := []string{}
+	for parameter := range c.Parameters {
+		parameters = append(parameters, parameter)
+	}
+	sort.Strings(parameters)
+	for _, parameter := range parameters {
+		configuration = append(configuration, []string{parameter, fmt.Sprint(c.Parameters[parameter])})
+	}
+	configuration = append(configuration, []string{"unitCost", formatFloat(objective.UnitCost)})
+	for _, term := range objective.Terms {
+		configuration = append(configuration, []string{"weight " + termName(term), formatFloat(term.Weight)})
+	}
+	writeCSV(name+"_configuration.csv", configuration)
+
+	writeManifestCSV(name+"_manifest.csv", record.Manifest)
+}
+
+func rosterRows(roster []*pilotRecord) [][]string {
+	// returns the roster as csv records (one row per assigned pairing)
+	rows := [][]string{{"pilot", "pairing", "start", "end", "legs"}}
+	for _, pilot := range roster {
+		for _, pair := range pilot.Pairings {
+			rows = append(rows, []string{strconv.Itoa(pilot.Pilot), strconv.Itoa(pair.Id),
+				pair.Start.Format("2006-01-02 15:04"), pair.End.Format("2006-01-02 15:04"), strconv.Itoa(pair.Legs)})
+		}
+	}
+	return rows
+}
+
+func writeCSV(fileName string, records [][]string) {
+	// write "records" to a csv file
+	file, err := os.Create(fileName)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer file.Close()
+	writer := csv.NewWriter(file)
+	writer.WriteAll(records)
+	if err := writer.Error(); err != nil {
+		fmt.Println(err)
+	}
+}
+
+func formatFloat(value float64) string {
+	// returns the shortest representation of "value"
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,236 @@
+package results
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"go-airline-crew-rostering/airline"
+	"go-airline-crew-rostering/diff"
+	"go-airline-crew-rostering/input"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func (writer *XLSXWriter) WriteDiff(fileName string, d *diff.Diff, args *input.ArgumentCollection, al *airline.Airline) {
+	// Creates an excel file to store the differences between the two rosters,
+	// along with the schedule of the new roster
+	f := excelize.NewFile()
+	defer func() {
+		if err := f.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	docProperties, _ := f.GetDocProps()
+	docProperties.Language = "en-UK"
+	f.SetDocProps(docProperties)
+
+	f.SetDefaultFont("Arial")
+
+	diffSheetName := "Diff"
+	scheduleSheetName := "Schedule"
+	manifestSheetName := "Manifest"
+
+	f.SetSheetName("Sheet1", diffSheetName)
+	if _, err := f.NewSheet(scheduleSheetName); err != nil {
+		fmt.Println(err)
+		return
+	}
+	if _, err := f.NewSheet(manifestSheetName); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	drawDiffSheet(f, diffSheetName, d)
+	drawScheduleSheet(f, scheduleSheetName, al, al.PilotsArray, *args.Colouring)
+	drawManifestSheet(f, newManifest(0, args, al))
+
+	if err := f.SaveAs(fileName); err != nil {
+		fmt.Println(err)
+	}
+}
+
+func (writer *JSONWriter) WriteDiff(fileName string, d *diff.Diff, args *input.ArgumentCollection, al *airline.Airline) {
+	// Create a json file with the differences between the two rosters
+	// and the manifest of the comparison
+	writeJSON(fileName, &struct {
+		*diff.Diff
+		Manifest *manifestRecord `json:"manifest"`
+	}{d, newManifest(0, args, al)})
+}
+
+func (writer *CSVWriter) WriteDiff(fileName string, d *diff.Diff, args *input.ArgumentCollection, al *airline.Airline) {
+	// Create the csv files of the differences: the changes of each pilot are stored in "fileName"
+	// and the pairings that moved between pilots, the assignments that break the rules
+	// and the manifest in files with the same name and a suffix
+	ids := func(pairings []*diff.Pairing) string {
+		values := []string{}
+		for _, pairing := range pairings {
+			values = append(values, strconv.Itoa(pairing.Id))
+		}
+		return strings.Join(values, " ")
+	}
+	pilots := [][]string{{"pilot", "added", "removed", "flightTimeBefore", "flightTimeAfter", "daysOffBefore", "daysOffAfter"}}
+	for _, pilot := range d.Pilots {
+		pilots = append(pilots, []string{strconv.Itoa(pilot.Id), ids(pilot.Added), ids(pilot.Removed), formatFloat(pilot.FlightTimeBefore),
+			formatFloat(pilot.FlightTimeAfter), strconv.Itoa(pilot.DaysOffBefore), strconv.Itoa(pilot.DaysOffAfter)})
+	}
+	writeCSV(fileName, pilots)
+
+	// the pilot of an uncovered pairing is -1
+	moves := [][]string{{"pairing", "start", "from", "to"}}
+	for _, move := range append(append(append([]*diff.Move{}, d.Moves...), d.Covered...), d.Uncovered...) {
+		moves = append(moves, []string{strconv.Itoa(move.Pairing.Id), move.Pairing.Start.Format("2006-01-02 15:04"),
+			strconv.Itoa(move.From), strconv.Itoa(move.To)})
+	}
+	writeCSV(strings.TrimSuffix(fileName, ".csv")+"_moves.csv", moves)
+
+	violations := [][]string{{"roster", "pilot", "pairing", "rule"}}
+	for _, violation := range d.OldViolations {
+		violations = append(violations, []string{"old", strconv.Itoa(violation.Pilot), strconv.Itoa(violation.Pairing), violation.Rule})
+	}
+	for _, violation := range d.NewViolations {
+		violations = append(violations, []string{"new", strconv.Itoa(violation.Pilot), strconv.Itoa(violation.Pairing), violation.Rule})
+	}
+	writeCSV(strings.TrimSuffix(fileName, ".csv")+"_violations.csv", violations)
+	writeManifestCSV(strings.TrimSuffix(fileName, ".csv")+"_manifest.csv", newManifest(0, args, al))
+}
+
+func drawDiffSheet(f *excelize.File, sheetName string, d *diff.Diff) {
+	// create an excel sheet containing a summary of the differences,
+	// the changes of each pilot and the pairings that moved between pilots
+	setView(f, sheetName, 100.0)
+
+	f.SetColWidth(sheetName, "G", "G", 10)
+	f.SetColWidth(sheetName, "H", "I", 40)
+	f.SetColWidth(sheetName, "J", "M", 16)
+	f.SetColWidth(sheetName, "O", "R", 20)
+	for i := 5; i <= 12; i++ {
+		f.SetRowHeight(sheetName, i, 30)
+	}
+
+	f.SetCellValue(sheetName, "B2", "Roster Differences")
+	f.SetCellValue(sheetName, "B5", "Old Roster")
+	f.SetCellValue(sheetName, "B6", "New Roster")
+	f.SetCellValue(sheetName, "B7", "Pilots Changed")
+	f.SetCellValue(sheetName, "B8", "Added Assignments")
+	f.SetCellValue(sheetName, "B9", "Removed Assignments")
+	f.SetCellValue(sheetName, "B10", "Moved Pairings")
+	f.SetCellValue(sheetName, "B11", "Newly Covered")
+	f.SetCellValue(sheetName, "B12", "Newly Uncovered")
+	f.SetCellValue(sheetName, "D5", d.Old)
+	f.SetCellValue(sheetName, "D6", d.New)
+	f.SetCellValue(sheetName, "D7", d.Summary.PilotsChanged)
+	f.SetCellValue(sheetName, "D8", d.Summary.Added)
+	f.SetCellValue(sheetName, "D9", d.Summary.Removed)
+	f.SetCellValue(sheetName, "D10", d.Summary.Moved)
+	f.SetCellValue(sheetName, "D11", d.Summary.NewlyCovered)
+	f.SetCellValue(sheetName, "D12", d.Summary.NewlyUncovered)
+	drawVerticalTable(f, sheetName, "B2", 8, "7266A4", "E5E0EC")
+
+	headerStyleId, _ := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Size: 12, Color: "FFFFFF", Bold: true},
+		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"7266A4"}},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center", WrapText: true},
+	})
+	cellStyleId, _ := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Size: 12},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center", WrapText: true},
+	})
+	enable := true
+	ids := func(pairings []*diff.Pairing) string {
+		values := []string{}
+		for _, pairing := range pairings {
+			values = append(values, fmt.Sprintf("%04d", pairing.Id))
+		}
+		return strings.Join(values, ", ")
+	}
+
+	// changes of each pilot
+	headers := []string{"Pilot", "Added", "Removed", "Flight Time Before", "Flight Time After", "Days Off Before", "Days Off After"}
+	for i, header := range headers {
+		cell, _ := excelize.CoordinatesToCellName(7+i, 2)
+		f.SetCellValue(sheetName, cell, header)
+	}
+	f.SetRowHeight(sheetName, 2, 40)
+	f.SetCellStyle(sheetName, "G2", "M2", headerStyleId)
+	for i, pilot := range d.Pilots {
+		row := 3 + i
+		values := []interface{}{pilot.Id, ids(pilot.Added), ids(pilot.Removed), math.Round(pilot.FlightTimeBefore),
+			math.Round(pilot.FlightTimeAfter), pilot.DaysOffBefore, pilot.DaysOffAfter}
+		for j, value := range values {
+			cell, _ := excelize.CoordinatesToCellName(7+j, row)
+			f.SetCellValue(sheetName, cell, value)
+		}
+		f.SetCellStyle(sheetName, fmt.Sprintf("G%d", row), fmt.Sprintf("M%d", row), cellStyleId)
+	}
+	f.AddTable(sheetName, &excelize.Table{
+		Range:          fmt.Sprintf("G2:M%d", 3+int(math.Max(float64(len(d.Pilots))-1, 0))),
+		Name:           "Pilots",
+		StyleName:      "TableStyleMedium12",
+		ShowRowStripes: &enable,
+	})
+
+	// pairings assigned to another pilot, covered or left uncovered by the new roster
+	headers = []string{"Pairing", "Departure", "From Pilot", "To Pilot"}
+	for i, header := range headers {
+		cell, _ := excelize.CoordinatesToCellName(15+i, 2)
+		f.SetCellValue(sheetName, cell, header)
+	}
+	f.SetCellStyle(sheetName, "O2", "R2", headerStyleId)
+	row := 3
+	pilot := func(id int) interface{} {
+		if id < 0 {
+			return "uncovered"
+		}
+		return id
+	}
+	for _, move := range append(append(append([]*diff.Move{}, d.Moves...), d.Covered...), d.Uncovered...) {
+		values := []interface{}{fmt.Sprintf("%04d", move.Pairing.Id), move.Pairing.Start.Format("02/01/2006 15:04"), pilot(move.From), pilot(move.To)}
+		for j, value := range values {
+			cell, _ := excelize.CoordinatesToCellName(15+j, row)
+			f.SetCellValue(sheetName, cell, value)
+		}
+		f.SetCellStyle(sheetName, fmt.Sprintf("O%d", row), fmt.Sprintf("R%d", row), cellStyleId)
+		row++
+	}
+	f.AddTable(sheetName, &excelize.Table{
+		Range:          fmt.Sprintf("O2:R%d", int(math.Max(float64(row-1), 3))),
+		Name:           "Moves",
+		StyleName:      "TableStyleMedium12",
+		ShowRowStripes: &enable,
+	})
+
+	// assignments of the rosters that break the rules
+	headers = []string{"Roster", "Pilot", "Pairing", "Rule"}
+	for i, header := range headers {
+		cell, _ := excelize.CoordinatesToCellName(20+i, 2)
+		f.SetCellValue(sheetName, cell, header)
+	}
+	f.SetColWidth(sheetName, "T", "V", 12)
+	f.SetColWidth(sheetName, "W", "W", 40)
+	f.SetCellStyle(sheetName, "T2", "W2", headerStyleId)
+	row = 3
+	for _, roster := range []struct {
+		name       string
+		violations []*diff.Violation
+	}{{"Old", d.OldViolations}, {"New", d.NewViolations}} {
+		for _, violation := range roster.violations {
+			values := []interface{}{roster.name, violation.Pilot, fmt.Sprintf("%04d", violation.Pairing), violation.Rule}
+			for j, value := range values {
+				cell, _ := excelize.CoordinatesToCellName(20+j, row)
+				f.SetCellValue(sheetName, cell, value)
+			}
+			f.SetCellStyle(sheetName, fmt.Sprintf("T%d", row), fmt.Sprintf("W%d", row), cellStyleId)
+			row++
+		}
+	}
+	f.AddTable(sheetName, &excelize.Table{
+		Range:          fmt.Sprintf("T2:W%d", int(math.Max(float64(row-1), 3))),
+		Name:           "Violations",
+		StyleName:      "TableStyleMedium12",
+		ShowRowStripes: &enable,
+	})
+}
@@ -1,230 +1,373 @@
-package results
-
-import (
-	"time"
-
-	"github.com/xuri/excelize/v2"
-)
-
-// struct used for the visualization of the schedule
-type dateInfo struct {
-	year       int
-	month      int
-	dateString string
-	startCell  string
-}
-
-func daysInMonth(year int, month string) int {
-	// returns the number of days a month has
-	daysInMonth := make(map[string]int)
-	daysInMonth["January"] = 31
-	daysInMonth["February"] = 28
-	daysInMonth["March"] = 31
-	daysInMonth["April"] = 30
-	daysInMonth["May"] = 31
-	daysInMonth["June"] = 30
-	daysInMonth["July"] = 31
-	daysInMonth["August"] = 31
-	daysInMonth["September"] = 30
-	daysInMonth["October"] = 31
-	daysInMonth["November"] = 30
-	daysInMonth["December"] = 31
-
-	days := daysInMonth[month]
-	if month == "February" && checkLeapYear(year) {
-		days = 29
-	}
-	return days
-}
-
-func checkLeapYear(year int) bool {
-	// checks if a year is a leap year
-	if year%400 == 0 {
-		return true
-	} else if year%100 == 0 {
-		return false
-	} else if year%4 == 0 {
-		return true
-	}
-	return false
-}
-
-func drawScheduleHeader(f *excelize.File, sheetName string, startCell string) {
-	// function that visualizes the headers of an airline crew rostering schedule
-	col, row, _ := excelize.CellNameToCoordinates(startCell)
-	endCell, _ := excelize.CoordinatesToCellName(col+1, row+1)
-	f.MergeCell(sheetName, startCell, endCell)
-	f.SetCellValue(sheetName, startCell, "         Dates\n  Pilots")
-
-	style := &excelize.Style{Font: &excelize.Font{Size: 16, Color: "FFFFFF", Bold: true},
-		Alignment: &excelize.Alignment{Horizontal: "left", Vertical: "center", WrapText: true},
-		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"376091"}},
-		Border: []excelize.Border{{Type: "top", Color: "FFFFFF", Style: 5},
-			{Type: "right", Color: "FFFFFF", Style: 5},
-			{Type: "bottom", Color: "FFFFFF", Style: 5},
-			{Type: "left", Color: "FFFFFF", Style: 5},
-			{Type: "diagonalDown", Color: "FFFFFF", Style: 1},
-		}}
-	styleId, _ := f.NewStyle(style)
-	f.SetCellStyle(sheetName, startCell, endCell, styleId)
-	f.SetRowHeight(sheetName, row, 30.0)
-	f.SetRowHeight(sheetName, row+1, 30.0)
-}
-
-func drawPilotColumn(f *excelize.File, sheetName string, startCell string, pilots int) {
-	// function that visualizes the column containing the pilots' ids of an airline crew rostering schedule
-	col, row, _ := excelize.CellNameToCoordinates(startCell)
-	endCell, _ := excelize.CoordinatesToCellName(col+1, row+pilots-1)
-	style := &excelize.Style{Font: &excelize.Font{Size: 12, Color: "FFFFFF", Bold: true},
-		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center", WrapText: true},
-		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"4F81BD"}},
-		Border: []excelize.Border{{Type: "right", Color: "FFFFFF", Style: 5},
-			{Type: "bottom", Color: "FFFFFF", Style: 2},
-			{Type: "left", Color: "FFFFFF", Style: 5},
-		}}
-	styleId, _ := f.NewStyle(style)
-	f.SetCellStyle(sheetName, startCell, endCell, styleId)
-	for pilot := 0; pilot < pilots; pilot++ {
-		cell, _ := excelize.CoordinatesToCellName(col, row+pilot)
-		endCell, _ := excelize.CoordinatesToCellName(col+1, row+pilot)
-		f.SetRowHeight(sheetName, row+pilot, 15.0)
-		f.MergeCell(sheetName, cell, endCell)
-		f.SetCellValue(sheetName, cell, pilot)
-	}
-}
-
-func drawMonths(f *excelize.File, sheetName string, startCell string, months []*dateInfo) {
-	// function that visualizes the months and days of an airline crew rostering schedule
-	col, row, _ := excelize.CellNameToCoordinates(startCell)
-	monthStyleId, _ := f.NewStyle(&excelize.Style{Font: &excelize.Font{Size: 16, Color: "FFFFFF", Bold: true},
-		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center", WrapText: true},
-		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"4F81BD"}},
-		Border: []excelize.Border{{Type: "top", Color: "FFFFFF", Style: 5},
-			{Type: "right", Color: "FFFFFF", Style: 5},
-			{Type: "bottom", Color: "FFFFFF", Style: 5},
-			{Type: "left", Color: "FFFFFF", Style: 5},
-		}})
-	dayStyleId, _ := f.NewStyle(&excelize.Style{Font: &excelize.Font{Size: 14, Color: "FFFFFF", Bold: true},
-		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center", WrapText: true},
-		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"4F81BD"}},
-		Border: []excelize.Border{{Type: "right", Color: "FFFFFF", Style: 2},
-			{Type: "bottom", Color: "FFFFFF", Style: 5},
-			{Type: "left", Color: "FFFFFF", Style: 2},
-		}})
-	lastDayStyleId, _ := f.NewStyle(&excelize.Style{Font: &excelize.Font{Size: 14, Color: "FFFFFF", Bold: true},
-		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center", WrapText: true},
-		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"4F81BD"}},
-		Border: []excelize.Border{{Type: "right", Color: "FFFFFF", Style: 5},
-			{Type: "bottom", Color: "FFFFFF", Style: 5},
-			{Type: "left", Color: "FFFFFF", Style: 2},
-		}})
-	for _, month := range months {
-		days := daysInMonth(month.year, time.Month(month.month).String())
-		monthStartCell, _ := excelize.CoordinatesToCellName(col, row)
-		monthEndCell, _ := excelize.CoordinatesToCellName(col+2*days-1, row)
-		month.startCell = monthStartCell
-		f.MergeCell(sheetName, monthStartCell, monthEndCell)
-		f.SetCellStyle(sheetName, monthStartCell, monthEndCell, monthStyleId)
-		f.SetCellValue(sheetName, monthStartCell, month.dateString)
-		colStartStr, _ := excelize.ColumnNumberToName(col)
-		colEndStr, _ := excelize.ColumnNumberToName(col + 2*days - 1)
-		f.SetColWidth(sheetName, colStartStr, colEndStr, 2.15)
-
-		daysStartCell, _ := excelize.CoordinatesToCellName(col, row+1)
-		daysEndCell, _ := excelize.CoordinatesToCellName(col+2*days-3, row+1)
-		f.SetCellStyle(sheetName, daysStartCell, daysEndCell, dayStyleId)
-
-		lastDayCell1, _ := excelize.CoordinatesToCellName(col+2*days-2, row+1)
-		lastDayCell2, _ := excelize.CoordinatesToCellName(col+2*days-1, row+1)
-		f.SetCellStyle(sheetName, lastDayCell1, lastDayCell2, lastDayStyleId)
-
-		for i, d := 0, 1; i < 2*days; i, d = i+2, d+1 {
-			startCell, _ := excelize.CoordinatesToCellName(col+i, row+1)
-			endCell, _ := excelize.CoordinatesToCellName(col+i+1, row+1)
-			f.MergeCell(sheetName, startCell, endCell)
-			f.SetCellValue(sheetName, startCell, d)
-		}
-		col += 2 * days
-	}
-}
-
-func drawDataArea(f *excelize.File, sheetName string, months []*dateInfo, pilots int) {
-	// function that visualizes the area containing the pair assignments of an airline crew rostering schedule
-	halfDayEvenStyleId, _ := f.NewStyle(&excelize.Style{Font: &excelize.Font{Size: 11, Bold: true},
-		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center", WrapText: true},
-		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"B8CCE4"}},
-		Border:    []excelize.Border{{Type: "right", Color: "FFFFFF", Style: 1}, {Type: "bottom", Color: "FFFFFF", Style: 2}}})
-	halfDayOddStyleId, _ := f.NewStyle(&excelize.Style{Font: &excelize.Font{Size: 11, Bold: true},
-		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center", WrapText: true},
-		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"DEEBF6"}},
-		Border:    []excelize.Border{{Type: "right", Color: "FFFFFF", Style: 1}, {Type: "bottom", Color: "FFFFFF", Style: 2}}})
-	dayEvenStyleId, _ := f.NewStyle(&excelize.Style{Font: &excelize.Font{Size: 11, Bold: true},
-		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center", WrapText: true},
-		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"B8CCE4"}},
-		Border:    []excelize.Border{{Type: "right", Color: "FFFFFF", Style: 2}, {Type: "bottom", Color: "FFFFFF", Style: 2}}})
-	dayOddStyleId, _ := f.NewStyle(&excelize.Style{Font: &excelize.Font{Size: 11, Bold: true},
-		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center", WrapText: true},
-		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"DEEBF6"}},
-		Border:    []excelize.Border{{Type: "right", Color: "FFFFFF", Style: 2}, {Type: "bottom", Color: "FFFFFF", Style: 2}}})
-	lastDayEvenStyleId, _ := f.NewStyle(&excelize.Style{Font: &excelize.Font{Size: 11, Bold: true},
-		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center", WrapText: true},
-		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"B8CCE4"}},
-		Border:    []excelize.Border{{Type: "right", Color: "FFFFFF", Style: 5}, {Type: "bottom", Color: "FFFFFF", Style: 2}}})
-	lastDayOddStyleId, _ := f.NewStyle(&excelize.Style{Font: &excelize.Font{Size: 11, Bold: true},
-		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center", WrapText: true},
-		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"DEEBF6"}},
-		Border:    []excelize.Border{{Type: "right", Color: "FFFFFF", Style: 5}, {Type: "bottom", Color: "FFFFFF", Style: 2}}})
-
-	for _, month := range months {
-		col, row, _ := excelize.CellNameToCoordinates(month.startCell)
-		row += 2
-		days := daysInMonth(month.year, time.Month(month.month).String())
-		for pilot := 0; pilot < pilots; pilot++ {
-			for d := 0; d < 2*days; d += 2 {
-				halfCell, _ := excelize.CoordinatesToCellName(col+d, row+pilot)
-				cell, _ := excelize.CoordinatesToCellName(col+d+1, row+pilot)
-				if pilot%2 == 0 {
-					f.SetCellStyle(sheetName, halfCell, halfCell, halfDayEvenStyleId)
-					if d == 2*days-2 {
-						f.SetCellStyle(sheetName, cell, cell, lastDayEvenStyleId)
-					} else {
-						f.SetCellStyle(sheetName, cell, cell, dayEvenStyleId)
-					}
-				} else {
-					f.SetCellStyle(sheetName, halfCell, halfCell, halfDayOddStyleId)
-					if d == 2*days-2 {
-						f.SetCellStyle(sheetName, cell, cell, lastDayOddStyleId)
-					} else {
-						f.SetCellStyle(sheetName, cell, cell, dayOddStyleId)
-					}
-				}
-			}
-		}
-	}
-}
-
-func findCell(startCell string, date time.Time, pilotId int) string {
-	// find a cell in a visualized airline crew rostering schedule based on the date and the pilot
-	col, row, _ := excelize.CellNameToCoordinates(startCell)
-	row += 2 + pilotId
-	col += 2*date.Day() - 2
-	if date.Hour() > 12 {
-		col++
-	}
-	cell, _ := excelize.CoordinatesToCellName(col, row)
-	return cell
-}
-
-func drawDashes(f *excelize.File, sheetName string, startCell string, endCell string) {
-	// draw dashes in cells to show that a pilot is busy during the time represented by
-	// these cells
-	col, row, _ := excelize.CellNameToCoordinates(startCell)
-	endCol, _, _ := excelize.CellNameToCoordinates(endCell)
-	for j := col + 1; j <= endCol; j++ {
-		cell, _ := excelize.CoordinatesToCellName(j, row)
-		value, _ := f.GetCellValue(sheetName, cell)
-		if value != "F" {
-			f.SetCellValue(sheetName, cell, "-")
-		}
-	}
-}
+package results
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"go-airline-crew-rostering/airline"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Month (or the part of a month inside the schedule) used for the visualization of the schedule
+type scheduleMonth struct {
+	start     time.Time // first day of the month inside the schedule
+	days      int       // days of the month inside the schedule
+	startCell string    // cell of the month's header
+}
+
+// Fill colours of the schedule's data area (even rows, odd rows)
+var (
+	workdayFills = [2]string{"B8CCE4", "DEEBF6"}
+	weekendFills = [2]string{"95B3D7", "C5D9F1"}
+	dayOffFills  = [2]string{"D8E4BC", "EBF1DE"}
+)
+
+// Fill colours of the pairings (by duration or by base)
+var pairingFills = []string{"F4B084", "FFD966", "A9D08E", "C9A0DC", "F8CBAD", "9BC2E6", "FF99CC", "BFBFBF"}
+
+// Styles of the cells of the schedule's data area, created once for every combination
+// of fill colour and right border
+type scheduleStyles struct {
+	f   *excelize.File
+	ids map[string]int
+}
+
+func (styles *scheduleStyles) id(fill string, rightBorder int) int {
+	// returns the style of a cell of the data area with "fill" colour
+	// and a right border of style "rightBorder"
+	key := fmt.Sprintf("%s-%d", fill, rightBorder)
+	if id, exists := styles.ids[key]; exists {
+		return id
+	}
+	id, _ := styles.f.NewStyle(&excelize.Style{Font: &excelize.Font{Size: 11, Bold: true},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center", WrapText: true},
+		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{fill}},
+		Border:    []excelize.Border{{Type: "right", Color: "FFFFFF", Style: rightBorder}, {Type: "bottom", Color: "FFFFFF", Style: 2}}})
+	styles.ids[key] = id
+	return id
+}
+
+func scheduleMonths(start time.Time, end time.Time) []*scheduleMonth {
+	// returns the months of the schedule from "start" to "end" (excluding the end),
+	// where the first and the last month may be partial
+	months := []*scheduleMonth{}
+	for date := start; date.Before(end); {
+		next := time.Date(date.Year(), date.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		if next.After(end) {
+			next = end
+		}
+		months = append(months, &scheduleMonth{start: date, days: int(next.Sub(date).Hours() / 24)})
+		date = next
+	}
+	return months
+}
+
+func isWeekend(date time.Time) bool {
+	// checks if a date is a Saturday or a Sunday
+	return date.Weekday() == time.Saturday || date.Weekday() == time.Sunday
+}
+
+func drawScheduleSheet(f *excelize.File, sheetName string, al *airline.Airline, pilots []*airline.Pilot, colouring string) {
+	// create an excel sheet containing the airline crew rostering schedule of "pilots"
+	// from the start to the end of the schedule, two months per block of rows
+	f.SetRowHeight(sheetName, 1, 7)
+	f.SetColWidth(sheetName, "A", "A", 1)
+	f.SetColWidth(sheetName, "B", "C", 7.65)
+
+	setView(f, sheetName, 82.0)
+
+	styles := &scheduleStyles{f: f, ids: make(map[string]int)}
+	months := scheduleMonths(al.ScheduleStart, al.ScheduleEnd)
+	startCell := "B2"
+	for i := 0; i < len(months); i += 2 {
+		j := i + 2
+		if j > len(months) {
+			j = len(months)
+		}
+		col, row, _ := excelize.CellNameToCoordinates(startCell)
+		drawScheduleHeader(f, sheetName, startCell)
+		cell, _ := excelize.CoordinatesToCellName(col, row+2)
+		drawPilotColumn(f, sheetName, cell, al.NumberOfPilots)
+		cell, _ = excelize.CoordinatesToCellName(col+2, row)
+		drawMonths(f, sheetName, cell, months[i:j])
+		drawDataArea(f, sheetName, months[i:j], pilots, al, styles)
+		startCell, _ = excelize.CoordinatesToCellName(col, row+al.NumberOfPilots+3)
+	}
+
+	fills, labels := pairingColours(pilots, colouring)
+	for _, pilot := range pilots {
+		for i := 1; i <= pilot.AssignedLength; i++ {
+			pair := pilot.AssignedPairs[i]
+			fill := fills[pair]
+			pairStartCell := findCell(months, pair.Start, pilot.Id)
+			if pairStartCell == "" {
+				continue
+			}
+
+			// the pairing occupies every half day from its start to its end
+			first := time.Date(pair.Start.Year(), pair.Start.Month(), pair.Start.Day(), 0, 0, 0, 0, time.UTC)
+			for halfDay := first; halfDay.Before(pair.End); halfDay = halfDay.Add(12 * time.Hour) {
+				if !halfDay.Add(12 * time.Hour).After(pair.Start) {
+					continue
+				}
+				cell := findCell(months, halfDay, pilot.Id)
+				if cell == "" {
+					continue
+				}
+				value, _ := f.GetCellValue(sheetName, cell)
+				if cell == pairStartCell {
+					f.SetCellValue(sheetName, cell, "F")
+				} else if value != "F" {
+					f.SetCellValue(sheetName, cell, "-")
+				}
+				if fill != "" {
+					f.SetCellStyle(sheetName, cell, cell, styles.id(fill, rightBorder(months, halfDay)))
+				}
+			}
+
+			dateString := fmt.Sprintf("Departure: %02d/%02d/%d %02d:%02d\nArrival: %02d/%02d/%d %02d:%02d",
+				pair.Start.Day(), pair.Start.Month(), pair.Start.Year(), pair.Start.Hour(), pair.Start.Minute(),
+				pair.End.Day(), pair.End.Month(), pair.End.Year(), pair.End.Hour(), pair.End.Minute())
+			titleString := fmt.Sprintf("Pair %04d", pair.Id)
+			dataValidation := excelize.NewDataValidation(true)
+			dataValidation.Sqref = pairStartCell + ":" + pairStartCell
+			dataValidation.SetInput(titleString, dateString)
+			f.AddDataValidation(sheetName, dataValidation)
+		}
+	}
+
+	drawScheduleLegend(f, sheetName, startCell, labels, styles)
+}
+
+func pairingColours(pilots []*airline.Pilot, colouring string) (map[*airline.Pair]string, [][2]string) {
+	// returns the fill colour of every assigned pairing, either by its duration in days
+	// or by its base (the departure airport of its first flightleg),
+	// and the label of every colour for the legend of the schedule
+	// (no pairing has its own colour if "colouring" is "none")
+	fills := make(map[*airline.Pair]string)
+	labels := [][2]string{}
+	if colouring == "none" {
+		return fills, labels
+	}
+	if colouring == "base" {
+		bases := []string{}
+		index := make(map[string]int)
+		for _, pilot := range pilots {
+			for _, pair := range pilot.AssignedPairs[1 : pilot.AssignedLength+1] {
+				base := pairingBase(pair)
+				if _, exists := index[base]; !exists {
+					index[base] = 0
+					bases = append(bases, base)
+				}
+			}
+		}
+		sort.Strings(bases)
+		for i, base := range bases {
+			index[base] = i % len(pairingFills)
+			labels = append(labels, [2]string{pairingFills[index[base]], "Base " + base})
+		}
+		for _, pilot := range pilots {
+			for _, pair := range pilot.AssignedPairs[1 : pilot.AssignedLength+1] {
+				fills[pair] = pairingFills[index[pairingBase(pair)]]
+			}
+		}
+		return fills, labels
+	}
+
+	durations := []string{"1 day", "2 days", "3 days", "4 or more days"}
+	for i, duration := range durations {
+		labels = append(labels, [2]string{pairingFills[i], duration})
+	}
+	for _, pilot := range pilots {
+		for _, pair := range pilot.AssignedPairs[1 : pilot.AssignedLength+1] {
+			days := pair.EndDay - pair.StartDay
+			if days >= len(durations) {
+				days = len(durations) - 1
+			}
+			fills[pair] = pairingFills[days]
+		}
+	}
+	return fills, labels
+}
+
+func pairingBase(pair *airline.Pair) string {
+	// returns the departure airport of the first flightleg of the pairing
+	if len(pair.Legs) == 0 {
+		return "unknown"
+	}
+	return pair.Legs[0].Source
+}
+
+func drawScheduleHeader(f *excelize.File, sheetName string, startCell string) {
+	// function that visualizes the headers of an airline crew rostering schedule
+	col, row, _ := excelize.CellNameToCoordinates(startCell)
+	endCell, _ := excelize.CoordinatesToCellName(col+1, row+1)
+	f.MergeCell(sheetName, startCell, endCell)
+	f.SetCellValue(sheetName, startCell, "         Dates\n  Pilots")
+
+	style := &excelize.Style{Font: &excelize.Font{Size: 16, Color: "FFFFFF", Bold: true},
+		Alignment: &excelize.Alignment{Horizontal: "left", Vertical: "center", WrapText: true},
+		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"376091"}},
+		Border: []excelize.Border{{Type: "top", Color: "FFFFFF", Style: 5},
+			{Type: "right", Color: "FFFFFF", Style: 5},
+			{Type: "bottom", Color: "FFFFFF", Style: 5},
+			{Type: "left", Color: "FFFFFF", Style: 5},
+			{Type: "diagonalDown", Color: "FFFFFF", Style: 1},
+		}}
+	styleId, _ := f.NewStyle(style)
+	f.SetCellStyle(sheetName, startCell, endCell, styleId)
+	f.SetRowHeight(sheetName, row, 30.0)
+	f.SetRowHeight(sheetName, row+1, 30.0)
+}
+
+func drawPilotColumn(f *excelize.File, sheetName string, startCell string, pilots int) {
+	// function that visualizes the column containing the pilots' ids of an airline crew rostering schedule
+	col, row, _ := excelize.CellNameToCoordinates(startCell)
+	endCell, _ := excelize.CoordinatesToCellName(col+1, row+pilots-1)
+	style := &excelize.Style{Font: &excelize.Font{Size: 12, Color: "FFFFFF", Bold: true},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center", WrapText: true},
+		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"4F81BD"}},
+		Border: []excelize.Border{{Type: "right", Color: "FFFFFF", Style: 5},
+			{Type: "bottom", Color: "FFFFFF", Style: 2},
+			{Type: "left", Color: "FFFFFF", Style: 5},
+		}}
+	styleId, _ := f.NewStyle(style)
+	f.SetCellStyle(sheetName, startCell, endCell, styleId)
+	for pilot := 0; pilot < pilots; pilot++ {
+		cell, _ := excelize.CoordinatesToCellName(col, row+pilot)
+		endCell, _ := excelize.CoordinatesToCellName(col+1, row+pilot)
+		f.SetRowHeight(sheetName, row+pilot, 15.0)
+		f.MergeCell(sheetName, cell, endCell)
+		f.SetCellValue(sheetName, cell, pilot)
+	}
+}
+
+func drawMonths(f *excelize.File, sheetName string, startCell string, months []*scheduleMonth) {
+	// function that visualizes the months and days of an airline crew rostering schedule
+	// (the weekends have a darker colour)
+	col, row, _ := excelize.CellNameToCoordinates(startCell)
+	monthStyleId, _ := f.NewStyle(&excelize.Style{Font: &excelize.Font{Size: 16, Color: "FFFFFF", Bold: true},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center", WrapText: true},
+		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"4F81BD"}},
+		Border: []excelize.Border{{Type: "top", Color: "FFFFFF", Style: 5},
+			{Type: "right", Color: "FFFFFF", Style: 5},
+			{Type: "bottom", Color: "FFFFFF", Style: 5},
+			{Type: "left", Color: "FFFFFF", Style: 5},
+		}})
+	dayStyle := func(fill string, rightBorder int) int {
+		styleId, _ := f.NewStyle(&excelize.Style{Font: &excelize.Font{Size: 14, Color: "FFFFFF", Bold: true},
+			Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center", WrapText: true},
+			Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{fill}},
+			Border: []excelize.Border{{Type: "right", Color: "FFFFFF", Style: rightBorder},
+				{Type: "bottom", Color: "FFFFFF", Style: 5},
+				{Type: "left", Color: "FFFFFF", Style: 2},
+			}})
+		return styleId
+	}
+	dayStyleIds := map[bool][2]int{
+		false: {dayStyle("4F81BD", 2), dayStyle("4F81BD", 5)},
+		true:  {dayStyle("17375E", 2), dayStyle("17375E", 5)},
+	}
+	for _, month := range months {
+		monthStartCell, _ := excelize.CoordinatesToCellName(col, row)
+		monthEndCell, _ := excelize.CoordinatesToCellName(col+2*month.days-1, row)
+		month.startCell = monthStartCell
+		f.MergeCell(sheetName, monthStartCell, monthEndCell)
+		f.SetCellStyle(sheetName, monthStartCell, monthEndCell, monthStyleId)
+		f.SetCellValue(sheetName, monthStartCell, month.start.Format("January 2006"))
+		colStartStr, _ := excelize.ColumnNumberToName(col)
+		colEndStr, _ := excelize.ColumnNumberToName(col + 2*month.days - 1)
+		f.SetColWidth(sheetName, colStartStr, colEndStr, 2.15)
+
+		for d := 0; d < month.days; d++ {
+			date := month.start.AddDate(0, 0, d)
+			startCell, _ := excelize.CoordinatesToCellName(col+2*d, row+1)
+			endCell, _ := excelize.CoordinatesToCellName(col+2*d+1, row+1)
+			last := 0
+			if d == month.days-1 {
+				last = 1
+			}
+			f.MergeCell(sheetName, startCell, endCell)
+			f.SetCellStyle(sheetName, startCell, endCell, dayStyleIds[isWeekend(date)][last])
+			f.SetCellValue(sheetName, startCell, date.Day())
+		}
+		col += 2 * month.days
+	}
+}
+
+func drawDataArea(f *excelize.File, sheetName string, months []*scheduleMonth, pilots []*airline.Pilot, al *airline.Airline,
+	styles *scheduleStyles) {
+	// function that visualizes the area containing the pair assignments of an airline crew rostering schedule,
+	// where the days off of each pilot and the weekends have their own colours
+	for _, month := range months {
+		col, row, _ := excelize.CellNameToCoordinates(month.startCell)
+		row += 2
+		for _, pilot := range pilots {
+			for d := 0; d < month.days; d++ {
+				date := month.start.AddDate(0, 0, d)
+				day := int(date.Sub(al.ScheduleStart).Hours() / 24)
+				fills := workdayFills
+				if day < al.ScheduleDuration && !pilot.Workday(day) {
+					fills = dayOffFills
+				} else if isWeekend(date) {
+					fills = weekendFills
+				}
+				fill := fills[pilot.Id%2]
+				halfCell, _ := excelize.CoordinatesToCellName(col+2*d, row+pilot.Id)
+				cell, _ := excelize.CoordinatesToCellName(col+2*d+1, row+pilot.Id)
+				f.SetCellStyle(sheetName, halfCell, halfCell, styles.id(fill, 1))
+				f.SetCellStyle(sheetName, cell, cell, styles.id(fill, rightBorder(months, date.Add(12*time.Hour))))
+			}
+		}
+	}
+}
+
+func drawScheduleLegend(f *excelize.File, sheetName string, startCell string, labels [][2]string, styles *scheduleStyles) {
+	// function that visualizes the colours of the schedule and their meaning below the schedule
+	col, row, _ := excelize.CellNameToCoordinates(startCell)
+	labels = append([][2]string{{workdayFills[0], "Workday"}, {weekendFills[0], "Weekend"}, {dayOffFills[0], "Day off"}}, labels...)
+	for i, label := range labels {
+		cell, _ := excelize.CoordinatesToCellName(col, row+i)
+		f.SetCellStyle(sheetName, cell, cell, styles.id(label[0], 1))
+		cell, _ = excelize.CoordinatesToCellName(col+1, row+i)
+		f.SetCellValue(sheetName, cell, label[1])
+	}
+}
+
+func rightBorder(months []*scheduleMonth, date time.Time) int {
+	// returns the style of the right border of the half day cell of "date":
+	// thin inside a day, medium between days and thick at the end of a month
+	if date.Hour() < 12 {
+		return 1
+	}
+	for _, month := range months {
+		if date.Year() == month.start.Year() && date.Month() == month.start.Month() {
+			if date.Day() == month.start.Day()+month.days-1 {
+				return 5
+			}
+		}
+	}
+	return 2
+}
+
+func findCell(months []*scheduleMonth, date time.Time, pilotId int) string {
+	// find a cell in a visualized airline crew rostering schedule based on the date and the pilot
+	// returns an empty string if the date is outside the schedule
+	for _, month := range months {
+		day := int(date.Sub(month.start).Hours() / 24)
+		if date.Before(month.start) || day >= month.days || month.startCell == "" {
+			continue
+		}
+		col, row, _ := excelize.CellNameToCoordinates(month.startCell)
+		row += 2 + pilotId
+		col += 2 * day
+		if date.Hour() >= 12 {
+			col++
+		}
+		cell, _ := excelize.CoordinatesToCellName(col, row)
+		return cell
+	}
+	return ""
+}
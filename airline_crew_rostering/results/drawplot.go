@@ -1,151 +1,357 @@
-package results
-
-import (
-	"fmt"
-	"image/color"
-	"log"
-	"math"
-
-	"go-airline-crew-rostering/input"
-	"go-airline-crew-rostering/metrics"
-
-	"gonum.org/v1/plot"
-	"gonum.org/v1/plot/plotter"
-	"gonum.org/v1/plot/text"
-	"gonum.org/v1/plot/vg"
-)
-
-type ticker struct {
-	Ticker plot.Ticker
-	Format string
-	// Time   func(t float64) time.Time
-}
-
-func tickers(min float64, max float64) (int, int) {
-	minorTicker := 1
-	majorTicker := 2
-	if max-min > 25 {
-		minorTicker = 2
-		majorTicker = 10
-	}
-	if max-min > 50 {
-		minorTicker = 5
-		majorTicker = 10
-	}
-	if max-min > 100 {
-		minorTicker, majorTicker = tickers(min/10, max/10)
-		minorTicker = minorTicker * 10
-		majorTicker = majorTicker * 10
-	}
-	return minorTicker, majorTicker
-}
-
-func (t ticker) Ticks(min float64, max float64) []plot.Tick {
-	minorTicker, majorTicker := tickers(min, max)
-	ticks := []plot.Tick{}
-	i := math.Floor(float64(min)/float64(minorTicker)) * float64(minorTicker)
-	for {
-		t := plot.Tick{Value: i}
-		if int(i)%majorTicker == 0 {
-			t.Label = fmt.Sprintf("%.0f\n", i)
-		}
-		ticks = append(ticks, t)
-		i = i + float64(minorTicker)
-		if i > max {
-			break
-		}
-	}
-	return ticks
-}
-
-func Min(min float64, max float64) float64 {
-	_, majorTicker := tickers(min, max)
-	return math.Floor(float64(min)/float64(majorTicker)) * float64(majorTicker)
-}
-
-func Max(min float64, max float64) float64 {
-	_, majorTicker := tickers(min, max)
-	return math.Ceil(float64(max)/float64(majorTicker)) * float64(majorTicker)
-}
-
-func drawIterationMetricsPlot(plotFile string, m *metrics.Metrics, args *input.ArgumentCollection) string {
-	// draw a plot depicting the progression of best, worst and average cost with each iteration
-	points := func(data []float64) plotter.XYs {
-		points := make(plotter.XYs, len(data))
-		for i := range points {
-			points[i].X = float64(i)
-			points[i].Y = data[i]
-		}
-		return points
-	}
-
-	p := plot.New()
-	// p.BackgroundColor = color.RGBA{R: 204, G: 201, B: 239, A: 255}
-
-	if args.Algorithm == "multiCSO" {
-		p.Title.Text = fmt.Sprintf("Cost Comparison per Iteration\n(Multi-step CSO, FL=%02.1f)", *args.FL)
-	} else if args.Algorithm == "AOA" {
-		p.Title.Text = fmt.Sprintf("Cost Comparison per Iteration\n(AOA, C1=%02.1f, C2=%02.1f, C3=%02.1f, C4=%02.1f)", args.Constants[0], args.Constants[1], args.Constants[2], args.Constants[3])
-	}
-
-	p.Title.TextStyle.XAlign = text.XCenter
-	p.Title.Padding = vg.Points(15)
-	p.Title.TextStyle.Font.Typeface = "Arial"
-	p.Title.TextStyle.Font.Size = 16
-	p.Title.TextStyle.Font.Weight = 3
-
-	p.X.Label.Text = "Generation"
-	p.X.Label.TextStyle.Font.Typeface = "Arial"
-	p.X.Label.TextStyle.Font.Size = 14
-	p.X.Label.TextStyle.Font.Weight = 3
-	p.X.Label.Padding = vg.Points(10)
-
-	p.Y.Label.Text = "Solution Cost"
-	p.Y.Label.TextStyle.Font.Typeface = "Arial"
-	p.Y.Label.TextStyle.Font.Size = 14
-	p.Y.Label.TextStyle.Font.Weight = 3
-	p.Y.Label.Padding = vg.Points(10)
-
-	p.Legend.TextStyle.Font.Typeface = "Arial"
-	p.Legend.Top = true
-	p.Legend.Left = true
-	p.Legend.Padding = vg.Millimeter
-
-	plottedData, err := plotter.NewLine(points(m.IterBestCost))
-	if err != nil {
-		log.Panic(err)
-	}
-	plottedData.Color = color.RGBA{R: 31, G: 179, B: 58, A: 255}
-	p.Add(plottedData)
-	p.Legend.Add("Iter Best Cost", plottedData)
-
-	plottedData, err = plotter.NewLine(points(m.IterWorstCost))
-	if err != nil {
-		log.Panic(err)
-	}
-	plottedData.Color = color.RGBA{R: 153, G: 29, B: 77, A: 255}
-	p.Add(plottedData)
-	p.Legend.Add("Iter Worst Cost", plottedData)
-
-	plottedData, err = plotter.NewLine(points(m.IterAverageCost))
-	if err != nil {
-		log.Panic(err)
-	}
-	plottedData.Color = color.RGBA{R: 106, G: 182, B: 224, A: 255}
-	p.Add(plottedData)
-	p.Legend.Add("Iter Average Cost", plottedData)
-
-	p.X.Tick.Marker = ticker{}
-
-	p.Y.Tick.Marker = ticker{}
-
-	p.X.Max *= 1.01
-	p.Y.Max *= 1.01
-
-	err = p.Save(15*vg.Centimeter, 15*vg.Centimeter, plotFile)
-	if err != nil {
-		log.Panic(err)
-	}
-
-	return plotFile
-}
+package results
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+	"math"
+
+	"go-airline-crew-rostering/airline"
+	"go-airline-crew-rostering/comparison"
+	"go-airline-crew-rostering/input"
+	"go-airline-crew-rostering/metrics"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/plotutil"
+	"gonum.org/v1/plot/text"
+	"gonum.org/v1/plot/vg"
+)
+
+type ticker struct {
+	Ticker plot.Ticker
+	Format string
+	// Time   func(t float64) time.Time
+}
+
+func tickers(min float64, max float64) (int, int) {
+	minorTicker := 1
+	majorTicker := 2
+	if max-min > 25 {
+		minorTicker = 2
+		majorTicker = 10
+	}
+	if max-min > 50 {
+		minorTicker = 5
+		majorTicker = 10
+	}
+	if max-min > 100 {
+		minorTicker, majorTicker = tickers(min/10, max/10)
+		minorTicker = minorTicker * 10
+		majorTicker = majorTicker * 10
+	}
+	return minorTicker, majorTicker
+}
+
+func (t ticker) Ticks(min float64, max float64) []plot.Tick {
+	minorTicker, majorTicker := tickers(min, max)
+	ticks := []plot.Tick{}
+	i := math.Floor(float64(min)/float64(minorTicker)) * float64(minorTicker)
+	for {
+		t := plot.Tick{Value: i}
+		if int(i)%majorTicker == 0 {
+			t.Label = fmt.Sprintf("%.0f\n", i)
+		}
+		ticks = append(ticks, t)
+		i = i + float64(minorTicker)
+		if i > max {
+			break
+		}
+	}
+	return ticks
+}
+
+func Min(min float64, max float64) float64 {
+	_, majorTicker := tickers(min, max)
+	return math.Floor(float64(min)/float64(majorTicker)) * float64(majorTicker)
+}
+
+func Max(min float64, max float64) float64 {
+	_, majorTicker := tickers(min, max)
+	return math.Ceil(float64(max)/float64(majorTicker)) * float64(majorTicker)
+}
+
+func drawIterationMetricsPlot(plotFile string, m *metrics.Metrics, args *input.ArgumentCollection) string {
+	// draw a plot depicting the progression of best, worst and average cost with each iteration
+	points := func(data []float64) plotter.XYs {
+		points := make(plotter.XYs, len(data))
+		for i := range points {
+			points[i].X = float64(i)
+			points[i].Y = data[i]
+		}
+		return points
+	}
+
+	p := plot.New()
+	// p.BackgroundColor = color.RGBA{R: 204, G: 201, B: 239, A: 255}
+
+	if args.Algorithm == "multiCSO" {
+		p.Title.Text = fmt.Sprintf("Cost Comparison per Iteration\n(Multi-step CSO, FL=%02.1f)", *args.FL)
+	} else if args.Algorithm == "AOA" {
+		p.Title.Text = fmt.Sprintf("Cost Comparison per Iteration\n(AOA, C1=%02.1f, C2=%02.1f, C3=%02.1f, C4=%02.1f)", args.Constants[0], args.Constants[1], args.Constants[2], args.Constants[3])
+	} else if args.Algorithm == "NSGA" {
+		p.Title.Text = fmt.Sprintf("Cost Comparison per Iteration\n(NSGA-II, Mutation=%02.2f)", *args.Mutation)
+	} else if args.Algorithm == "columnGeneration" {
+		p.Title.Text = "Master Problem Objective per Iteration\n(Column Generation)"
+	}
+
+	p.Title.TextStyle.XAlign = text.XCenter
+	p.Title.Padding = vg.Points(15)
+	p.Title.TextStyle.Font.Typeface = "Arial"
+	p.Title.TextStyle.Font.Size = 16
+	p.Title.TextStyle.Font.Weight = 3
+
+	p.X.Label.Text = "Generation"
+	p.X.Label.TextStyle.Font.Typeface = "Arial"
+	p.X.Label.TextStyle.Font.Size = 14
+	p.X.Label.TextStyle.Font.Weight = 3
+	p.X.Label.Padding = vg.Points(10)
+
+	p.Y.Label.Text = "Solution Cost"
+	p.Y.Label.TextStyle.Font.Typeface = "Arial"
+	p.Y.Label.TextStyle.Font.Size = 14
+	p.Y.Label.TextStyle.Font.Weight = 3
+	p.Y.Label.Padding = vg.Points(10)
+
+	p.Legend.TextStyle.Font.Typeface = "Arial"
+	p.Legend.Top = true
+	p.Legend.Left = true
+	p.Legend.Padding = vg.Millimeter
+
+	if args.Algorithm == "columnGeneration" {
+		p.X.Label.Text = "Iteration"
+		plottedData, err := plotter.NewLine(points(m.IterBestCost))
+		if err != nil {
+			log.Panic(err)
+		}
+		plottedData.Color = color.RGBA{R: 31, G: 179, B: 58, A: 255}
+		p.Add(plottedData)
+		p.Legend.Add("Master Objective", plottedData)
+
+		plottedData, err = plotter.NewLine(points(m.IterLowerBound))
+		if err != nil {
+			log.Panic(err)
+		}
+		plottedData.Color = color.RGBA{R: 153, G: 29, B: 77, A: 255}
+		p.Add(plottedData)
+		p.Legend.Add("Lower Bound", plottedData)
+
+		p.X.Tick.Marker = ticker{}
+		p.Y.Tick.Marker = ticker{}
+		p.X.Max *= 1.01
+		p.Y.Max *= 1.01
+		if err := p.Save(15*vg.Centimeter, 15*vg.Centimeter, plotFile); err != nil {
+			log.Panic(err)
+		}
+		return plotFile
+	}
+
+	plottedData, err := plotter.NewLine(points(m.IterBestCost))
+	if err != nil {
+		log.Panic(err)
+	}
+	plottedData.Color = color.RGBA{R: 31, G: 179, B: 58, A: 255}
+	p.Add(plottedData)
+	p.Legend.Add("Iter Best Cost", plottedData)
+
+	plottedData, err = plotter.NewLine(points(m.IterWorstCost))
+	if err != nil {
+		log.Panic(err)
+	}
+	plottedData.Color = color.RGBA{R: 153, G: 29, B: 77, A: 255}
+	p.Add(plottedData)
+	p.Legend.Add("Iter Worst Cost", plottedData)
+
+	plottedData, err = plotter.NewLine(points(m.IterAverageCost))
+	if err != nil {
+		log.Panic(err)
+	}
+	plottedData.Color = color.RGBA{R: 106, G: 182, B: 224, A: 255}
+	p.Add(plottedData)
+	p.Legend.Add("Iter Average Cost", plottedData)
+
+	p.X.Tick.Marker = ticker{}
+
+	p.Y.Tick.Marker = ticker{}
+
+	p.X.Max *= 1.01
+	p.Y.Max *= 1.01
+
+	err = p.Save(15*vg.Centimeter, 15*vg.Centimeter, plotFile)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	return plotFile
+}
+
+func drawConvergencePlot(plotFile string, c *comparison.Comparison) string {
+	// draw a plot depicting the best cost of each generation averaged over the runs of every set
+	p := plot.New()
+
+	p.Title.Text = "Average Best Cost per Iteration"
+	p.Title.TextStyle.XAlign = text.XCenter
+	p.Title.Padding = vg.Points(15)
+	p.Title.TextStyle.Font.Typeface = "Arial"
+	p.Title.TextStyle.Font.Size = 16
+	p.Title.TextStyle.Font.Weight = 3
+
+	p.X.Label.Text = "Generation"
+	p.X.Label.TextStyle.Font.Typeface = "Arial"
+	p.X.Label.TextStyle.Font.Size = 14
+	p.X.Label.TextStyle.Font.Weight = 3
+	p.X.Label.Padding = vg.Points(10)
+
+	p.Y.Label.Text = "Solution Cost"
+	p.Y.Label.TextStyle.Font.Typeface = "Arial"
+	p.Y.Label.TextStyle.Font.Size = 14
+	p.Y.Label.TextStyle.Font.Weight = 3
+	p.Y.Label.Padding = vg.Points(10)
+
+	p.Legend.TextStyle.Font.Typeface = "Arial"
+	p.Legend.Top = true
+	p.Legend.Padding = vg.Millimeter
+
+	for i, set := range c.Sets {
+		points := make(plotter.XYs, len(set.Curve))
+		for t := range points {
+			points[t].X = float64(t)
+			points[t].Y = set.Curve[t]
+		}
+		plottedData, err := plotter.NewLine(points)
+		if err != nil {
+			log.Panic(err)
+		}
+		plottedData.Color = plotutil.Color(i)
+		p.Add(plottedData)
+		p.Legend.Add(set.Name, plottedData)
+	}
+
+	p.X.Tick.Marker = ticker{}
+	p.Y.Tick.Marker = ticker{}
+	p.X.Max *= 1.01
+	p.Y.Max *= 1.01
+
+	if err := p.Save(15*vg.Centimeter, 15*vg.Centimeter, plotFile); err != nil {
+		log.Panic(err)
+	}
+	return plotFile
+}
+
+func newPlot(title string, xLabel string, yLabel string) *plot.Plot {
+	// returns a plot with the title, the labels of the axes and the legend
+	// styled like the rest of the plots of the results
+	p := plot.New()
+
+	p.Title.Text = title
+	p.Title.TextStyle.XAlign = text.XCenter
+	p.Title.Padding = vg.Points(15)
+	p.Title.TextStyle.Font.Typeface = "Arial"
+	p.Title.TextStyle.Font.Size = 16
+	p.Title.TextStyle.Font.Weight = 3
+
+	p.X.Label.Text = xLabel
+	p.X.Label.TextStyle.Font.Typeface = "Arial"
+	p.X.Label.TextStyle.Font.Size = 14
+	p.X.Label.TextStyle.Font.Weight = 3
+	p.X.Label.Padding = vg.Points(10)
+
+	p.Y.Label.Text = yLabel
+	p.Y.Label.TextStyle.Font.Typeface = "Arial"
+	p.Y.Label.TextStyle.Font.Size = 14
+	p.Y.Label.TextStyle.Font.Weight = 3
+	p.Y.Label.Padding = vg.Points(10)
+
+	p.Legend.TextStyle.Font.Typeface = "Arial"
+	p.Legend.Top = true
+	p.Legend.Padding = vg.Millimeter
+
+	p.X.Tick.Marker = ticker{}
+	p.Y.Tick.Marker = ticker{}
+	return p
+}
+
+func drawWorkloadPlot(plotFile string, al *airline.Airline) string {
+	// draw a plot depicting the flight time of each pilot compared to the average workload (in hours)
+	p := newPlot("Flight Time per Pilot", "Pilot", "Flight Time (hours)")
+
+	values := make(plotter.Values, len(al.PilotsArray))
+	for i, pilot := range al.PilotsArray {
+		values[i] = pilot.FlightTime / 60
+	}
+	bars, err := plotter.NewBarChart(values, vg.Points(6))
+	if err != nil {
+		log.Panic(err)
+	}
+	bars.LineStyle.Width = 0
+	bars.Color = color.RGBA{R: 106, G: 182, B: 224, A: 255}
+	p.Add(bars)
+	p.Legend.Add("Flight Time", bars)
+
+	average, err := plotter.NewLine(plotter.XYs{{X: -0.5, Y: al.AverageWorkload / 60}, {X: float64(len(values)) - 0.5, Y: al.AverageWorkload / 60}})
+	if err != nil {
+		log.Panic(err)
+	}
+	average.Color = color.RGBA{R: 153, G: 29, B: 77, A: 255}
+	average.Dashes = []vg.Length{vg.Points(5), vg.Points(3)}
+	p.Add(average)
+	p.Legend.Add("Average Workload", average)
+
+	p.Y.Min = 0
+	p.Y.Max *= 1.2
+	if err := p.Save(15*vg.Centimeter, 15*vg.Centimeter, plotFile); err != nil {
+		log.Panic(err)
+	}
+	return plotFile
+}
+
+func drawIterationSeriesPlot(plotFile string, title string, yLabel string, series []float64, colour color.Color) string {
+	// draw a plot depicting the progression of a measure of the algorithm with each iteration
+	p := newPlot(title, "Generation", yLabel)
+
+	points := make(plotter.XYs, len(series))
+	for i := range points {
+		points[i].X = float64(i)
+		points[i].Y = series[i]
+	}
+	plottedData, err := plotter.NewLine(points)
+	if err != nil {
+		log.Panic(err)
+	}
+	plottedData.Color = colour
+	p.Add(plottedData)
+
+	p.Y.Min = 0
+	p.X.Max *= 1.01
+	p.Y.Max = math.Max(p.Y.Max*1.05, 1)
+	if err := p.Save(15*vg.Centimeter, 15*vg.Centimeter, plotFile); err != nil {
+		log.Panic(err)
+	}
+	return plotFile
+}
+
+func drawSimilarityPlot(plotFile string, m *metrics.Metrics) string {
+	// draw a plot depicting the average similarity of the solutions to the global best with each iteration
+	return drawIterationSeriesPlot(plotFile, "Similarity to the Global Best per Iteration", "Similarity (%)",
+		m.IterSimilarity, color.RGBA{R: 31, G: 179, B: 58, A: 255})
+}
+
+func drawUniqueSolutionsPlot(plotFile string, m *metrics.Metrics) string {
+	// draw a plot depicting the number of different solutions found up to each iteration
+	series := make([]float64, len(m.IterUnique))
+	for i, unique := range m.IterUnique {
+		series[i] = float64(unique)
+	}
+	return drawIterationSeriesPlot(plotFile, "Unique Solutions Discovered", "Unique Solutions",
+		series, color.RGBA{R: 106, G: 182, B: 224, A: 255})
+}
+
+func drawUncoveredPlot(plotFile string, m *metrics.Metrics) string {
+	// draw a plot depicting the uncovered pairs of the best solution of each iteration
+	series := make([]float64, len(m.IterUncovered))
+	for i, uncovered := range m.IterUncovered {
+		series[i] = float64(uncovered)
+	}
+	return drawIterationSeriesPlot(plotFile, "Uncovered Pairs per Iteration", "Uncovered Pairs",
+		series, color.RGBA{R: 153, G: 29, B: 77, A: 255})
+}
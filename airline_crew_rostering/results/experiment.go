@@ -0,0 +1,237 @@
+package results
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"go-airline-crew-rostering/experiment"
+	"go-airline-crew-rostering/input"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// measures summarized by the experiments, in the order of their columns
+var experimentMeasures = []string{"Cost", "Coverage", "Jumps", "Similarity", "Time"}
+
+func experimentSummaries(c *experiment.Configuration) []*experiment.Summary {
+	// returns the summary of every measure of the configuration
+	return []*experiment.Summary{c.Cost, c.Coverage, c.Jumps, c.Similarity, c.Time}
+}
+
+func PrintExperiment(e *experiment.Experiment, args *input.ArgumentCollection) {
+	// Store the summary of every configuration of the experiment
+	// and the measures of every run in every requested format
+	for _, format := range *args.Formats {
+		writer, ok := Writers[format].(ExperimentWriter)
+		if !ok {
+			fmt.Printf("the %s format cannot store an experiment\n", format)
+			continue
+		}
+		writer.WriteExperiment(FileName(*args.ResultsFile, Writers[format].Extension()), e, args)
+	}
+}
+
+func (writer *JSONWriter) WriteExperiment(fileName string, e *experiment.Experiment, args *input.ArgumentCollection) {
+	// Create a json file with the experiment, including the measures of every run, and its manifest
+	writeJSON(fileName, &struct {
+		*experiment.Experiment
+		Manifest *manifestRecord `json:"manifest"`
+	}{e, newManifest(e.Seed, args, nil)})
+}
+
+func (writer *CSVWriter) WriteExperiment(fileName string, e *experiment.Experiment, args *input.ArgumentCollection) {
+	// Create a csv file with the summary of every configuration (one row per
+	// configuration) and the manifest in a file with the same name and a suffix
+	header := []string{"algorithm"}
+	for _, parameter := range e.Configurations[0].Parameters {
+		header = append(header, parameter.Name)
+	}
+	header = append(header, "runs")
+	for _, measure := range experimentMeasures {
+		for _, statistic := range []string{"mean", "std", "best", "worst"} {
+			header = append(header, strings.ToLower(measure)+"_"+statistic)
+		}
+	}
+	records := [][]string{header}
+	for _, configuration := range e.Configurations {
+		record := []string{e.Algorithm}
+		for _, parameter := range configuration.Parameters {
+			record = append(record, strconv.FormatFloat(parameter.Value, 'g', -1, 64))
+		}
+		record = append(record, strconv.Itoa(len(configuration.Runs)))
+		for _, summary := range experimentSummaries(configuration) {
+			for _, value := range []float64{summary.Mean, summary.Std, summary.Best, summary.Worst} {
+				record = append(record, strconv.FormatFloat(value, 'f', 4, 64))
+			}
+		}
+		records = append(records, record)
+	}
+	writeCSV(fileName, records)
+	writeManifestCSV(strings.TrimSuffix(fileName, ".csv")+"_manifest.csv", newManifest(e.Seed, args, nil))
+}
+
+func (writer *XLSXWriter) WriteExperiment(fileName string, e *experiment.Experiment, args *input.ArgumentCollection) {
+	// Creates an excel file with the summary of every configuration
+	// of the experiment and the measures of every run
+	f := excelize.NewFile()
+	defer func() {
+		if err := f.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	docProperties, _ := f.GetDocProps()
+	docProperties.Language = "en-UK"
+	f.SetDocProps(docProperties)
+
+	f.SetDefaultFont("Arial")
+
+	summarySheetName := "Experiment"
+	runsSheetName := "Runs"
+	manifestSheetName := "Manifest"
+	f.SetSheetName("Sheet1", summarySheetName)
+	if _, err := f.NewSheet(runsSheetName); err != nil {
+		fmt.Println(err)
+		return
+	}
+	if _, err := f.NewSheet(manifestSheetName); err != nil {
+		fmt.Println(err)
+		return
+	}
+	drawExperimentSheet(f, summarySheetName, e)
+	drawRunsSheet(f, runsSheetName, e)
+	drawManifestSheet(f, newManifest(e.Seed, args, nil))
+
+	if err := f.SaveAs(fileName); err != nil {
+		fmt.Println(err)
+	}
+}
+
+func drawExperimentSheet(f *excelize.File, sheetName string, e *experiment.Experiment) {
+	// create an excel sheet containing the settings of the experiment
+	// and the summary of the measures of every configuration
+	setView(f, sheetName, 100.0)
+
+	for i := 5; i <= 11; i++ {
+		f.SetRowHeight(sheetName, i, 30)
+	}
+	f.SetCellValue(sheetName, "B2", "Experiment")
+	f.SetCellValue(sheetName, "B5", "Algorithm")
+	f.SetCellValue(sheetName, "B6", "Input File")
+	f.SetCellValue(sheetName, "B7", "Schedule")
+	f.SetCellValue(sheetName, "B8", "Pilots")
+	f.SetCellValue(sheetName, "B9", "Generations")
+	f.SetCellValue(sheetName, "B10", "Agents")
+	f.SetCellValue(sheetName, "B11", "Runs")
+	f.SetCellValue(sheetName, "D5", e.Algorithm)
+	f.SetCellValue(sheetName, "D6", e.Filename)
+	f.SetCellValue(sheetName, "D7", e.StartDate.Format("02/01/2006")+" - "+e.EndDate.Format("02/01/2006"))
+	f.SetCellValue(sheetName, "D8", e.Pilots)
+	f.SetCellValue(sheetName, "D9", e.Generations)
+	f.SetCellValue(sheetName, "D10", e.Agents)
+	f.SetCellValue(sheetName, "D11", len(e.Seeds))
+	drawVerticalTable(f, sheetName, "B2", 7, "4F81BD", "B8CCE4")
+	f.SetColWidth(sheetName, "D", "E", 14)
+
+	headerStyleId, _ := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Size: 12, Color: "FFFFFF", Bold: true},
+		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"4F81BD"}},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center", WrapText: true},
+	})
+	cellStyleId, _ := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Size: 12},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center"},
+	})
+
+	// one column per parameter and four columns (mean, std, best, worst) per measure
+	headers := []string{}
+	for _, parameter := range e.Configurations[0].Parameters {
+		headers = append(headers, parameter.Name)
+	}
+	for _, measure := range experimentMeasures {
+		for _, statistic := range []string{"Mean", "Std", "Best", "Worst"} {
+			headers = append(headers, measure+" "+statistic)
+		}
+	}
+	for i, header := range headers {
+		cell, _ := excelize.CoordinatesToCellName(7+i, 2)
+		f.SetCellValue(sheetName, cell, header)
+	}
+	last, _ := excelize.ColumnNumberToName(6 + len(headers))
+	f.SetColWidth(sheetName, "G", last, 13)
+	f.SetRowHeight(sheetName, 2, 40)
+	f.SetCellStyle(sheetName, "G2", last+"2", headerStyleId)
+	for i, configuration := range e.Configurations {
+		row := 3 + i
+		values := []interface{}{}
+		for _, parameter := range configuration.Parameters {
+			values = append(values, parameter.Value)
+		}
+		for _, summary := range experimentSummaries(configuration) {
+			for _, value := range []float64{summary.Mean, summary.Std, summary.Best, summary.Worst} {
+				values = append(values, math.Round(value*100)/100)
+			}
+		}
+		for j, value := range values {
+			cell, _ := excelize.CoordinatesToCellName(7+j, row)
+			f.SetCellValue(sheetName, cell, value)
+		}
+		f.SetCellStyle(sheetName, fmt.Sprintf("G%d", row), fmt.Sprintf("%s%d", last, row), cellStyleId)
+	}
+	enable := true
+	f.AddTable(sheetName, &excelize.Table{
+		Range:          fmt.Sprintf("G2:%s%d", last, 2+len(e.Configurations)),
+		Name:           "Summary",
+		StyleName:      "TableStyleMedium9",
+		ShowRowStripes: &enable,
+	})
+}
+
+func drawRunsSheet(f *excelize.File, sheetName string, e *experiment.Experiment) {
+	// create an excel sheet containing the measures of every run of the experiment
+	setView(f, sheetName, 100.0)
+
+	headers := []string{}
+	for _, parameter := range e.Configurations[0].Parameters {
+		headers = append(headers, parameter.Name)
+	}
+	headers = append(headers, "Seed", "Cost", "Coverage", "Jumps", "Similarity", "Valid Solutions", "Time")
+	for i, header := range headers {
+		cell, _ := excelize.CoordinatesToCellName(2+i, 2)
+		f.SetCellValue(sheetName, cell, header)
+	}
+	last, _ := excelize.ColumnNumberToName(1 + len(headers))
+	f.SetColWidth(sheetName, "B", last, 14.62)
+	f.SetRowHeight(sheetName, 2, 40)
+
+	styleId, _ := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Size: 12},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center", WrapText: true},
+	})
+	row := 3
+	for _, configuration := range e.Configurations {
+		for _, run := range configuration.Runs {
+			values := []interface{}{}
+			for _, parameter := range configuration.Parameters {
+				values = append(values, parameter.Value)
+			}
+			values = append(values, strconv.FormatInt(run.Seed, 10), math.Round(run.Cost), math.Round(run.Coverage*100)/100,
+				run.Jumps, math.Round(run.Similarity*100)/100, run.ValidSolutions, math.Round(run.Time*100)/100)
+			for j, value := range values {
+				cell, _ := excelize.CoordinatesToCellName(2+j, row)
+				f.SetCellValue(sheetName, cell, value)
+			}
+			row++
+		}
+	}
+	f.SetCellStyle(sheetName, "B2", fmt.Sprintf("%s%d", last, row-1), styleId)
+	enable := true
+	f.AddTable(sheetName, &excelize.Table{
+		Range:          fmt.Sprintf("B2:%s%d", last, row-1),
+		Name:           "Runs",
+		StyleName:      "TableStyleMedium20",
+		ShowRowStripes: &enable,
+	})
+}
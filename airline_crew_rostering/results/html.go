@@ -0,0 +1,315 @@
+package results
+
+import (
+	"fmt"
+	"html/template"
+	"math"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"go-airline-crew-rostering/airline"
+	"go-airline-crew-rostering/fitness"
+	"go-airline-crew-rostering/input"
+	"go-airline-crew-rostering/metrics"
+)
+
+// Writer of a self-contained html report with the roster as a gantt chart,
+// the convergence plot and the statistics of the run
+type HTMLWriter struct{}
+
+// Content of the html report
+type report struct {
+	Title     string
+	Generated string
+	General   [][2]interface{} // rows of the general information table
+	Solution  [][2]interface{} // rows of the solution table
+	Algorithm [][2]interface{} // rows of the optimization algorithm table
+	Days      []*reportDay
+	Months    []*reportMonth
+	Pilots    []*reportPilot
+	Fairness  []*metrics.Fairness
+	Uncovered []*metrics.Uncovered
+	Islands   []*islandEntry
+	Plot      template.HTML // convergence plot as inline svg
+	Workload  float64       // optimal workload (in minutes)
+	Manifest  [][2]string   // settings and values of the manifest of the run
+}
+
+// Day of the schedule in the header of the roster
+type reportDay struct {
+	Day     int
+	Weekend bool
+}
+
+// Month of the schedule in the header of the roster
+type reportMonth struct {
+	Name string
+	Days int // days of the month inside the schedule
+}
+
+// Row of the roster
+type reportPilot struct {
+	Id         int
+	FlightTime float64
+	Deviation  float64
+	Pairings   int
+	DaysOff    int
+	Bars       []*reportBar
+}
+
+// Pairing of the roster drawn as a bar
+type reportBar struct {
+	Id    int
+	Style template.CSS // position and width of the bar (in days)
+	Tip   string       // tooltip with the details of the pairing
+}
+
+func (writer *HTMLWriter) Extension() string {
+	return "html"
+}
+
+func (writer *HTMLWriter) Write(fileName string, m *metrics.Metrics, args *input.ArgumentCollection, al *airline.Airline, objective *fitness.Objective) {
+	// Create an html file that can be viewed offline, since the styles, the scripts
+	// and the plot are embedded in the file
+	r := newReport(fileName, m, args, al, objective)
+	file, err := os.Create(fileName)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer file.Close()
+	if err := reportTemplate.Execute(file, r); err != nil {
+		fmt.Println(err)
+	}
+}
+
+func newReport(fileName string, m *metrics.Metrics, args *input.ArgumentCollection, al *airline.Airline, objective *fitness.Objective) *report {
+	// returns the content of the report of the run
+	record := newRunRecord(m, args, al, objective)
+	r := &report{
+		Title:     fmt.Sprintf("Airline Crew Rostering (%s)", args.Algorithm),
+		Generated: time.Now().Format("2006-01-02 15:04"),
+		Fairness:  m.Fairness,
+		Uncovered: m.Uncovered,
+		Islands:   record.Metrics.Islands,
+		Workload:  math.Round(al.AverageWorkload),
+		Manifest:  record.Manifest.rows(),
+	}
+
+	r.General = [][2]interface{}{
+		{"Input File", *args.Filename},
+		{"Algorithm", args.Algorithm},
+		{"Start Date", args.StartDate.Format("2 January 2006")},
+		{"End Date", args.EndDate.Format("2 January 2006")},
+		{"Pilots", *args.Pilots},
+		{"Pairs", len(al.PairsArray) - 1},
+		{"Optimal Workload", math.Round(al.AverageWorkload)},
+		{"Agents", *args.Agents},
+		{"Generations", *args.Generations},
+		{"Seed", m.Seed},
+		{"Execution Time", m.TotalTime.Round(time.Millisecond).String()},
+	}
+	names := []string{}
+	for name := range record.Configuration.Parameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		r.General = append(r.General, [2]interface{}{name, record.Configuration.Parameters[name]})
+	}
+	if m.StopReason != "" {
+		r.General = append(r.General, [2]interface{}{"Stop Reason", m.StopReason}, [2]interface{}{"Generations Run", m.Generations})
+	}
+
+	r.Solution = [][2]interface{}{
+		{"Assigned Pairs", m.TotalAssignedPairs},
+		{"Unassigned Pairs", len(al.PairsArray) - 1 - m.TotalAssignedPairs},
+		{"Average Rest Period", math.Round(m.AverageRestPeriod*100) / 100},
+		{"Average Days Off", math.Round(m.AverageDaysOff*100) / 100},
+		{"Best Cost", math.Round(m.GlobalBestSolutionCost)},
+		{"Cost", math.Round(record.Metrics.Cost)},
+	}
+	if args.Algorithm == "columnGeneration" {
+		r.Solution = append(r.Solution, [2]interface{}{"Lower Bound", math.Round(m.LowerBound)})
+	}
+	for _, term := range record.Metrics.Objective {
+		r.Solution = append(r.Solution, [2]interface{}{"Objective " + term.Term, math.Round(term.Value*100) / 100})
+	}
+
+	r.Algorithm = [][2]interface{}{
+		{"Valid Solutions", m.ValidSolutions},
+		{"Invalid Solutions", m.TotalSolutions - m.ValidSolutions},
+		{"Total Solutions", m.TotalSolutions},
+		{"Unique Solutions", m.UniqueCount},
+		{"Jumps", m.Jumps},
+		{"Similarity", fmt.Sprintf("%.2f%%", m.AverageSimilarity)},
+	}
+
+	// days of the schedule grouped by month
+	for day := 0; day < al.ScheduleDuration; day++ {
+		date := al.ScheduleStart.AddDate(0, 0, day)
+		r.Days = append(r.Days, &reportDay{date.Day(), date.Weekday() == time.Saturday || date.Weekday() == time.Sunday})
+		if day == 0 || date.Day() == 1 {
+			r.Months = append(r.Months, &reportMonth{Name: date.Format("January 2006")})
+		}
+		r.Months[len(r.Months)-1].Days++
+	}
+
+	for _, pilot := range al.PilotsArray {
+		p := &reportPilot{
+			Id:         pilot.Id,
+			FlightTime: math.Round(pilot.FlightTime),
+			Deviation:  math.Round(math.Abs(al.AverageWorkload - pilot.FlightTime)),
+			Pairings:   pilot.AssignedLength,
+			DaysOff:    pilot.DaysOff(),
+		}
+		for _, pair := range pilot.AssignedPairs[1 : pilot.AssignedLength+1] {
+			start := pair.Start.Sub(al.ScheduleStart).Hours() / 24
+			length := pair.End.Sub(pair.Start).Hours() / 24
+			tip := []string{fmt.Sprintf("Pair %04d", pair.Id),
+				fmt.Sprintf("Departure: %s", pair.Start.Format("02/01/2006 15:04")),
+				fmt.Sprintf("Arrival: %s", pair.End.Format("02/01/2006 15:04"))}
+			for _, leg := range pair.Legs {
+				tip = append(tip, fmt.Sprintf("%d %s-%s %s-%s", leg.Number, leg.Source, leg.Destination,
+					leg.Start.Format("15:04"), leg.End.Format("15:04")))
+			}
+			p.Bars = append(p.Bars, &reportBar{
+				Id:    pair.Id,
+				Style: template.CSS(fmt.Sprintf("left: calc(var(--day) * %.4f); width: calc(var(--day) * %.4f)", start, length)),
+				Tip:   strings.Join(tip, "\n"),
+			})
+		}
+		r.Pilots = append(r.Pilots, p)
+	}
+
+	// the plot is drawn as an svg file and copied to the report
+	plotFilename := strings.TrimSuffix(fileName, ".html") + "_iterations.svg"
+	drawIterationMetricsPlot(plotFilename, m, args)
+	plot, err := os.ReadFile(plotFilename)
+	if err != nil {
+		fmt.Println(err)
+	}
+	if *args.Plots != "svg" {
+		os.Remove(plotFilename)
+	}
+	r.Plot = template.HTML(regexp.MustCompile(`(?s)^<\?xml.*?\?>\s*`).ReplaceAllString(string(plot), ""))
+	return r
+}
+
+var reportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"inc": func(i int) int { return i + 1 },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: Arial, sans-serif; margin: 24px; color: #222; }
+h1 { font-size: 22px; }
+h2 { font-size: 18px; margin-top: 32px; }
+.tables { display: flex; flex-wrap: wrap; gap: 24px; align-items: flex-start; }
+table { border-collapse: collapse; font-size: 13px; }
+th { background: #4F81BD; color: #FFF; padding: 6px 10px; }
+td { padding: 4px 10px; text-align: center; }
+tr:nth-child(even) td { background: #DBE5F1; }
+td.name { text-align: left; font-weight: bold; }
+.controls { margin: 8px 0; font-size: 13px; }
+.roster { --day: 24px; overflow-x: auto; border: 1px solid #BBB; }
+.row { display: flex; }
+.label { position: sticky; left: 0; z-index: 2; flex: none; width: 64px; background: #4F81BD; color: #FFF;
+  font-size: 12px; line-height: 22px; text-align: center; border-bottom: 1px solid #FFF; }
+.cells { display: flex; flex: none; }
+.month { flex: none; width: calc(var(--day) * var(--days)); background: #C0504D; color: #FFF; font-size: 12px;
+  line-height: 22px; text-align: center; box-sizing: border-box; border-left: 1px solid #FFF; overflow: hidden; }
+.day { flex: none; width: var(--day); font-size: 11px; line-height: 22px; text-align: center; box-sizing: border-box;
+  border-left: 1px solid #EEE; background: #E6B9B8; }
+.day.weekend { background: #D99694; }
+.lane { position: relative; flex: none; height: 22px; border-bottom: 1px solid #EEE;
+  background: repeating-linear-gradient(to right, #E5E5E5 0 1px, transparent 1px var(--day)); }
+.bar { position: absolute; top: 3px; height: 16px; min-width: 3px; background: #1FB33A; border-radius: 3px; cursor: pointer; }
+.bar:hover, .bar.selected { background: #991D4D; z-index: 3; }
+.bar:hover::after { content: attr(data-tip); position: absolute; left: 0; top: 20px; white-space: pre; background: #333; color: #FFF;
+  font-size: 12px; padding: 6px 8px; border-radius: 4px; z-index: 4; pointer-events: none; }
+.row.hidden { display: none; }
+.plot svg { max-width: 100%; height: auto; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p>Generated on {{.Generated}}</p>
+
+<h2>Roster</h2>
+<div class="controls">
+  <label>Pilots <input id="filter" type="text" placeholder="e.g. 3, 10-15"></label>
+  <label>Zoom <input id="zoom" type="range" min="6" max="60" value="24"></label>
+  <span>Click a pairing to highlight it.</span>
+</div>
+<div class="roster" id="roster">
+  <div class="row"><div class="label">Pilot</div><div class="cells">{{range .Months}}<div class="month" style="--days: {{.Days}}">{{.Name}}</div>{{end}}</div></div>
+  <div class="row"><div class="label"></div><div class="cells">{{range .Days}}<div class="day{{if .Weekend}} weekend{{end}}">{{.Day}}</div>{{end}}</div></div>
+  {{range .Pilots}}<div class="row pilot" data-pilot="{{.Id}}"><div class="label">{{.Id}}</div><div class="lane" style="width: calc(var(--day) * {{len $.Days}})">{{range .Bars}}<div class="bar" data-pair="{{.Id}}" style="{{.Style}}" data-tip="{{.Tip}}"></div>{{end}}</div></div>
+  {{end}}
+</div>
+
+<h2>Statistics</h2>
+<div class="tables">
+  <table><tr><th colspan="2">General Information</th></tr>{{range .General}}<tr><td class="name">{{index . 0}}</td><td>{{index . 1}}</td></tr>{{end}}</table>
+  <table><tr><th colspan="2">Solution Information</th></tr>{{range .Solution}}<tr><td class="name">{{index . 0}}</td><td>{{index . 1}}</td></tr>{{end}}</table>
+  <table><tr><th colspan="2">Optimization Algorithm</th></tr>{{range .Algorithm}}<tr><td class="name">{{index . 0}}</td><td>{{index . 1}}</td></tr>{{end}}</table>
+  {{if .Fairness}}<table><tr><th>Dimension</th><th>Gini Coefficient</th><th>Max-Min Spread</th><th>Standard Deviation</th></tr>
+  {{range .Fairness}}<tr><td class="name">{{.Dimension}}</td><td>{{printf "%.3f" .Gini}}</td><td>{{.Spread}}</td><td>{{printf "%.2f" .StandardDeviation}}</td></tr>{{end}}</table>{{end}}
+</div>
+
+<h2>Pilot Statistics</h2>
+<table><tr><th>Pilot</th><th>Flight Time (in minutes)</th><th>Deviation from Optimal Workload ({{.Workload}})</th><th>Assigned Pairs</th><th>Days Off</th></tr>
+{{range .Pilots}}<tr><td>{{.Id}}</td><td>{{.FlightTime}}</td><td>{{.Deviation}}</td><td>{{.Pairings}}</td><td>{{.DaysOff}}</td></tr>{{end}}</table>
+
+{{if .Uncovered}}<h2>Uncovered Pairings</h2>
+<table><tr><th>Pair</th><th>Departure</th><th>Arrival</th><th>Binding Constraint</th></tr>
+{{range .Uncovered}}<tr><td>{{.Pair.Id}}</td><td>{{.Pair.Start.Format "02/01/2006 15:04"}}</td><td>{{.Pair.End.Format "02/01/2006 15:04"}}</td><td>{{.Constraint}}</td></tr>{{end}}</table>{{end}}
+
+{{if .Islands}}<h2>Islands</h2>
+<table><tr><th>Island</th><th>Algorithm</th><th>Seed</th><th>Best Cost</th><th>Valid Solutions</th><th>Jumps</th><th>Immigrants</th></tr>
+{{range $i, $island := .Islands}}<tr><td>{{inc $i}}</td><td>{{.Algorithm}}</td><td>{{.Seed}}</td><td>{{.BestCost}}</td><td>{{.ValidSolutions}}</td><td>{{.Jumps}}</td><td>{{.Immigrants}}</td></tr>{{end}}</table>{{end}}
+
+<h2>Convergence</h2>
+<div class="plot">{{.Plot}}</div>
+
+<h2>Manifest</h2>
+<details><summary>Configuration and provenance of the run</summary>
+<table><tr><th>Setting</th><th>Value</th></tr>
+{{range .Manifest}}<tr><td class="name">{{index . 0}}</td><td>{{index . 1}}</td></tr>{{end}}</table>
+</details>
+
+<script>
+(function () {
+  var roster = document.getElementById("roster");
+  document.getElementById("zoom").addEventListener("input", function (e) {
+    roster.style.setProperty("--day", e.target.value + "px");
+  });
+  document.getElementById("filter").addEventListener("input", function (e) {
+    // show only the pilots of the comma separated ids and ranges
+    var ranges = e.target.value.split(",").map(function (s) { return s.trim(); }).filter(Boolean).map(function (s) {
+      var bounds = s.split("-").map(Number);
+      return [bounds[0], bounds.length > 1 ? bounds[1] : bounds[0]];
+    });
+    roster.querySelectorAll(".pilot").forEach(function (row) {
+      var id = Number(row.dataset.pilot);
+      var shown = ranges.length === 0 || ranges.some(function (r) { return id >= r[0] && id <= r[1]; });
+      row.classList.toggle("hidden", !shown);
+    });
+  });
+  roster.addEventListener("click", function (e) {
+    if (!e.target.classList.contains("bar")) { return; }
+    var selected = !e.target.classList.contains("selected");
+    roster.querySelectorAll(".bar.selected").forEach(function (bar) { bar.classList.remove("selected"); });
+    e.target.classList.toggle("selected", selected);
+  });
+})();
+</script>
+</body>
+</html>
+`))
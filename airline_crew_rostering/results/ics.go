@@ -0,0 +1,177 @@
+package results
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"go-airline-crew-rostering/airline"
+	"go-airline-crew-rostering/fitness"
+	"go-airline-crew-rostering/input"
+	"go-airline-crew-rostering/metrics"
+)
+
+// Writer of iCalendar files with the pairings and the days off of the pilots,
+// either one file per pilot or one combined feed
+type ICSWriter struct{}
+
+const (
+	icsDomain   = "go-airline-crew-rostering" // domain of the unique ids of the events
+	icsDateTime = "20060102T150405Z"
+	icsDate     = "20060102"
+)
+
+func (writer *ICSWriter) Extension() string {
+	return "ics"
+}
+
+func (writer *ICSWriter) Write(fileName string, m *metrics.Metrics, args *input.ArgumentCollection, al *airline.Airline, objective *fitness.Objective) {
+	// Create the calendars of the roster: "fileName" contains every pilot if the feed is combined,
+	// otherwise each pilot has a file with the same name and the suffix "_pilot<id>"
+	manifest, err := json.Marshal(newManifest(m.Seed, args, al))
+	if err != nil {
+		fmt.Println(err)
+	}
+	writeCalendars(fileName, args, al, string(manifest))
+}
+
+func writeCalendars(fileName string, args *input.ArgumentCollection, al *airline.Airline, manifest string) {
+	// write the calendars of the roster "al.PilotsArray" to "fileName" (combined feed)
+	// or to a file per pilot
+	stamp := time.Now().UTC()
+	report := time.Duration(*args.ReportTime) * time.Minute
+	if *args.Calendar == "combined" {
+		events := []string{}
+		for _, pilot := range al.PilotsArray {
+			events = append(events, pilotEvents(pilot, al, report, stamp, true)...)
+		}
+		writeCalendar(fileName, "Roster", manifest, events)
+		return
+	}
+	name := strings.TrimSuffix(fileName, ".ics")
+	for _, pilot := range al.PilotsArray {
+		writeCalendar(fmt.Sprintf("%s_pilot%d.ics", name, pilot.Id), fmt.Sprintf("Roster of pilot %d", pilot.Id), manifest,
+			pilotEvents(pilot, al, report, stamp, false))
+	}
+}
+
+func pilotEvents(pilot *airline.Pilot, al *airline.Airline, report time.Duration, stamp time.Time, combined bool) []string {
+	// returns the events of the pairings and the days off of the pilot
+	// The unique id of a pairing's event depends only on the pairing (and of a day off on the
+	// pilot and the day), so importing an updated roster replaces the events instead of duplicating
+	// them, even when a pairing is reassigned to another pilot. The sequence increases with every
+	// export, so the calendar applications take the events as updates of the imported ones
+	sequence := fmt.Sprintf("SEQUENCE:%d", stamp.Unix()/60)
+	prefix := ""
+	if combined {
+		prefix = fmt.Sprintf("Pilot %d: ", pilot.Id)
+	}
+	events := []string{}
+	for _, pair := range pilot.AssignedPairs[1 : pilot.AssignedLength+1] {
+		route := []string{}
+		location := ""
+		description := []string{fmt.Sprintf("Report %s", pair.Start.Add(-report).Format("2006-01-02 15:04 MST"))}
+		for i, leg := range pair.Legs {
+			if i == 0 {
+				route = append(route, leg.Source)
+				location = leg.Source
+				description[0] += " at " + leg.Source
+			}
+			route = append(route, leg.Destination)
+			description = append(description, fmt.Sprintf("Flight %d %s-%s %s-%s", leg.Number, leg.Source, leg.Destination,
+				leg.Start.Format("15:04"), leg.End.Format("15:04")))
+		}
+		summary := fmt.Sprintf("%sPairing %d", prefix, pair.Id)
+		if len(route) > 0 {
+			summary += " " + strings.Join(route, "-")
+		}
+		event := []string{
+			"BEGIN:VEVENT",
+			fmt.Sprintf("UID:pairing%d-%s@%s", pair.Id, pair.Start.UTC().Format(icsDateTime), icsDomain),
+			"DTSTAMP:" + stamp.Format(icsDateTime),
+			"LAST-MODIFIED:" + stamp.Format(icsDateTime),
+			sequence,
+			"DTSTART:" + pair.Start.Add(-report).UTC().Format(icsDateTime),
+			"DTEND:" + pair.End.UTC().Format(icsDateTime),
+			"SUMMARY:" + escapeText(summary),
+			"DESCRIPTION:" + escapeText(strings.Join(description, "\n")),
+		}
+		if location != "" {
+			event = append(event, "LOCATION:"+escapeText(location))
+		}
+		events = append(events, append(event, "END:VEVENT")...)
+	}
+
+	// every day of the schedule without pairings is an all-day event
+	for day := 0; day < al.ScheduleDuration; day++ {
+		if pilot.Workday(day) {
+			continue
+		}
+		date := al.ScheduleStart.AddDate(0, 0, day)
+		events = append(events,
+			"BEGIN:VEVENT",
+			fmt.Sprintf("UID:pilot%d-off%s@%s", pilot.Id, date.Format(icsDate), icsDomain),
+			"DTSTAMP:"+stamp.Format(icsDateTime),
+			"LAST-MODIFIED:"+stamp.Format(icsDateTime),
+			sequence,
+			"DTSTART;VALUE=DATE:"+date.Format(icsDate),
+			"DTEND;VALUE=DATE:"+date.AddDate(0, 0, 1).Format(icsDate),
+			"SUMMARY:"+escapeText(prefix+"Day off"),
+			"TRANSP:TRANSPARENT",
+			"END:VEVENT")
+	}
+	return events
+}
+
+func writeCalendar(fileName string, name string, manifest string, events []string) {
+	// write a calendar with "events" to "fileName"
+	// (the manifest of the run is stored as json in a non-standard property)
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//" + icsDomain + "//Roster//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:" + escapeText(name),
+	}
+	if manifest != "" {
+		lines = append(lines, "X-ROSTER-MANIFEST:"+escapeText(manifest))
+	}
+	lines = append(lines, events...)
+	lines = append(lines, "END:VCALENDAR")
+
+	var builder strings.Builder
+	for _, line := range lines {
+		builder.WriteString(foldLine(line))
+		builder.WriteString("\r\n")
+	}
+	if err := os.WriteFile(fileName, []byte(builder.String()), 0644); err != nil {
+		fmt.Println(err)
+	}
+}
+
+func escapeText(text string) string {
+	// escape the special characters of an iCalendar text value
+	return strings.NewReplacer("\\", "\\\\", ";", "\\;", ",", "\\,", "\n", "\\n").Replace(text)
+}
+
+func foldLine(line string) string {
+	// split a content line in lines of at most 75 octets
+	// (the continuation lines start with a space), without splitting a character
+	folded := []string{}
+	for len(line) > 75 {
+		cut := 75
+		if len(folded) > 0 {
+			cut = 74
+		}
+		for !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		folded = append(folded, line[:cut])
+		line = line[cut:]
+	}
+	return strings.Join(append(folded, line), "\r\n ")
+}
@@ -0,0 +1,246 @@
+package results
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"os"
+	"time"
+
+	"go-airline-crew-rostering/airline"
+	"go-airline-crew-rostering/fitness"
+	"go-airline-crew-rostering/input"
+	"go-airline-crew-rostering/metrics"
+)
+
+// Writer of a json file with the roster, the metrics and the configuration of the run
+type JSONWriter struct{}
+
+// Results of a run, as stored in the json file
+type runRecord struct {
+	Configuration *configurationRecord `json:"configuration"`
+	Metrics       *metricsRecord       `json:"metrics"`
+	Roster        []*pilotRecord       `json:"roster"`
+	Manifest      *manifestRecord      `json:"manifest"` // configuration and provenance of the run
+}
+
+// Configuration of a run
+type configurationRecord struct {
+	Algorithm   string                 `json:"algorithm"`   // name of the optimization algorithm
+	Filename    string                 `json:"filename"`    // name of the file that contains the pairs
+	StartDate   time.Time              `json:"startDate"`   // start date of the schedule
+	EndDate     time.Time              `json:"endDate"`     // end date of the schedule
+	Pilots      int                    `json:"pilots"`      // number of available pilots
+	Seed        int64                  `json:"seed"`        // seed of the random number generator
+	Generations int                    `json:"generations"` // maximum number of generations (or iterations)
+	Agents      int                    `json:"agents"`      // number of agents of the algorithm
+	Parameters  map[string]interface{} `json:"parameters"`  // parameters of the algorithm
+	Objective   *fitness.Objective     `json:"objective"`   // weighted terms of the objective
+}
+
+// Metrics of a run
+type metricsRecord struct {
+	TotalTime         float64          `json:"totalTime"`                  // execution time (in seconds)
+	Cost              float64          `json:"cost"`                       // cost of the solution
+	BestCost          float64          `json:"bestCost"`                   // cost of the best solution found by the algorithm
+	ValidSolutions    int              `json:"validSolutions"`             // valid solutions found
+	TotalSolutions    int              `json:"totalSolutions"`             // solutions found (valid and invalid)
+	UniqueSolutions   int              `json:"uniqueSolutions"`            // different solutions found
+	AssignedPairs     int              `json:"assignedPairs"`              // pairings covered by the solution
+	UncoveredPairs    []int            `json:"uncoveredPairs"`             // ids of the pairings not covered by the solution
+	AverageRestPeriod float64          `json:"averageRestPeriod"`          // average rest period per pair of pairings (in hours)
+	AverageDaysOff    float64          `json:"averageDaysOff"`             // average days off per pilot per timespan
+	Jumps             int              `json:"jumps"`                      // times a new global best was found
+	AverageSimilarity float64          `json:"averageSimilarity"`          // average similarity between each solution and the global best
+	Generations       int              `json:"generations"`                // generations executed by the algorithm
+	StopReason        string           `json:"stopReason"`                 // reason for stopping the algorithm
+	IterBestCost      []float64        `json:"iterBestCost"`               // best cost of each generation
+	IterWorstCost     []float64        `json:"iterWorstCost"`              // worst cost of each generation
+	IterAverageCost   []float64        `json:"iterAverageCost"`            // average cost of each generation
+	LowerBound        float64          `json:"lowerBound,omitempty"`       // lower bound of the cost (column generation only)
+	IterLowerBound    []float64        `json:"iterLowerBound,omitempty"`   // lower bound of each iteration (column generation only)
+	Objective         []*termEntry     `json:"objective"`                  // value of each term of the objective
+	Fairness          []*fairnessEntry `json:"fairness"`                   // fairness indicators of each dimension of the solution
+	Pilots            []*pilotEntry    `json:"pilots"`                     // statistics of the schedule of each pilot
+	ParetoObjectives  [][]float64      `json:"paretoObjectives,omitempty"` // objective values of each non dominated solution (NSGA-II only)
+	Islands           []*islandEntry   `json:"islands,omitempty"`          // metrics of each island (island model only)
+}
+
+// Value of a term of the objective for the solution
+type termEntry struct {
+	Term  string  `json:"term"`
+	Value float64 `json:"value"`
+}
+
+// Metrics of an island of the island model
+type islandEntry struct {
+	Algorithm      string  `json:"algorithm"`
+	Seed           int64   `json:"seed"`
+	BestCost       float64 `json:"bestCost"`
+	ValidSolutions int     `json:"validSolutions"`
+	Jumps          int     `json:"jumps"`
+	Immigrants     int     `json:"immigrants"`
+}
+
+// Fairness indicators of a dimension of the solution
+type fairnessEntry struct {
+	Dimension         string  `json:"dimension"`
+	Gini              float64 `json:"gini"`
+	Spread            float64 `json:"spread"`
+	StandardDeviation float64 `json:"std"`
+}
+
+// Statistics of the schedule of a pilot
+type pilotEntry struct {
+	Pilot          int      `json:"pilot"`
+	FlightTime     float64  `json:"flightTime"`     // total flight time (in minutes)
+	Deviation      float64  `json:"deviation"`      // difference between the flight time and the average workload (in minutes)
+	Pairings       int      `json:"pairings"`       // number of assigned pairings
+	MinDaysOff     int      `json:"minDaysOff"`     // fewest days off in a timespan
+	AverageDaysOff float64  `json:"averageDaysOff"` // average days off in a timespan
+	ExcessRest     float64  `json:"excessRest"`     // total excess rest period (in minutes)
+	LongestStreak  int      `json:"longestStreak"`  // most consecutive days with duty
+	DaysOffSlack   int      `json:"daysOffSlack"`   // days off above the minimum in the tightest timespan
+	RestSlack      *float64 `json:"restSlack"`      // shortest rest above the minimum rest period (in minutes, null with fewer than two pairings)
+}
+
+// Pairings assigned to a pilot
+type pilotRecord struct {
+	Pilot      int              `json:"pilot"`
+	FlightTime float64          `json:"flightTime"` // total flight time (in minutes)
+	Pairings   []*pairingRecord `json:"pairings"`
+}
+
+// Pairing of the roster
+type pairingRecord struct {
+	Id    int       `json:"id"`
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	Legs  int       `json:"legs"` // number of flight legs
+}
+
+func newRunRecord(m *metrics.Metrics, args *input.ArgumentCollection, al *airline.Airline, objective *fitness.Objective) *runRecord {
+	// returns the results of the run, as stored by the json and the csv writers
+	record := &runRecord{}
+	record.Configuration = &configurationRecord{
+		Algorithm:   args.Algorithm,
+		Filename:    *args.Filename,
+		StartDate:   args.StartDate,
+		EndDate:     args.EndDate,
+		Pilots:      *args.Pilots,
+		Seed:        m.Seed,
+		Generations: *args.Generations,
+		Agents:      *args.Agents,
+		Parameters:  parameters(args),
+		Objective:   objective,
+	}
+
+	_, cost := objective.Evaluate(al.PilotsArray, al)
+	r := &metricsRecord{
+		TotalTime:         m.TotalTime.Seconds(),
+		Cost:              cost * objective.UnitCost,
+		BestCost:          m.GlobalBestSolutionCost,
+		ValidSolutions:    m.ValidSolutions,
+		TotalSolutions:    m.TotalSolutions,
+		UniqueSolutions:   m.UniqueCount,
+		AssignedPairs:     m.TotalAssignedPairs,
+		UncoveredPairs:    []int{},
+		AverageRestPeriod: m.AverageRestPeriod,
+		AverageDaysOff:    m.AverageDaysOff,
+		Jumps:             m.Jumps,
+		AverageSimilarity: m.AverageSimilarity,
+		Generations:       m.Generations,
+		StopReason:        m.StopReason,
+		IterBestCost:      m.IterBestCost,
+		IterWorstCost:     m.IterWorstCost,
+		IterAverageCost:   m.IterAverageCost,
+		LowerBound:        m.LowerBound,
+		IterLowerBound:    m.IterLowerBound,
+		Objective:         []*termEntry{},
+		Fairness:          []*fairnessEntry{},
+		Pilots:            []*pilotEntry{},
+		ParetoObjectives:  m.ParetoObjectives,
+	}
+	for _, uncovered := range m.Uncovered {
+		r.UncoveredPairs = append(r.UncoveredPairs, uncovered.Pair.Id)
+	}
+	for _, fairness := range m.Fairness {
+		r.Fairness = append(r.Fairness, &fairnessEntry{fairness.Dimension, fairness.Gini, fairness.Spread, fairness.StandardDeviation})
+	}
+	for _, p := range m.Pilots {
+		var restSlack *float64
+		if p.HasRest {
+			restSlack = &p.RestSlack
+		}
+		r.Pilots = append(r.Pilots, &pilotEntry{p.Pilot, p.FlightTime, p.Deviation, p.Pairings, p.MinDaysOff,
+			p.AverageDaysOff, p.ExcessRest, p.LongestStreak, p.DaysOffSlack, restSlack})
+	}
+	for _, island := range m.Islands {
+		r.Islands = append(r.Islands, &islandEntry{island.Algorithm, island.Mtr.Seed, island.Mtr.GlobalBestSolutionCost,
+			island.Mtr.ValidSolutions, island.Mtr.Jumps, island.Immigrants})
+	}
+	for _, term := range objective.Terms {
+		r.Objective = append(r.Objective, &termEntry{termName(term), objective.Value(term, al.PilotsArray, al)})
+	}
+	record.Metrics = r
+
+	record.Manifest = newManifest(m.Seed, args, al)
+
+	record.Roster = rosterRecords(al.PilotsArray)
+	return record
+}
+
+func rosterRecords(pilots []*airline.Pilot) []*pilotRecord {
+	// returns the pairings assigned to every pilot, as stored by the json and the csv writers
+	roster := []*pilotRecord{}
+	for _, pilot := range pilots {
+		p := &pilotRecord{Pilot: pilot.Id, FlightTime: pilot.FlightTime, Pairings: []*pairingRecord{}}
+		for _, pair := range pilot.AssignedPairs[1 : pilot.AssignedLength+1] {
+			p.Pairings = append(p.Pairings, &pairingRecord{pair.Id, pair.Start, pair.End, pair.FlightLegs})
+		}
+		roster = append(roster, p)
+	}
+	return roster
+}
+
+func parameters(args *input.ArgumentCollection) map[string]interface{} {
+	// returns the parameters of the run's optimization algorithm
+	switch args.Algorithm {
+	case "multiCSO":
+		return map[string]interface{}{"FL": *args.FL}
+	case "AOA":
+		return map[string]interface{}{"C1": args.Constants[0], "C2": args.Constants[1], "C3": args.Constants[2], "C4": args.Constants[3]}
+	case "columnGeneration":
+		return map[string]interface{}{"columns": *args.Columns, "penalty": *args.Penalty}
+	case "NSGA":
+		return map[string]interface{}{"mutation": *args.Mutation}
+	case "islands":
+		return map[string]interface{}{"islands": *args.Islands, "islandAlgorithm": *args.IslandType, "migrationInterval": *args.Migration,
+			"FL": *args.FL, "C1": args.Constants[0], "C2": args.Constants[1], "C3": args.Constants[2], "C4": args.Constants[3]}
+	}
+	return map[string]interface{}{}
+}
+
+func (writer *JSONWriter) Extension() string {
+	return "json"
+}
+
+func (writer *JSONWriter) Write(fileName string, m *metrics.Metrics, args *input.ArgumentCollection, al *airline.Airline, objective *fitness.Objective) {
+	// Create a json file with the configuration, the metrics and the roster of the run
+	writeJSON(fileName, newRunRecord(m, args, al, objective))
+}
+
+func writeJSON(fileName string, record interface{}) {
+	// Write "record" to a json file, which is created only if the record
+	// can be encoded (so a failure does not leave an empty file behind)
+	// Terminates the application on failure
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(record); err != nil {
+		log.Fatalf("cannot write %s: %v", fileName, err)
+	}
+	if err := os.WriteFile(fileName, buffer.Bytes(), 0666); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,204 @@
+package results
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"go-airline-crew-rostering/airline"
+	"go-airline-crew-rostering/input"
+	"go-airline-crew-rostering/metrics"
+)
+
+// Configuration and provenance of a run, stored by every output format
+// so that the results can be reproduced
+type manifestRecord struct {
+	Created      time.Time              `json:"created"`      // time the results were written
+	Arguments    map[string]interface{} `json:"arguments"`    // value of every argument of the run
+	Rules        []*ruleEntry           `json:"rules"`        // rules of the schedule (null if the command has no single schedule)
+	Inputs       []*inputEntry          `json:"inputs"`       // files read by the run
+	Seed         int64                  `json:"seed"`         // effective seed of the random number generator (0 if the command does not use it)
+	GoVersion    string                 `json:"goVersion"`    // version of Go that built the application
+	Revision     string                 `json:"revision"`     // VCS revision of the build
+	RevisionTime string                 `json:"revisionTime"` // time of the VCS revision
+	Modified     bool                   `json:"modified"`     // the build had uncommitted changes
+	Host         string                 `json:"host"`         // name of the machine that executed the run
+	Platform     string                 `json:"platform"`     // operating system and architecture
+	CPUs         int                    `json:"cpus"`         // logical CPUs of the machine
+}
+
+// Rule of the schedule
+type ruleEntry struct {
+	Name  string `json:"name"`
+	Value int    `json:"value"`
+}
+
+// File read by the run
+type inputEntry struct {
+	Argument string `json:"argument"` // argument that names the file
+	File     string `json:"file"`
+	SHA256   string `json:"sha256"` // empty if the file cannot be read
+}
+
+func newManifest(seed int64, args *input.ArgumentCollection, al *airline.Airline) *manifestRecord {
+	// returns the manifest of the run ("al" is nil for the commands
+	// that are not executed on a single schedule)
+	manifest := &manifestRecord{
+		Created:   time.Now(),
+		Arguments: arguments(args),
+		Inputs:    []*inputEntry{},
+		Seed:      seed,
+		GoVersion: runtime.Version(),
+		Revision:  "unknown",
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+		CPUs:      runtime.NumCPU(),
+	}
+	manifest.Host, _ = os.Hostname()
+	if al != nil {
+		manifest.Rules = scheduleRules(al)
+	}
+
+	// the revision is stamped by "go build" when the application is built inside the repository
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				manifest.Revision = setting.Value
+			case "vcs.time":
+				manifest.RevisionTime = setting.Value
+			case "vcs.modified":
+				manifest.Modified = setting.Value == "true"
+			}
+		}
+	}
+
+	type file struct {
+		argument string
+		file     *string
+	}
+	files := []file{
+		{"filename", args.Filename}, {"preferences", args.Preferences}, {"roster", args.Roster}, {"events", args.Events},
+		{"warmStart", args.WarmStart}, {"resume", args.Resume}, {"old", args.OldRoster}, {"new", args.NewRoster},
+		{"objective", args.Objective},
+	}
+	// the repeated arguments are numbered so that every file has its own key
+	if args.Experiments != nil {
+		for i := range *args.Experiments {
+			files = append(files, file{fmt.Sprintf("experiments[%d]", i), &(*args.Experiments)[i]})
+		}
+	}
+	// the instances of the other commands are made of the pairings file
+	if args.Algorithm == "tune" {
+		for i, instance := range args.Instances {
+			files = append(files, file{fmt.Sprintf("instance[%d]", i), &instance.Filename})
+		}
+	}
+	for _, f := range files {
+		if f.file == nil || *f.file == "" {
+			continue
+		}
+		manifest.Inputs = append(manifest.Inputs, &inputEntry{f.argument, *f.file, fileHash(*f.file)})
+	}
+	return manifest
+}
+
+func scheduleRules(al *airline.Airline) []*ruleEntry {
+	// returns the rules of the schedule of "al", in the order of the manifest
+	return []*ruleEntry{
+		{"restPeriod", al.RestPeriod},              // minimum rest period between two consecutive pairings (in minutes)
+		{"timespan", al.Timespan()},                // period (in days) that must contain the minimum days off
+		{"minimumDaysOff", al.MinimumDaysOff()},    // minimum days off in a timespan
+		{"earlyStartHour", metrics.EarlyStartHour}, // pairings that start before this hour are early starts
+	}
+}
+
+func arguments(args *input.ArgumentCollection) map[string]interface{} {
+	// returns the value of every field of the argument collection,
+	// named after the field in camel case
+	values := make(map[string]interface{})
+	collection := reflect.ValueOf(args).Elem()
+	for i := 0; i < collection.NumField(); i++ {
+		name := []rune(collection.Type().Field(i).Name)
+		name[0] = unicode.ToLower(name[0])
+		value := collection.Field(i)
+		if value.Kind() == reflect.Pointer {
+			if value.IsNil() {
+				values[string(name)] = nil
+				continue
+			}
+			value = value.Elem()
+		}
+		values[string(name)] = value.Interface()
+	}
+	return values
+}
+
+func fileHash(fileName string) string {
+	// returns the SHA-256 of the file in hexadecimal (empty if the file cannot be read)
+	file, err := os.Open(fileName)
+	if err != nil {
+		fmt.Println(err)
+		return ""
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		fmt.Println(err)
+		return ""
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func (manifest *manifestRecord) rows() [][2]string {
+	// returns the manifest as a list of settings and their values,
+	// for the formats that store it as a table
+	rows := [][2]string{
+		{"created", manifest.Created.Format(time.RFC3339)},
+		{"seed", strconv.FormatInt(manifest.Seed, 10)},
+		{"goVersion", manifest.GoVersion},
+		{"revision", manifest.Revision},
+		{"revisionTime", manifest.RevisionTime},
+		{"modified", strconv.FormatBool(manifest.Modified)},
+		{"host", manifest.Host},
+		{"platform", manifest.Platform},
+		{"cpus", strconv.Itoa(manifest.CPUs)},
+	}
+	for _, rule := range manifest.Rules {
+		rows = append(rows, [2]string{"rules." + rule.Name, strconv.Itoa(rule.Value)})
+	}
+	for _, in := range manifest.Inputs {
+		rows = append(rows, [2]string{"inputs." + in.Argument, in.File}, [2]string{"inputs." + in.Argument + ".sha256", in.SHA256})
+	}
+
+	names := []string{}
+	for name := range manifest.Arguments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		// the values are written as json, without the quotes of the strings
+		value, _ := json.Marshal(manifest.Arguments[name])
+		rows = append(rows, [2]string{"arguments." + name, strings.Trim(string(value), `"`)})
+	}
+	return rows
+}
+
+func writeManifestCSV(fileName string, manifest *manifestRecord) {
+	// write the manifest to a csv file (one row per setting)
+	records := [][]string{{"setting", "value"}}
+	for _, row := range manifest.rows() {
+		records = append(records, []string{row[0], row[1]})
+	}
+	writeCSV(fileName, records)
+}
@@ -0,0 +1,227 @@
+package results
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-airline-crew-rostering/airline"
+	"go-airline-crew-rostering/disruption"
+	"go-airline-crew-rostering/fitness"
+	"go-airline-crew-rostering/input"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func (writer *XLSXWriter) WriteReplan(fileName string, d *disruption.Disruption, args *input.ArgumentCollection, al *airline.Airline, objective *fitness.Objective) {
+	// Creates an excel file to store a repaired airline crew rostering schedule,
+	// along with the changes of each pilot's assignments
+	f := excelize.NewFile()
+	defer func() {
+		if err := f.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	docProperties, _ := f.GetDocProps()
+	docProperties.Language = "en-UK"
+	f.SetDocProps(docProperties)
+
+	f.SetDefaultFont("Arial")
+
+	changesSheetName := "Changes"
+	scheduleSheetName := "Schedule"
+	manifestSheetName := "Manifest"
+
+	f.SetSheetName("Sheet1", changesSheetName)
+	if _, err := f.NewSheet(scheduleSheetName); err != nil {
+		fmt.Println(err)
+		return
+	}
+	if _, err := f.NewSheet(manifestSheetName); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	drawChangesSheet(f, changesSheetName, d, args, al, objective)
+	drawScheduleSheet(f, scheduleSheetName, al, al.PilotsArray, *args.Colouring)
+	drawManifestSheet(f, newManifest(0, args, al))
+
+	if err := f.SaveAs(fileName); err != nil {
+		fmt.Println(err)
+	}
+}
+
+// Repair of a roster, as stored in the json file
+type replanRecord struct {
+	Published string           `json:"published"` // name of the file of the published roster
+	Events    string           `json:"events"`    // name of the file of the disruption events
+	Cutoff    time.Time        `json:"cutoff"`    // assignments of pairings that start before the cutoff are frozen
+	Cost      float64          `json:"cost"`      // cost of the repaired roster
+	Changes   []*changeRecord  `json:"changes"`   // changed assignments, sorted by pilot and start of the pairing
+	Uncovered []*pairingRecord `json:"uncovered"` // pairings left without a pilot
+	Roster    []*pilotRecord   `json:"roster"`    // repaired roster
+	Manifest  *manifestRecord  `json:"manifest"`  // configuration and provenance of the repair
+}
+
+// Changed assignment of the roster
+type changeRecord struct {
+	Pilot   int            `json:"pilot"`
+	Pairing *pairingRecord `json:"pairing"`
+	Action  string         `json:"action"` // "removed" or "added"
+	Reason  string         `json:"reason"`
+}
+
+func newReplanRecord(d *disruption.Disruption, args *input.ArgumentCollection, al *airline.Airline, objective *fitness.Objective) *replanRecord {
+	// returns the repair of the roster, as stored by the json and the csv writers
+	_, cost := objective.Evaluate(al.PilotsArray, al)
+	record := &replanRecord{
+		Published: *args.Roster,
+		Events:    *args.Events,
+		Cutoff:    d.Cutoff,
+		Cost:      cost * objective.UnitCost,
+		Changes:   []*changeRecord{},
+		Uncovered: []*pairingRecord{},
+		Roster:    rosterRecords(al.PilotsArray),
+		Manifest:  newManifest(0, args, al),
+	}
+	for _, change := range d.Changes {
+		pair := change.Pair
+		record.Changes = append(record.Changes, &changeRecord{change.Pilot, &pairingRecord{pair.Id, pair.Start, pair.End, pair.FlightLegs}, change.Action, change.Reason})
+	}
+	for _, pair := range d.Uncovered {
+		record.Uncovered = append(record.Uncovered, &pairingRecord{pair.Id, pair.Start, pair.End, pair.FlightLegs})
+	}
+	return record
+}
+
+func (writer *JSONWriter) WriteReplan(fileName string, d *disruption.Disruption, args *input.ArgumentCollection, al *airline.Airline, objective *fitness.Objective) {
+	// Create a json file with the repaired roster and its changes
+	// (the roster can be read back like the roster of a run)
+	writeJSON(fileName, newReplanRecord(d, args, al, objective))
+}
+
+func (writer *CSVWriter) WriteReplan(fileName string, d *disruption.Disruption, args *input.ArgumentCollection, al *airline.Airline, objective *fitness.Objective) {
+	// Create the csv files of the repair: the repaired roster is stored in "fileName"
+	// and the changes, the uncovered pairings and the manifest in files with the same name and a suffix
+	record := newReplanRecord(d, args, al, objective)
+	name := strings.TrimSuffix(fileName, ".csv")
+	writeCSV(fileName, rosterRows(record.Roster))
+
+	changes := [][]string{{"pilot", "action", "pairing", "start", "end", "reason"}}
+	for _, change := range record.Changes {
+		changes = append(changes, []string{strconv.Itoa(change.Pilot), change.Action, strconv.Itoa(change.Pairing.Id),
+			change.Pairing.Start.Format("2006-01-02 15:04"), change.Pairing.End.Format("2006-01-02 15:04"), change.Reason})
+	}
+	writeCSV(name+"_changes.csv", changes)
+
+	uncovered := [][]string{{"pairing", "start", "end", "legs"}}
+	for _, pair := range record.Uncovered {
+		uncovered = append(uncovered, []string{strconv.Itoa(pair.Id), pair.Start.Format("2006-01-02 15:04"),
+			pair.End.Format("2006-01-02 15:04"), strconv.Itoa(pair.Legs)})
+	}
+	writeCSV(name+"_uncovered.csv", uncovered)
+	writeManifestCSV(name+"_manifest.csv", record.Manifest)
+}
+
+func (writer *ICSWriter) WriteReplan(fileName string, d *disruption.Disruption, args *input.ArgumentCollection, al *airline.Airline, objective *fitness.Objective) {
+	// Create the calendars of the repaired roster, which replace
+	// the events of the published roster when they are imported
+	manifest, err := json.Marshal(newManifest(0, args, al))
+	if err != nil {
+		fmt.Println(err)
+	}
+	writeCalendars(fileName, args, al, string(manifest))
+}
+
+func drawChangesSheet(f *excelize.File, sheetName string, d *disruption.Disruption, args *input.ArgumentCollection, al *airline.Airline, objective *fitness.Objective) {
+	// create an excel sheet containing a summary of the repair,
+	// the changed assignments of each pilot and the uncovered pairings
+	setView(f, sheetName, 100.0)
+
+	f.SetColWidth(sheetName, "H", "K", 20)
+	f.SetColWidth(sheetName, "L", "L", 30)
+	for i := 5; i <= 10; i++ {
+		f.SetRowHeight(sheetName, i, 30)
+	}
+
+	pilots := make(map[int]bool)
+	for _, change := range d.Changes {
+		pilots[change.Pilot] = true
+	}
+	_, cost := objective.Evaluate(al.PilotsArray, al)
+
+	f.SetCellValue(sheetName, "B2", "Roster Repair")
+	f.SetCellValue(sheetName, "B5", "Published Roster")
+	f.SetCellValue(sheetName, "B6", "Cutoff")
+	f.SetCellValue(sheetName, "B7", "Changed Assignments")
+	f.SetCellValue(sheetName, "B8", "Pilots Affected")
+	f.SetCellValue(sheetName, "B9", "Uncovered Pairings")
+	f.SetCellValue(sheetName, "B10", "Solution Cost")
+	f.SetCellValue(sheetName, "D5", *args.Roster)
+	f.SetCellValue(sheetName, "D6", d.Cutoff.Format("02/01/2006 15:04"))
+	f.SetCellValue(sheetName, "D7", len(d.Changes))
+	f.SetCellValue(sheetName, "D8", len(pilots))
+	f.SetCellValue(sheetName, "D9", len(d.Uncovered))
+	f.SetCellValue(sheetName, "D10", math.Round(cost*objective.UnitCost))
+	drawVerticalTable(f, sheetName, "B2", 6, "7266A4", "E5E0EC")
+
+	headerStyleId, _ := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Size: 12, Color: "FFFFFF", Bold: true},
+		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"7266A4"}},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center"},
+	})
+	cellStyleId, _ := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Size: 12},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center"},
+	})
+	enable := true
+
+	// changed assignments of each pilot
+	f.SetCellValue(sheetName, "G2", "Pilot")
+	f.SetCellValue(sheetName, "H2", "Action")
+	f.SetCellValue(sheetName, "I2", "Pairing")
+	f.SetCellValue(sheetName, "J2", "Departure")
+	f.SetCellValue(sheetName, "K2", "Arrival")
+	f.SetCellValue(sheetName, "L2", "Reason")
+	f.SetCellStyle(sheetName, "G2", "L2", headerStyleId)
+	for i, change := range d.Changes {
+		row := 3 + i
+		f.SetCellValue(sheetName, fmt.Sprintf("G%d", row), change.Pilot)
+		f.SetCellValue(sheetName, fmt.Sprintf("H%d", row), change.Action)
+		f.SetCellValue(sheetName, fmt.Sprintf("I%d", row), fmt.Sprintf("%04d", change.Pair.Id))
+		f.SetCellValue(sheetName, fmt.Sprintf("J%d", row), change.Pair.Start.Format("02/01/2006 15:04"))
+		f.SetCellValue(sheetName, fmt.Sprintf("K%d", row), change.Pair.End.Format("02/01/2006 15:04"))
+		f.SetCellValue(sheetName, fmt.Sprintf("L%d", row), change.Reason)
+		f.SetCellStyle(sheetName, fmt.Sprintf("G%d", row), fmt.Sprintf("L%d", row), cellStyleId)
+	}
+	f.AddTable(sheetName, &excelize.Table{
+		Range:          fmt.Sprintf("G2:L%d", 3+int(math.Max(float64(len(d.Changes))-1, 0))),
+		Name:           "Changes",
+		StyleName:      "TableStyleMedium12",
+		ShowRowStripes: &enable,
+	})
+
+	// pairings left without a pilot
+	f.SetCellValue(sheetName, "N2", "Uncovered")
+	f.SetCellValue(sheetName, "O2", "Departure")
+	f.SetCellValue(sheetName, "P2", "Arrival")
+	f.SetColWidth(sheetName, "N", "P", 20)
+	f.SetCellStyle(sheetName, "N2", "P2", headerStyleId)
+	for i, pair := range d.Uncovered {
+		row := 3 + i
+		f.SetCellValue(sheetName, fmt.Sprintf("N%d", row), fmt.Sprintf("%04d", pair.Id))
+		f.SetCellValue(sheetName, fmt.Sprintf("O%d", row), pair.Start.Format("02/01/2006 15:04"))
+		f.SetCellValue(sheetName, fmt.Sprintf("P%d", row), pair.End.Format("02/01/2006 15:04"))
+		f.SetCellStyle(sheetName, fmt.Sprintf("N%d", row), fmt.Sprintf("P%d", row), cellStyleId)
+	}
+	f.AddTable(sheetName, &excelize.Table{
+		Range:          fmt.Sprintf("N2:P%d", 3+int(math.Max(float64(len(d.Uncovered))-1, 0))),
+		Name:           "Uncovered",
+		StyleName:      "TableStyleMedium12",
+		ShowRowStripes: &enable,
+	})
+}
@@ -1,561 +1,1003 @@
-package results
-
-import (
-	"fmt"
-	"math"
-	"os"
-	"strconv"
-	"strings"
-	"time"
-
-	"go-airline-crew-rostering/airline"
-	"go-airline-crew-rostering/input"
-	"go-airline-crew-rostering/metrics"
-
-	"github.com/xuri/excelize/v2"
-)
-
-func PrintResults(m *metrics.Metrics, args *input.ArgumentCollection, al *airline.Airline) {
-	// Creates an excel file to store an airline crew rostering schedule, along with various
-	// statistics
-	f := excelize.NewFile()
-	defer func() {
-		if err := f.Close(); err != nil {
-			fmt.Println(err)
-		}
-	}()
-
-	docProperties, _ := f.GetDocProps()
-	docProperties.Language = "en-UK"
-	f.SetDocProps(docProperties)
-
-	f.SetDefaultFont("Arial")
-
-	generalSheetName := "General Information"
-	solutionSheetName := "Solution Statistics"
-	algorithmSheetName := "Optimization Algorithm"
-	scheduleSheetName := "Schedule"
-	pairingsSheetName := "Pairings"
-
-	f.SetSheetName("Sheet1", generalSheetName)
-
-	if _, err := f.NewSheet(solutionSheetName); err != nil {
-		fmt.Println(err)
-		return
-	}
-	if _, err := f.NewSheet(algorithmSheetName); err != nil {
-		fmt.Println(err)
-		return
-	}
-	if _, err := f.NewSheet(scheduleSheetName); err != nil {
-		fmt.Println(err)
-		return
-	}
-	if _, err := f.NewSheet(pairingsSheetName); err != nil {
-		fmt.Println(err)
-		return
-	}
-
-	drawGeneralSheet(f, m, args, al)
-	drawSolutionStatisticsSheet(f, m, args, al)
-	drawOptimizationAlgorithmSheet(f, m, args, al)
-	drawScheduleSheet(f, al)
-	drawPairingsSheet(f, m, args, al)
-
-	index, _ := f.GetSheetIndex(scheduleSheetName)
-	f.SetActiveSheet(index)
-
-	if err := f.SaveAs(*args.ResultsFile); err != nil {
-		fmt.Println(err)
-	}
-
-}
-
-func setView(f *excelize.File, sheetName string, zoom float64) {
-	// set view options for an excel sheet
-	options, err := f.GetSheetView(sheetName, 0)
-	if err != nil {
-		fmt.Println(err)
-	}
-	*options.ShowGridLines = false
-	*options.ShowRowColHeaders = false
-	*options.ZoomScale = zoom
-	f.SetSheetView(sheetName, 0, &options)
-}
-
-func drawGeneralSheet(f *excelize.File, m *metrics.Metrics, args *input.ArgumentCollection, al *airline.Airline) {
-	// create an excel sheet containing general information of the application
-	sheetName := "General Information"
-	algorithmName := ""
-	if args.Algorithm == "multiCSO" {
-		algorithmName = "Multi-step CSO"
-	} else if args.Algorithm == "AOA" {
-		algorithmName = "Archimedes Optimization"
-	}
-
-	setView(f, sheetName, 100.0)
-
-	f.SetColWidth(sheetName, "G", "J", 11.62)
-	f.SetColWidth(sheetName, "L", "O", 11.62)
-	for i := 5; i <= 11; i++ {
-		f.SetRowHeight(sheetName, i, 30)
-	}
-
-	f.SetCellValue(sheetName, "B2", "General Information")
-	f.SetCellValue(sheetName, "B5", "Input File")
-	f.SetCellValue(sheetName, "B6", "Algorithm")
-	f.SetCellValue(sheetName, "B7", "Execution Time")
-	f.SetCellValue(sheetName, "D5", *args.Filename)
-	f.SetCellValue(sheetName, "D6", algorithmName)
-
-	executionstring := m.TotalTime.String()
-
-	i := strings.Index(executionstring, "m")
-	if i > -1 && i < len(executionstring) && executionstring[i+1:i+2] != "s" {
-		executionstring = executionstring[:i] + " min. " + executionstring[i+1:]
-	}
-	i = strings.Index(executionstring, "s")
-	if i > -1 && executionstring[i-1:i] != "m" {
-		executionstring = executionstring[:i] + " sec." + executionstring[i+1:]
-	}
-	f.SetCellValue("General Information", "D7", executionstring)
-
-	drawVerticalTable(f, sheetName, "B2", 3, "4F81BD", "B8CCE4")
-
-	f.SetCellValue(sheetName, "G2", "Airline Crew Rostering Information")
-	f.SetCellValue(sheetName, "G5", "Start Date")
-	f.SetCellValue(sheetName, "G6", "End Date")
-	f.SetCellValue(sheetName, "G7", "Pilots")
-	f.SetCellValue(sheetName, "G8", "Pairs")
-	f.SetCellValue(sheetName, "G9", "Optimal Workload")
-
-	startdatestring := strconv.Itoa(args.StartDate.Day()) + " " + args.StartDate.Month().String() + " " + strconv.Itoa(args.StartDate.Year())
-	enddatestring := strconv.Itoa(args.EndDate.Day()) + " " + args.EndDate.Month().String() + " " + strconv.Itoa(args.EndDate.Year())
-
-	f.SetCellValue(sheetName, "I5", startdatestring)
-	f.SetCellValue(sheetName, "I6", enddatestring)
-	f.SetCellValue(sheetName, "I7", *args.Pilots)
-	f.SetCellValue(sheetName, "I8", len(al.PairsArray)-1)
-	f.SetCellValue(sheetName, "I9", math.Round(al.AverageWorkload))
-
-	drawVerticalTable(f, sheetName, "G2", 5, "C0504D", "E6B9B8")
-
-	f.SetCellValue(sheetName, "L2", "Optimization Algorithm Information")
-	f.SetCellValue(sheetName, "L5", "Agents")
-	f.SetCellValue(sheetName, "L6", "Generations")
-	f.SetCellValue(sheetName, "L7", "Seed")
-	f.SetCellValue(sheetName, "N5", *args.Agents)
-	f.SetCellValue(sheetName, "N6", *args.Generations)
-
-	var rows int
-	if args.Algorithm == "multiCSO" {
-		f.SetCellValue(sheetName, "L8", "FL")
-		f.SetCellValue(sheetName, "N8", *args.FL)
-		rows = 4
-	} else if args.Algorithm == "AOA" {
-		f.SetCellValue(sheetName, "L8", "C1")
-		f.SetCellValue(sheetName, "L9", "C2")
-		f.SetCellValue(sheetName, "L10", "C3")
-		f.SetCellValue(sheetName, "L11", "C4")
-		f.SetCellValue(sheetName, "N8", args.Constants[0])
-		f.SetCellValue(sheetName, "N9", args.Constants[1])
-		f.SetCellValue(sheetName, "N10", args.Constants[2])
-		f.SetCellValue(sheetName, "N11", args.Constants[3])
-		rows = 7
-	}
-
-	drawVerticalTable(f, sheetName, "L2", rows, "4CB7C8", "B6E9CE")
-	if *args.Seed > -1 {
-		styleId, _ := f.NewStyle(&excelize.Style{
-			Font:      &excelize.Font{Size: 12},
-			Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center", WrapText: true},
-			Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"B6E9CE"}},
-			Border:    []excelize.Border{{Type: "top", Color: "FFFFFF", Style: 1}, {Type: "left", Color: "FFFFFF", Style: 1}, {Type: "bottom", Color: "FFFFFF", Style: 1}},
-			NumFmt:    49,
-		})
-		f.SetCellStyle(sheetName, "N7", "O7", styleId)
-		f.SetCellValue(sheetName, "N7", strconv.Itoa(*args.Seed))
-	} else {
-		f.SetCellValue(sheetName, "N7", "None")
-	}
-}
-
-func drawSolutionStatisticsSheet(f *excelize.File, m *metrics.Metrics, args *input.ArgumentCollection, al *airline.Airline) {
-	// create an excel sheet containing statistics related to the solution
-	sheetName := "Solution Statistics"
-
-	setView(f, sheetName, 100.0)
-
-	tableStyleTitle := excelize.Style{
-		Font:      &excelize.Font{Size: 16, Color: "FFFFFF", Bold: true, Underline: "single"},
-		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"4CB3C8"}},
-		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center"},
-		Border:    []excelize.Border{{Type: "bottom", Color: "FFFFFF", Style: 5}},
-	}
-	tableStyleCellsHeader := excelize.Style{
-		Font:      &excelize.Font{Size: 12, Color: "FFFFFF", Bold: true},
-		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center", WrapText: true},
-		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"4CB3C8"}},
-		Border: []excelize.Border{{Type: "top", Color: "FFFFFF", Style: 5}, {Type: "right", Color: "FFFFFF", Style: 1},
-			{Type: "bottom", Color: "FFFFFF", Style: 5}, {Type: "left", Color: "FFFFFF", Style: 1}},
-	}
-	tableStyleCellsData := excelize.Style{
-		Font:      &excelize.Font{Size: 12},
-		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center", WrapText: true},
-		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"B6DDE8"}},
-		Border: []excelize.Border{{Type: "top", Color: "FFFFFF", Style: 1}, {Type: "left", Color: "FFFFFF", Style: 1},
-			{Type: "bottom", Color: "FFFFFF", Style: 1}, {Type: "left", Color: "FFFFFF", Style: 1}},
-	}
-
-	f.SetColWidth(sheetName, "B", "E", 11.62)
-	f.SetColWidth(sheetName, "G", "J", 22.62)
-
-	f.SetCellValue(sheetName, "B2", "Solution Information")
-	f.SetCellValue(sheetName, "B5", "Assigned Pairs")
-	f.SetCellValue(sheetName, "B6", "Unassigned Pairs")
-	f.SetCellValue(sheetName, "B7", "Average Rest Period")
-	f.SetCellValue(sheetName, "B8", "Average Days Off")
-	f.SetCellValue(sheetName, "B9", "Best Cost")
-	f.SetCellValue(sheetName, "B10", "Worst Cost")
-	f.SetCellValue(sheetName, "B11", "Total Deviation")
-
-	f.SetCellValue(sheetName, "D5", m.TotalAssignedPairs)
-	f.SetCellValue(sheetName, "D6", len(al.PairsArray)-1-m.TotalAssignedPairs)
-	f.SetCellValue(sheetName, "D7", math.Round(m.AverageRestPeriod*100)/100)
-	f.SetCellValue(sheetName, "D8", math.Round(m.AverageDaysOff*100)/100)
-	f.SetCellValue(sheetName, "D9", math.Round(m.GlobalBestSolutionCost))
-
-	worstCost := m.IterWorstCost[0]
-	for i := range m.IterWorstCost {
-		if worstCost < m.IterWorstCost[i] {
-			worstCost = m.IterWorstCost[i]
-		}
-	}
-	f.SetCellValue(sheetName, "D10", math.Round(worstCost))
-	f.SetCellValue(sheetName, "D11", math.Round(m.GlobalBestSolutionCost/m.UnitCost))
-
-	drawVerticalTable(f, sheetName, "B2", 7, "7666A4", "CCC0DA")
-
-	f.SetCellValue(sheetName, "G2", "Pilot Statistics")
-	f.SetCellValue(sheetName, "G5", "Pilot")
-	f.SetCellValue(sheetName, "H5", "Flight time\n (in minutes)")
-	f.SetCellValue(sheetName, "I5", "Deviation from\n optimal workload")
-	f.SetCellValue(sheetName, "J5", "Assigned Pairs")
-
-	styleId, _ := f.NewStyle(&tableStyleTitle)
-	f.SetCellStyle(sheetName, "G2", "J4", styleId)
-	styleId, _ = f.NewStyle(&tableStyleCellsHeader)
-	f.SetCellStyle(sheetName, "G5", "J5", styleId)
-	f.MergeCell(sheetName, "G2", "J4")
-
-	styleId, _ = f.NewStyle(&tableStyleCellsData)
-	fillColor := &tableStyleCellsData.Fill.Color[0]
-	*fillColor = "DBEEF3"
-	styleId2, _ := f.NewStyle(&tableStyleCellsData)
-
-	f.SetRowHeight(sheetName, 5, 40.0)
-	for i, pilot := range al.PilotsArray {
-		f.SetRowHeight(sheetName, 6+i, 40.0)
-		cell, _ := excelize.CoordinatesToCellName(7, 6+i)
-		f.SetCellValue(sheetName, cell, pilot.Id)
-		cell, _ = excelize.CoordinatesToCellName(8, 6+i)
-		f.SetCellValue(sheetName, cell, math.Round(pilot.FlightTime))
-		cell, _ = excelize.CoordinatesToCellName(9, 6+i)
-		f.SetCellValue(sheetName, cell, math.Round(math.Abs(al.AverageWorkload-pilot.FlightTime)))
-		cell, _ = excelize.CoordinatesToCellName(10, 6+i)
-		f.SetCellValue(sheetName, cell, pilot.AssignedLength)
-		start, _ := excelize.CoordinatesToCellName(7, 6+i)
-		end, _ := excelize.CoordinatesToCellName(10, 6+i)
-		if i%2 == 0 {
-			f.SetCellStyle(sheetName, start, end, styleId)
-		} else {
-			f.SetCellStyle(sheetName, start, end, styleId2)
-		}
-	}
-
-	start, _ := excelize.CoordinatesToCellName(7, 5)
-	end, _ := excelize.CoordinatesToCellName(10, 5+(*args.Pilots))
-	enable := true
-	f.AddTable(sheetName, &excelize.Table{
-		Range:             start + ":" + end,
-		Name:              "PilotStatistics",
-		StyleName:         "TableStyleMedium20",
-		ShowFirstColumn:   false,
-		ShowLastColumn:    false,
-		ShowRowStripes:    &enable,
-		ShowColumnStripes: false,
-	})
-
-}
-
-func drawOptimizationAlgorithmSheet(f *excelize.File, m *metrics.Metrics, args *input.ArgumentCollection, al *airline.Airline) {
-	// create an excel sheet containing statistics related to the optimization algorithm
-	sheetName := "Optimization Algorithm"
-
-	setView(f, sheetName, 100.0)
-
-	f.SetColWidth(sheetName, "B", "E", 11.62)
-	for i := 5; i <= 10; i++ {
-		f.SetRowHeight(sheetName, i, 30.0)
-	}
-
-	f.SetCellValue(sheetName, "B2", "Optimization Algorithm Statistics")
-	f.SetCellValue(sheetName, "B5", "Valid Solutions")
-	f.SetCellValue(sheetName, "B6", "Invalid Solutions")
-	f.SetCellValue(sheetName, "B7", "Total Solutions")
-	f.SetCellValue(sheetName, "B8", "Unique Solutions")
-	f.SetCellValue(sheetName, "B9", "Jumps")
-	f.SetCellValue(sheetName, "B10", "Similarity")
-	f.SetCellValue(sheetName, "D5", m.ValidSolutions)
-
-	f.SetCellValue(sheetName, "D6", m.TotalSolutions-m.ValidSolutions)
-	f.SetCellValue(sheetName, "D7", m.TotalSolutions)
-	f.SetCellValue(sheetName, "D8", m.UniqueCount)
-	f.SetCellValue(sheetName, "D9", m.Jumps)
-	f.SetCellValue(sheetName, "D10", math.Round(m.AverageSimilarity*100)/10000)
-
-	drawVerticalTable(f, sheetName, "B2", 6, "4F81BD", "B8CCE4")
-
-	styleId, _ := f.NewStyle(&excelize.Style{Font: &excelize.Font{Size: 12},
-		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center", WrapText: true},
-		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"B8CCE4"}},
-		Border:    []excelize.Border{{Type: "top", Color: "FFFFFF", Style: 1}, {Type: "left", Color: "FFFFFF", Style: 1}},
-		NumFmt:    10,
-	})
-	f.SetCellStyle(sheetName, "D10", "E10", styleId)
-	outputFile := strings.Split(*args.ResultsFile, ".")
-	plotFilename := outputFile[0] + "_iterations.png"
-	drawIterationMetricsPlot(plotFilename, m, args)
-	if err := f.AddPicture(sheetName, "H2", plotFilename, &excelize.GraphicOptions{AltText: "Iterations' Comparison"}); err != nil {
-		fmt.Println(err)
-	}
-	os.Remove(plotFilename)
-}
-
-func drawScheduleSheet(f *excelize.File, al *airline.Airline) {
-	// create an excel sheet containing the airline crew rostering schedule
-	sheetName := "Schedule"
-
-	f.SetRowHeight(sheetName, 1, 7)
-	f.SetColWidth(sheetName, "A", "A", 1)
-	f.SetColWidth(sheetName, "B", "C", 7.65)
-
-	setView(f, sheetName, 82.0)
-
-	scheduleStartDate := al.ScheduleStart
-	scheduleEndDate := al.ScheduleEnd
-	if len(al.PairsArray) > 1 {
-		firstPair := al.PairsArray[1].Start
-		scheduleStartDate = time.Date(firstPair.Year(), firstPair.Month(), 1, 0, 0, 0, 0, time.UTC)
-		lastPair := al.PairsArray[len(al.PairsArray)-1].End
-		for _, pair := range al.PairsArray {
-			if pair.End.After(lastPair) {
-				lastPair = pair.End
-			}
-		}
-		days := daysInMonth(lastPair.Year(), lastPair.Month().String())
-		scheduleEndDate = time.Date(lastPair.Year(), lastPair.Month(), days, 0, 0, 0, 0, time.UTC)
-	}
-
-	monthsIndex := make(map[string]int)
-	monthsInSchedule := []*dateInfo{}
-	for year := scheduleStartDate.Year(); year <= scheduleEndDate.Year(); year++ {
-		month := 1
-		if year == scheduleStartDate.Year() {
-			month = int(scheduleStartDate.Month())
-		}
-		for ; month <= 12; month++ {
-			dateString := time.Month(month).String() + " " + strconv.Itoa(year)
-			monthInfo := &dateInfo{year: year, month: month, dateString: dateString, startCell: ""}
-			monthsInSchedule = append(monthsInSchedule, monthInfo)
-			monthsIndex[monthInfo.dateString] = len(monthsInSchedule) - 1
-			if year == scheduleEndDate.Year() && month == int(scheduleEndDate.Month()) {
-				break
-			}
-		}
-	}
-	startCell := "B2"
-	for i := 0; i < len(monthsInSchedule); i += 2 {
-		j := i + 2
-		if i == len(monthsInSchedule)-1 {
-			j = i + 1
-		}
-		col, row, _ := excelize.CellNameToCoordinates(startCell)
-		drawScheduleHeader(f, sheetName, startCell)
-		cell, _ := excelize.CoordinatesToCellName(col, row+2)
-		drawPilotColumn(f, sheetName, cell, al.NumberOfPilots)
-		cell, _ = excelize.CoordinatesToCellName(col+2, row)
-		drawMonths(f, sheetName, cell, monthsInSchedule[i:j])
-		drawDataArea(f, sheetName, monthsInSchedule[i:j], al.NumberOfPilots)
-		startCell, _ = excelize.CoordinatesToCellName(col, row+al.NumberOfPilots+3)
-	}
-
-	for _, pilot := range al.PilotsArray {
-		for i := 1; i <= pilot.AssignedLength; i++ {
-			pair := pilot.AssignedPairs[i]
-			dateString := pair.Start.Month().String() + " " + strconv.Itoa(pair.Start.Year())
-			monthInfo := monthsInSchedule[monthsIndex[dateString]]
-
-			pairStartCell := findCell(monthInfo.startCell, pair.Start, pilot.Id)
-
-			f.SetCellValue(sheetName, pairStartCell, "F")
-
-			if pair.Start.Month() == pair.End.Month() {
-				pairEndCell := findCell(monthInfo.startCell, pair.End, pilot.Id)
-				drawDashes(f, sheetName, pairStartCell, pairEndCell)
-			} else if pair.Start.Month() != pair.End.Month() {
-				days := daysInMonth(pair.Start.Year(), pair.Start.Month().String())
-				endOfStartMonth := time.Date(pair.Start.Year(), pair.Start.Month(), days, 0, 0, 0, 0, time.UTC)
-				cell := findCell(monthInfo.startCell, endOfStartMonth, pilot.Id)
-				drawDashes(f, sheetName, pairStartCell, cell)
-
-				startOfEndMonth := time.Date(pair.End.Year(), pair.End.Month(), 1, 0, 0, 0, 0, time.UTC)
-				dateString = pair.End.Month().String() + " " + strconv.Itoa(pair.End.Year())
-				monthInfo = monthsInSchedule[monthsIndex[dateString]]
-				pairEndCell := findCell(monthInfo.startCell, pair.End, pilot.Id)
-				cell = findCell(monthInfo.startCell, startOfEndMonth, pilot.Id)
-				col, row, _ := excelize.CellNameToCoordinates(cell)
-				cell, _ = excelize.CoordinatesToCellName(col-1, row)
-				drawDashes(f, sheetName, cell, pairEndCell)
-
-			}
-
-			dateString = fmt.Sprintf("Departure: %02d/%02d/%d %02d:%02d\nArrival: %02d/%02d/%d %02d:%02d",
-				pair.Start.Day(), pair.Start.Month(), pair.Start.Year(), pair.Start.Hour(), pair.Start.Minute(),
-				pair.End.Day(), pair.End.Month(), pair.End.Year(), pair.End.Hour(), pair.End.Minute())
-			titleString := fmt.Sprintf("Pair %04d", pair.Id)
-			dataValidation := excelize.NewDataValidation(true)
-			dataValidation.Sqref = pairStartCell + ":" + pairStartCell
-			dataValidation.SetInput(titleString, dateString)
-			f.AddDataValidation(sheetName, dataValidation)
-		}
-	}
-
-}
-
-func drawPairingsSheet(f *excelize.File, m *metrics.Metrics, args *input.ArgumentCollection, al *airline.Airline) {
-	// create an excel sheet containing all the pairings along with their start and end datetimes
-	sheetName := "Pairings"
-	setView(f, sheetName, 100)
-
-	f.SetRowHeight(sheetName, 2, 30)
-	f.SetRowHeight(sheetName, 3, 30)
-	f.SetRowHeight(sheetName, 4, 36)
-	f.SetColWidth(sheetName, "G", "I", 25.65)
-
-	rows := len(al.PairsArray) - 1
-	f.MergeCell(sheetName, "G2", "I3")
-	f.SetCellValue(sheetName, "G2", "Pairings")
-	styleId, _ := f.NewStyle(&excelize.Style{
-		Font:      &excelize.Font{Size: 16, Color: "FFFFFF", Bold: true, Underline: "single"},
-		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"7266A4"}},
-		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center"},
-		Border: []excelize.Border{{Type: "bottom", Color: "FFFFFF", Style: 5},
-			{Type: "left", Color: "FFFFFF", Style: 2},
-			{Type: "right", Color: "FFFFFF", Style: 2}},
-	})
-	f.SetCellStyle(sheetName, "G2", "I3", styleId)
-
-	f.SetCellValue(sheetName, "G4", "Pairings")
-	f.SetCellValue(sheetName, "H4", "Departure")
-	f.SetCellValue(sheetName, "I4", "Arrival")
-	styleId, _ = f.NewStyle(&excelize.Style{
-		Font:      &excelize.Font{Size: 12, Color: "FFFFFF", Bold: true},
-		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"7266A4"}},
-		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center"},
-		Border: []excelize.Border{{Type: "bottom", Color: "FFFFFF", Style: 5},
-			{Type: "left", Color: "FFFFFF", Style: 2},
-			{Type: "right", Color: "FFFFFF", Style: 2}},
-	})
-	f.SetCellStyle(sheetName, "G4", "I4", styleId)
-
-	dateTimefmt := "dd/mm/yyyy hh:mm"
-	pairingsfmt := "000#"
-
-	cellStyleId1, _ := f.NewStyle(&excelize.Style{
-		Font:      &excelize.Font{Size: 12},
-		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"CCC0DA"}},
-		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center"},
-		Border: []excelize.Border{{Type: "bottom", Color: "FFFFFF", Style: 1},
-			{Type: "left", Color: "FFFFFF", Style: 2},
-			{Type: "right", Color: "FFFFFF", Style: 2}},
-		CustomNumFmt: &pairingsfmt,
-	})
-
-	cellStyleId2, _ := f.NewStyle(&excelize.Style{
-		Font:      &excelize.Font{Size: 12},
-		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"E5E0EC"}},
-		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center"},
-		Border: []excelize.Border{{Type: "bottom", Color: "FFFFFF", Style: 1},
-			{Type: "left", Color: "FFFFFF", Style: 2},
-			{Type: "right", Color: "FFFFFF", Style: 2}},
-		CustomNumFmt: &pairingsfmt,
-	})
-
-	cellStyleId3, _ := f.NewStyle(&excelize.Style{
-		Font:      &excelize.Font{Size: 12},
-		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"CCC0DA"}},
-		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center"},
-		Border: []excelize.Border{{Type: "bottom", Color: "FFFFFF", Style: 1},
-			{Type: "left", Color: "FFFFFF", Style: 2},
-			{Type: "right", Color: "FFFFFF", Style: 2}},
-		CustomNumFmt: &dateTimefmt,
-	})
-
-	cellStyleId4, _ := f.NewStyle(&excelize.Style{
-		Font:      &excelize.Font{Size: 12},
-		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"E5E0EC"}},
-		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center"},
-		Border: []excelize.Border{{Type: "bottom", Color: "FFFFFF", Style: 1},
-			{Type: "left", Color: "FFFFFF", Style: 2},
-			{Type: "right", Color: "FFFFFF", Style: 2}},
-		CustomNumFmt: &dateTimefmt,
-	})
-
-	for i := 1; i < len(al.PairsArray); i++ {
-		departure := al.PairsArray[i].Start
-		arrival := al.PairsArray[i].End
-		departureString := fmt.Sprintf("%d/%d/%d %d:%02d",
-			departure.Day(), departure.Month(), departure.Year(), departure.Hour(), departure.Minute())
-		arrivalString := fmt.Sprintf("%d/%d/%d %d:%02d",
-			arrival.Day(), arrival.Month(), arrival.Year(), arrival.Hour(), arrival.Minute())
-
-		f.SetRowHeight(sheetName, 4+al.PairsArray[i].Id, 20.0)
-
-		var pairStyle int
-		var dateStyle int
-		if al.PairsArray[i].Id%2 != 0 {
-			pairStyle = cellStyleId1
-			dateStyle = cellStyleId3
-		} else {
-			pairStyle = cellStyleId2
-			dateStyle = cellStyleId4
-		}
-
-		cell, _ := excelize.CoordinatesToCellName(7, 4+al.PairsArray[i].Id)
-		f.SetCellValue(sheetName, cell, al.PairsArray[i].Id)
-		f.SetCellStyle(sheetName, cell, cell, pairStyle)
-
-		cell, _ = excelize.CoordinatesToCellName(8, 4+al.PairsArray[i].Id)
-		f.SetCellValue(sheetName, cell, departureString)
-		f.SetCellStyle(sheetName, cell, cell, dateStyle)
-
-		cell, _ = excelize.CoordinatesToCellName(9, 4+al.PairsArray[i].Id)
-		f.SetCellValue(sheetName, cell, arrivalString)
-		f.SetCellStyle(sheetName, cell, cell, dateStyle)
-	}
-
-	start, _ := excelize.CoordinatesToCellName(7, 4)
-	end, _ := excelize.CoordinatesToCellName(9, 4+rows)
-	enable := true
-	f.AddTable(sheetName, &excelize.Table{
-		Range:             start + ":" + end,
-		Name:              "Pairings",
-		StyleName:         "TableStyleMedium12",
-		ShowFirstColumn:   false,
-		ShowLastColumn:    false,
-		ShowRowStripes:    &enable,
-		ShowColumnStripes: false,
-	})
-
-}
+package results
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"go-airline-crew-rostering/airline"
+	"go-airline-crew-rostering/comparison"
+	"go-airline-crew-rostering/diff"
+	"go-airline-crew-rostering/disruption"
+	"go-airline-crew-rostering/experiment"
+	"go-airline-crew-rostering/fitness"
+	"go-airline-crew-rostering/input"
+	"go-airline-crew-rostering/metrics"
+	"go-airline-crew-rostering/tuning"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Container for the functions that store the results of a run in an output format
+type ResultWriter interface {
+	Extension() string // extension of the files of the format
+	Write(fileName string, m *metrics.Metrics, args *input.ArgumentCollection, al *airline.Airline, objective *fitness.Objective)
+}
+
+// Container for the functions that store the repair of a roster (replan command) in an output format
+type ReplanWriter interface {
+	WriteReplan(fileName string, d *disruption.Disruption, args *input.ArgumentCollection, al *airline.Airline, objective *fitness.Objective)
+}
+
+// Container for the functions that store the differences between two rosters (diff command) in an output format
+type DiffWriter interface {
+	WriteDiff(fileName string, d *diff.Diff, args *input.ArgumentCollection, al *airline.Airline)
+}
+
+// Container for the functions that store a parameter tuning (tune command) in an output format
+type TuningWriter interface {
+	WriteTuning(fileName string, tuner *tuning.Tuner, args *input.ArgumentCollection)
+}
+
+// Container for the functions that store an experiment (experiment command) in an output format
+type ExperimentWriter interface {
+	WriteExperiment(fileName string, e *experiment.Experiment, args *input.ArgumentCollection)
+}
+
+// Container for the functions that store a comparison of result sets (compare command) in an output format
+type ComparisonWriter interface {
+	WriteComparison(fileName string, c *comparison.Comparison, args *input.ArgumentCollection)
+}
+
+// Writers of the output formats (selected with --format)
+var Writers = map[string]ResultWriter{
+	"xlsx": new(XLSXWriter),
+	"json": new(JSONWriter),
+	"csv":  new(CSVWriter),
+	"ics":  new(ICSWriter),
+	"html": new(HTMLWriter),
+}
+
+// Writer of the excel workbook with the schedule and various statistics
+type XLSXWriter struct{}
+
+func PrintResults(m *metrics.Metrics, args *input.ArgumentCollection, al *airline.Airline, objective *fitness.Objective) {
+	// Store the results of the run in every requested format
+	// (the files are named after the results file)
+	for _, format := range *args.Formats {
+		writer := Writers[format]
+		writer.Write(FileName(*args.ResultsFile, writer.Extension()), m, args, al, objective)
+	}
+}
+
+func PrintReplan(d *disruption.Disruption, args *input.ArgumentCollection, al *airline.Airline, objective *fitness.Objective) {
+	// Store the repaired roster and its changes in every requested format
+	for _, format := range *args.Formats {
+		writer, ok := Writers[format].(ReplanWriter)
+		if !ok {
+			fmt.Printf("the %s format cannot store the repair of a roster\n", format)
+			continue
+		}
+		writer.WriteReplan(FileName(*args.ResultsFile, Writers[format].Extension()), d, args, al, objective)
+	}
+}
+
+func PrintDiff(d *diff.Diff, args *input.ArgumentCollection, al *airline.Airline) {
+	// Store the differences between the two rosters in every requested format
+	for _, format := range *args.Formats {
+		writer, ok := Writers[format].(DiffWriter)
+		if !ok {
+			fmt.Printf("the %s format cannot store the differences between rosters\n", format)
+			continue
+		}
+		writer.WriteDiff(FileName(*args.ResultsFile, Writers[format].Extension()), d, args, al)
+	}
+}
+
+func PrintTuning(tuner *tuning.Tuner, args *input.ArgumentCollection) {
+	// Store the best configuration of the tuning and the results
+	// of every configuration raced in every requested format
+	for _, format := range *args.Formats {
+		writer, ok := Writers[format].(TuningWriter)
+		if !ok {
+			fmt.Printf("the %s format cannot store a parameter tuning\n", format)
+			continue
+		}
+		writer.WriteTuning(FileName(*args.ResultsFile, Writers[format].Extension()), tuner, args)
+	}
+}
+
+func FileName(resultsFile string, extension string) string {
+	// returns the name of the results file with "extension"
+	return strings.TrimSuffix(resultsFile, filepath.Ext(resultsFile)) + "." + extension
+}
+
+func (writer *XLSXWriter) Extension() string {
+	return "xlsx"
+}
+
+func (writer *XLSXWriter) Write(fileName string, m *metrics.Metrics, args *input.ArgumentCollection, al *airline.Airline, objective *fitness.Objective) {
+	// Creates an excel file to store an airline crew rostering schedule, along with various
+	// statistics
+	f := excelize.NewFile()
+	defer func() {
+		if err := f.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	docProperties, _ := f.GetDocProps()
+	docProperties.Language = "en-UK"
+	f.SetDocProps(docProperties)
+
+	f.SetDefaultFont("Arial")
+
+	generalSheetName := "General Information"
+	solutionSheetName := "Solution Statistics"
+	algorithmSheetName := "Optimization Algorithm"
+	scheduleSheetName := "Schedule"
+	pairingsSheetName := "Pairings"
+	plotsSheetName := "Plots"
+	manifestSheetName := "Manifest"
+
+	f.SetSheetName("Sheet1", generalSheetName)
+
+	if _, err := f.NewSheet(solutionSheetName); err != nil {
+		fmt.Println(err)
+		return
+	}
+	if _, err := f.NewSheet(algorithmSheetName); err != nil {
+		fmt.Println(err)
+		return
+	}
+	if _, err := f.NewSheet(scheduleSheetName); err != nil {
+		fmt.Println(err)
+		return
+	}
+	if _, err := f.NewSheet(pairingsSheetName); err != nil {
+		fmt.Println(err)
+		return
+	}
+	if _, err := f.NewSheet(plotsSheetName); err != nil {
+		fmt.Println(err)
+		return
+	}
+	if _, err := f.NewSheet(manifestSheetName); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	drawGeneralSheet(f, m, args, al)
+	drawSolutionStatisticsSheet(f, m, args, al, objective)
+	drawOptimizationAlgorithmSheet(f, m, args, al)
+	drawScheduleSheet(f, scheduleSheetName, al, al.PilotsArray, *args.Colouring)
+	drawPairingsSheet(f, m, args, al)
+	drawPlotsSheet(f, m, args, al)
+	drawManifestSheet(f, newManifest(m.Seed, args, al))
+	if len(m.Pilots) > 0 {
+		if !drawPilotsSheet(f, m) {
+			return
+		}
+	}
+	if len(m.Uncovered) > 0 {
+		if !drawUncoveredSheet(f, m) {
+			return
+		}
+	}
+	if len(m.Islands) > 0 {
+		if !drawIslandsSheet(f, m) {
+			return
+		}
+	}
+	if len(m.ParetoFront) > 0 {
+		if !drawParetoFrontSheets(f, m, args, al) {
+			return
+		}
+	}
+
+	index, _ := f.GetSheetIndex(scheduleSheetName)
+	f.SetActiveSheet(index)
+
+	if err := f.SaveAs(fileName); err != nil {
+		fmt.Println(err)
+	}
+
+}
+
+func termName(term *fitness.Term) string {
+	// returns the name of an objective term, along with its norm or its dimension and indicator
+	if term.Name == fitness.Deviation {
+		return term.Name + " (" + term.Norm + ")"
+	} else if term.Name == fitness.Fairness {
+		return term.Name + " (" + term.Dimension + ", " + term.Indicator + ")"
+	}
+	return term.Name
+}
+
+func setView(f *excelize.File, sheetName string, zoom float64) {
+	// set view options for an excel sheet
+	options, err := f.GetSheetView(sheetName, 0)
+	if err != nil {
+		fmt.Println(err)
+	}
+	*options.ShowGridLines = false
+	*options.ShowRowColHeaders = false
+	*options.ZoomScale = zoom
+	f.SetSheetView(sheetName, 0, &options)
+}
+
+func drawGeneralSheet(f *excelize.File, m *metrics.Metrics, args *input.ArgumentCollection, al *airline.Airline) {
+	// create an excel sheet containing general information of the application
+	sheetName := "General Information"
+	algorithmName := ""
+	if args.Algorithm == "multiCSO" {
+		algorithmName = "Multi-step CSO"
+	} else if args.Algorithm == "AOA" {
+		algorithmName = "Archimedes Optimization"
+	} else if args.Algorithm == "columnGeneration" {
+		algorithmName = "Column Generation"
+	} else if args.Algorithm == "NSGA" {
+		algorithmName = "NSGA-II"
+	} else if args.Algorithm == "islands" {
+		algorithmName = "Island Model"
+	} else if args.Algorithm == "score" {
+		algorithmName = "Given Roster"
+	}
+
+	setView(f, sheetName, 100.0)
+
+	f.SetColWidth(sheetName, "G", "J", 11.62)
+	f.SetColWidth(sheetName, "L", "O", 11.62)
+	for i := 5; i <= 11; i++ {
+		f.SetRowHeight(sheetName, i, 30)
+	}
+
+	f.SetCellValue(sheetName, "B2", "General Information")
+	f.SetCellValue(sheetName, "B5", "Input File")
+	f.SetCellValue(sheetName, "B6", "Algorithm")
+	f.SetCellValue(sheetName, "B7", "Execution Time")
+	f.SetCellValue(sheetName, "D5", *args.Filename)
+	f.SetCellValue(sheetName, "D6", algorithmName)
+	generalRows := 3
+	if m.StopReason != "" {
+		f.SetCellValue(sheetName, "B8", "Stop Reason")
+		f.SetCellValue(sheetName, "B9", "Generations Run")
+		f.SetCellValue(sheetName, "D8", m.StopReason)
+		f.SetCellValue(sheetName, "D9", m.Generations)
+		generalRows = 5
+	}
+
+	executionstring := m.TotalTime.String()
+
+	i := strings.Index(executionstring, "m")
+	if i > -1 && i < len(executionstring) && executionstring[i+1:i+2] != "s" {
+		executionstring = executionstring[:i] + " min. " + executionstring[i+1:]
+	}
+	i = strings.Index(executionstring, "s")
+	if i > -1 && executionstring[i-1:i] != "m" {
+		executionstring = executionstring[:i] + " sec." + executionstring[i+1:]
+	}
+	f.SetCellValue("General Information", "D7", executionstring)
+
+	drawVerticalTable(f, sheetName, "B2", generalRows, "4F81BD", "B8CCE4")
+
+	f.SetCellValue(sheetName, "G2", "Airline Crew Rostering Information")
+	f.SetCellValue(sheetName, "G5", "Start Date")
+	f.SetCellValue(sheetName, "G6", "End Date")
+	f.SetCellValue(sheetName, "G7", "Pilots")
+	f.SetCellValue(sheetName, "G8", "Pairs")
+	f.SetCellValue(sheetName, "G9", "Optimal Workload")
+
+	startdatestring := strconv.Itoa(args.StartDate.Day()) + " " + args.StartDate.Month().String() + " " + strconv.Itoa(args.StartDate.Year())
+	enddatestring := strconv.Itoa(args.EndDate.Day()) + " " + args.EndDate.Month().String() + " " + strconv.Itoa(args.EndDate.Year())
+
+	f.SetCellValue(sheetName, "I5", startdatestring)
+	f.SetCellValue(sheetName, "I6", enddatestring)
+	f.SetCellValue(sheetName, "I7", *args.Pilots)
+	f.SetCellValue(sheetName, "I8", len(al.PairsArray)-1)
+	f.SetCellValue(sheetName, "I9", math.Round(al.AverageWorkload))
+
+	drawVerticalTable(f, sheetName, "G2", 5, "C0504D", "E6B9B8")
+
+	f.SetCellValue(sheetName, "L2", "Optimization Algorithm Information")
+	f.SetCellValue(sheetName, "L5", "Agents")
+	f.SetCellValue(sheetName, "L6", "Generations")
+	f.SetCellValue(sheetName, "L7", "Seed")
+	f.SetCellValue(sheetName, "N5", *args.Agents)
+	f.SetCellValue(sheetName, "N6", *args.Generations)
+
+	var rows int
+	if args.Algorithm == "multiCSO" {
+		f.SetCellValue(sheetName, "L8", "FL")
+		f.SetCellValue(sheetName, "N8", *args.FL)
+		rows = 4
+	} else if args.Algorithm == "AOA" {
+		f.SetCellValue(sheetName, "L8", "C1")
+		f.SetCellValue(sheetName, "L9", "C2")
+		f.SetCellValue(sheetName, "L10", "C3")
+		f.SetCellValue(sheetName, "L11", "C4")
+		f.SetCellValue(sheetName, "N8", args.Constants[0])
+		f.SetCellValue(sheetName, "N9", args.Constants[1])
+		f.SetCellValue(sheetName, "N10", args.Constants[2])
+		f.SetCellValue(sheetName, "N11", args.Constants[3])
+		rows = 7
+	} else if args.Algorithm == "columnGeneration" {
+		f.SetCellValue(sheetName, "L6", "Iterations")
+		f.SetCellValue(sheetName, "L8", "Columns")
+		f.SetCellValue(sheetName, "L9", "Penalty")
+		f.SetCellValue(sheetName, "N8", *args.Columns)
+		f.SetCellValue(sheetName, "N9", *args.Penalty)
+		rows = 5
+	} else if args.Algorithm == "NSGA" {
+		f.SetCellValue(sheetName, "L8", "Mutation")
+		f.SetCellValue(sheetName, "N8", *args.Mutation)
+		rows = 4
+	} else if args.Algorithm == "islands" {
+		f.SetCellValue(sheetName, "L5", "Agents per Island")
+		f.SetCellValue(sheetName, "L8", "Islands")
+		f.SetCellValue(sheetName, "L9", "Island Algorithm")
+		f.SetCellValue(sheetName, "L10", "Migration Interval")
+		f.SetCellValue(sheetName, "N8", *args.Islands)
+		f.SetCellValue(sheetName, "N9", *args.IslandType)
+		f.SetCellValue(sheetName, "N10", *args.Migration)
+		rows = 6
+	}
+
+	drawVerticalTable(f, sheetName, "L2", rows, "4CB7C8", "B6E9CE")
+	// the seed is always recorded, so that the run can be replayed
+	if *args.Seed == -1 {
+		f.SetCellValue(sheetName, "L7", "Generated Seed")
+	}
+	styleId, _ := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Size: 12},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center", WrapText: true},
+		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"B6E9CE"}},
+		Border:    []excelize.Border{{Type: "top", Color: "FFFFFF", Style: 1}, {Type: "left", Color: "FFFFFF", Style: 1}, {Type: "bottom", Color: "FFFFFF", Style: 1}},
+		NumFmt:    49,
+	})
+	f.SetCellStyle(sheetName, "N7", "O7", styleId)
+	f.SetCellValue(sheetName, "N7", strconv.FormatInt(m.Seed, 10))
+}
+
+func drawSolutionStatisticsSheet(f *excelize.File, m *metrics.Metrics, args *input.ArgumentCollection, al *airline.Airline,
+	objective *fitness.Objective) {
+	// create an excel sheet containing statistics related to the solution
+	sheetName := "Solution Statistics"
+
+	setView(f, sheetName, 100.0)
+
+	tableStyleTitle := excelize.Style{
+		Font:      &excelize.Font{Size: 16, Color: "FFFFFF", Bold: true, Underline: "single"},
+		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"4CB3C8"}},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center"},
+		Border:    []excelize.Border{{Type: "bottom", Color: "FFFFFF", Style: 5}},
+	}
+	tableStyleCellsHeader := excelize.Style{
+		Font:      &excelize.Font{Size: 12, Color: "FFFFFF", Bold: true},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center", WrapText: true},
+		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"4CB3C8"}},
+		Border: []excelize.Border{{Type: "top", Color: "FFFFFF", Style: 5}, {Type: "right", Color: "FFFFFF", Style: 1},
+			{Type: "bottom", Color: "FFFFFF", Style: 5}, {Type: "left", Color: "FFFFFF", Style: 1}},
+	}
+	tableStyleCellsData := excelize.Style{
+		Font:      &excelize.Font{Size: 12},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center", WrapText: true},
+		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"B6DDE8"}},
+		Border: []excelize.Border{{Type: "top", Color: "FFFFFF", Style: 1}, {Type: "left", Color: "FFFFFF", Style: 1},
+			{Type: "bottom", Color: "FFFFFF", Style: 1}, {Type: "left", Color: "FFFFFF", Style: 1}},
+	}
+
+	f.SetColWidth(sheetName, "B", "E", 11.62)
+	f.SetColWidth(sheetName, "G", "J", 22.62)
+
+	f.SetCellValue(sheetName, "B2", "Solution Information")
+	f.SetCellValue(sheetName, "B5", "Assigned Pairs")
+	f.SetCellValue(sheetName, "B6", "Unassigned Pairs")
+	f.SetCellValue(sheetName, "B7", "Average Rest Period")
+	f.SetCellValue(sheetName, "B8", "Average Days Off")
+	f.SetCellValue(sheetName, "B9", "Best Cost")
+	f.SetCellValue(sheetName, "B10", "Worst Cost")
+	f.SetCellValue(sheetName, "B11", "Total Deviation")
+
+	f.SetCellValue(sheetName, "D5", m.TotalAssignedPairs)
+	f.SetCellValue(sheetName, "D6", len(al.PairsArray)-1-m.TotalAssignedPairs)
+	f.SetCellValue(sheetName, "D7", math.Round(m.AverageRestPeriod*100)/100)
+	f.SetCellValue(sheetName, "D8", math.Round(m.AverageDaysOff*100)/100)
+	f.SetCellValue(sheetName, "D9", math.Round(m.GlobalBestSolutionCost))
+
+	worstCost := m.IterWorstCost[0]
+	for i := range m.IterWorstCost {
+		if worstCost < m.IterWorstCost[i] {
+			worstCost = m.IterWorstCost[i]
+		}
+	}
+	f.SetCellValue(sheetName, "D10", math.Round(worstCost))
+	deviation := &fitness.Term{Name: fitness.Deviation, Norm: "L1"}
+	f.SetCellValue(sheetName, "D11", math.Round(objective.Value(deviation, al.PilotsArray, al)))
+
+	rows := 7
+	if args.Algorithm == "columnGeneration" {
+		f.SetCellValue(sheetName, "B12", "Lower Bound")
+		f.SetCellValue(sheetName, "D12", math.Round(m.LowerBound))
+		rows = 8
+	}
+
+	drawVerticalTable(f, sheetName, "B2", rows, "7666A4", "CCC0DA")
+
+	// values of the objective's terms for the solution
+	f.SetCellValue(sheetName, "B16", "Objective")
+	for i, term := range objective.Terms {
+		cell, _ := excelize.CoordinatesToCellName(2, 19+i)
+		f.SetCellValue(sheetName, cell, termName(term))
+		cell, _ = excelize.CoordinatesToCellName(4, 19+i)
+		f.SetCellValue(sheetName, cell, math.Round(objective.Value(term, al.PilotsArray, al)*100)/100)
+	}
+	fitnessValue, cost := objective.Evaluate(al.PilotsArray, al)
+	cell, _ := excelize.CoordinatesToCellName(2, 19+len(objective.Terms))
+	f.SetCellValue(sheetName, cell, "Fitness")
+	cell, _ = excelize.CoordinatesToCellName(4, 19+len(objective.Terms))
+	f.SetCellValue(sheetName, cell, math.Round(fitnessValue*100)/100)
+	cell, _ = excelize.CoordinatesToCellName(2, 20+len(objective.Terms))
+	f.SetCellValue(sheetName, cell, "Cost")
+	cell, _ = excelize.CoordinatesToCellName(4, 20+len(objective.Terms))
+	f.SetCellValue(sheetName, cell, math.Round(cost*objective.UnitCost))
+	drawVerticalTable(f, sheetName, "B16", len(objective.Terms)+2, "7666A4", "CCC0DA")
+
+	f.SetCellValue(sheetName, "G2", "Pilot Statistics")
+	f.SetCellValue(sheetName, "G5", "Pilot")
+	f.SetCellValue(sheetName, "H5", "Flight time\n (in minutes)")
+	f.SetCellValue(sheetName, "I5", "Deviation from\n optimal workload")
+	f.SetCellValue(sheetName, "J5", "Assigned Pairs")
+
+	styleId, _ := f.NewStyle(&tableStyleTitle)
+	f.SetCellStyle(sheetName, "G2", "J4", styleId)
+	styleId, _ = f.NewStyle(&tableStyleCellsHeader)
+	f.SetCellStyle(sheetName, "G5", "J5", styleId)
+	f.MergeCell(sheetName, "G2", "J4")
+
+	styleId, _ = f.NewStyle(&tableStyleCellsData)
+	fillColor := &tableStyleCellsData.Fill.Color[0]
+	*fillColor = "DBEEF3"
+	styleId2, _ := f.NewStyle(&tableStyleCellsData)
+
+	f.SetRowHeight(sheetName, 5, 40.0)
+	for i, pilot := range al.PilotsArray {
+		f.SetRowHeight(sheetName, 6+i, 40.0)
+		cell, _ := excelize.CoordinatesToCellName(7, 6+i)
+		f.SetCellValue(sheetName, cell, pilot.Id)
+		cell, _ = excelize.CoordinatesToCellName(8, 6+i)
+		f.SetCellValue(sheetName, cell, math.Round(pilot.FlightTime))
+		cell, _ = excelize.CoordinatesToCellName(9, 6+i)
+		f.SetCellValue(sheetName, cell, math.Round(math.Abs(al.AverageWorkload-pilot.FlightTime)))
+		cell, _ = excelize.CoordinatesToCellName(10, 6+i)
+		f.SetCellValue(sheetName, cell, pilot.AssignedLength)
+		start, _ := excelize.CoordinatesToCellName(7, 6+i)
+		end, _ := excelize.CoordinatesToCellName(10, 6+i)
+		if i%2 == 0 {
+			f.SetCellStyle(sheetName, start, end, styleId)
+		} else {
+			f.SetCellStyle(sheetName, start, end, styleId2)
+		}
+	}
+
+	start, _ := excelize.CoordinatesToCellName(7, 5)
+	end, _ := excelize.CoordinatesToCellName(10, 5+(*args.Pilots))
+	enable := true
+	f.AddTable(sheetName, &excelize.Table{
+		Range:             start + ":" + end,
+		Name:              "PilotStatistics",
+		StyleName:         "TableStyleMedium20",
+		ShowFirstColumn:   false,
+		ShowLastColumn:    false,
+		ShowRowStripes:    &enable,
+		ShowColumnStripes: false,
+	})
+
+	// fairness indicators of each dimension of the pilots' schedules
+	f.SetColWidth(sheetName, "L", "O", 16.62)
+	f.SetCellValue(sheetName, "L2", "Fairness")
+	f.SetCellValue(sheetName, "L5", "Dimension")
+	f.SetCellValue(sheetName, "M5", "Gini Coefficient")
+	f.SetCellValue(sheetName, "N5", "Max-Min Spread")
+	f.SetCellValue(sheetName, "O5", "Standard Deviation")
+
+	styleId, _ = f.NewStyle(&tableStyleTitle)
+	f.SetCellStyle(sheetName, "L2", "O4", styleId)
+	styleId, _ = f.NewStyle(&tableStyleCellsHeader)
+	f.SetCellStyle(sheetName, "L5", "O5", styleId)
+	f.MergeCell(sheetName, "L2", "O4")
+	*fillColor = "B6DDE8"
+	styleId, _ = f.NewStyle(&tableStyleCellsData)
+	for i, fairness := range m.Fairness {
+		cell, _ := excelize.CoordinatesToCellName(12, 6+i)
+		f.SetCellValue(sheetName, cell, fairness.Dimension)
+		cell, _ = excelize.CoordinatesToCellName(13, 6+i)
+		f.SetCellValue(sheetName, cell, math.Round(fairness.Gini*1000)/1000)
+		cell, _ = excelize.CoordinatesToCellName(14, 6+i)
+		f.SetCellValue(sheetName, cell, fairness.Spread)
+		cell, _ = excelize.CoordinatesToCellName(15, 6+i)
+		f.SetCellValue(sheetName, cell, math.Round(fairness.StandardDeviation*100)/100)
+	}
+	end, _ = excelize.CoordinatesToCellName(15, 5+len(m.Fairness))
+	f.SetCellStyle(sheetName, "L6", end, styleId)
+
+}
+
+func drawOptimizationAlgorithmSheet(f *excelize.File, m *metrics.Metrics, args *input.ArgumentCollection, al *airline.Airline) {
+	// create an excel sheet containing statistics related to the optimization algorithm
+	sheetName := "Optimization Algorithm"
+
+	setView(f, sheetName, 100.0)
+
+	f.SetColWidth(sheetName, "B", "E", 11.62)
+	for i := 5; i <= 10; i++ {
+		f.SetRowHeight(sheetName, i, 30.0)
+	}
+
+	f.SetCellValue(sheetName, "B2", "Optimization Algorithm Statistics")
+	f.SetCellValue(sheetName, "B5", "Valid Solutions")
+	f.SetCellValue(sheetName, "B6", "Invalid Solutions")
+	f.SetCellValue(sheetName, "B7", "Total Solutions")
+	f.SetCellValue(sheetName, "B8", "Unique Solutions")
+	f.SetCellValue(sheetName, "B9", "Jumps")
+	f.SetCellValue(sheetName, "B10", "Similarity")
+	f.SetCellValue(sheetName, "D5", m.ValidSolutions)
+
+	f.SetCellValue(sheetName, "D6", m.TotalSolutions-m.ValidSolutions)
+	f.SetCellValue(sheetName, "D7", m.TotalSolutions)
+	f.SetCellValue(sheetName, "D8", m.UniqueCount)
+	f.SetCellValue(sheetName, "D9", m.Jumps)
+	f.SetCellValue(sheetName, "D10", math.Round(m.AverageSimilarity*100)/10000)
+
+	drawVerticalTable(f, sheetName, "B2", 6, "4F81BD", "B8CCE4")
+
+	styleId, _ := f.NewStyle(&excelize.Style{Font: &excelize.Font{Size: 12},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center", WrapText: true},
+		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"B8CCE4"}},
+		Border:    []excelize.Border{{Type: "top", Color: "FFFFFF", Style: 1}, {Type: "left", Color: "FFFFFF", Style: 1}},
+		NumFmt:    10,
+	})
+	f.SetCellStyle(sheetName, "D10", "E10", styleId)
+	addPlot(f, sheetName, "H2", "iterations", "Iterations' Comparison", args, func(plotFile string) string {
+		return drawIterationMetricsPlot(plotFile, m, args)
+	})
+}
+
+func drawPlotsSheet(f *excelize.File, m *metrics.Metrics, args *input.ArgumentCollection, al *airline.Airline) {
+	// create an excel sheet containing the workload of the pilots and, for the algorithms
+	// with a population, the diversity and the coverage of the solutions of each generation
+	sheetName := "Plots"
+	setView(f, sheetName, 100.0)
+
+	addPlot(f, sheetName, "B2", "workload", "Flight Time per Pilot", args, func(plotFile string) string {
+		return drawWorkloadPlot(plotFile, al)
+	})
+	if len(m.IterSimilarity) == 0 {
+		return
+	}
+	addPlot(f, sheetName, "L2", "similarity", "Similarity to the Global Best", args, func(plotFile string) string {
+		return drawSimilarityPlot(plotFile, m)
+	})
+	addPlot(f, sheetName, "B33", "unique", "Unique Solutions", args, func(plotFile string) string {
+		return drawUniqueSolutionsPlot(plotFile, m)
+	})
+	addPlot(f, sheetName, "L33", "uncovered", "Uncovered Pairs", args, func(plotFile string) string {
+		return drawUncoveredPlot(plotFile, m)
+	})
+}
+
+func addPlot(f *excelize.File, sheetName string, cell string, name string, altText string, args *input.ArgumentCollection,
+	draw func(plotFile string) string) {
+	// add the plot drawn by "draw" to the sheet and keep it as a standalone png or svg file
+	// named after the results file, if the "plots" option asks for it
+	base := strings.TrimSuffix(*args.ResultsFile, filepath.Ext(*args.ResultsFile)) + "_" + name
+	plotFile := draw(base + ".png")
+	if err := f.AddPicture(sheetName, cell, plotFile, &excelize.GraphicOptions{AltText: altText}); err != nil {
+		fmt.Println(err)
+	}
+	if *args.Plots != "png" {
+		os.Remove(plotFile)
+	}
+	if *args.Plots == "svg" {
+		draw(base + ".svg")
+	}
+}
+
+func drawPairingsSheet(f *excelize.File, m *metrics.Metrics, args *input.ArgumentCollection, al *airline.Airline) {
+	// create an excel sheet containing all the pairings along with their start and end datetimes
+	// and the pilot they are assigned to (the column can be edited and read back as a roster)
+	sheetName := "Pairings"
+	setView(f, sheetName, 100)
+
+	f.SetRowHeight(sheetName, 2, 30)
+	f.SetRowHeight(sheetName, 3, 30)
+	f.SetRowHeight(sheetName, 4, 36)
+	f.SetColWidth(sheetName, "G", "J", 25.65)
+
+	rows := len(al.PairsArray) - 1
+	f.MergeCell(sheetName, "G2", "J3")
+	f.SetCellValue(sheetName, "G2", "Pairings")
+	styleId, _ := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Size: 16, Color: "FFFFFF", Bold: true, Underline: "single"},
+		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"7266A4"}},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center"},
+		Border: []excelize.Border{{Type: "bottom", Color: "FFFFFF", Style: 5},
+			{Type: "left", Color: "FFFFFF", Style: 2},
+			{Type: "right", Color: "FFFFFF", Style: 2}},
+	})
+	f.SetCellStyle(sheetName, "G2", "J3", styleId)
+
+	f.SetCellValue(sheetName, "G4", "Pairings")
+	f.SetCellValue(sheetName, "H4", "Departure")
+	f.SetCellValue(sheetName, "I4", "Arrival")
+	f.SetCellValue(sheetName, "J4", "Pilot")
+	styleId, _ = f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Size: 12, Color: "FFFFFF", Bold: true},
+		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"7266A4"}},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center"},
+		Border: []excelize.Border{{Type: "bottom", Color: "FFFFFF", Style: 5},
+			{Type: "left", Color: "FFFFFF", Style: 2},
+			{Type: "right", Color: "FFFFFF", Style: 2}},
+	})
+	f.SetCellStyle(sheetName, "G4", "J4", styleId)
+
+	dateTimefmt := "dd/mm/yyyy hh:mm"
+	pairingsfmt := "000#"
+
+	cellStyleId1, _ := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Size: 12},
+		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"CCC0DA"}},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center"},
+		Border: []excelize.Border{{Type: "bottom", Color: "FFFFFF", Style: 1},
+			{Type: "left", Color: "FFFFFF", Style: 2},
+			{Type: "right", Color: "FFFFFF", Style: 2}},
+		CustomNumFmt: &pairingsfmt,
+	})
+
+	cellStyleId2, _ := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Size: 12},
+		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"E5E0EC"}},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center"},
+		Border: []excelize.Border{{Type: "bottom", Color: "FFFFFF", Style: 1},
+			{Type: "left", Color: "FFFFFF", Style: 2},
+			{Type: "right", Color: "FFFFFF", Style: 2}},
+		CustomNumFmt: &pairingsfmt,
+	})
+
+	cellStyleId3, _ := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Size: 12},
+		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"CCC0DA"}},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center"},
+		Border: []excelize.Border{{Type: "bottom", Color: "FFFFFF", Style: 1},
+			{Type: "left", Color: "FFFFFF", Style: 2},
+			{Type: "right", Color: "FFFFFF", Style: 2}},
+		CustomNumFmt: &dateTimefmt,
+	})
+
+	cellStyleId4, _ := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Size: 12},
+		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"E5E0EC"}},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center"},
+		Border: []excelize.Border{{Type: "bottom", Color: "FFFFFF", Style: 1},
+			{Type: "left", Color: "FFFFFF", Style: 2},
+			{Type: "right", Color: "FFFFFF", Style: 2}},
+		CustomNumFmt: &dateTimefmt,
+	})
+
+	pilots := make(map[*airline.Pair]int) // pilot of each assigned pairing
+	for _, pilot := range al.PilotsArray {
+		for _, pair := range pilot.AssignedPairs[1 : pilot.AssignedLength+1] {
+			pilots[pair] = pilot.Id
+		}
+	}
+
+	for i := 1; i < len(al.PairsArray); i++ {
+		departure := al.PairsArray[i].Start
+		arrival := al.PairsArray[i].End
+		departureString := fmt.Sprintf("%d/%d/%d %d:%02d",
+			departure.Day(), departure.Month(), departure.Year(), departure.Hour(), departure.Minute())
+		arrivalString := fmt.Sprintf("%d/%d/%d %d:%02d",
+			arrival.Day(), arrival.Month(), arrival.Year(), arrival.Hour(), arrival.Minute())
+
+		f.SetRowHeight(sheetName, 4+al.PairsArray[i].Id, 20.0)
+
+		var pairStyle int
+		var dateStyle int
+		if al.PairsArray[i].Id%2 != 0 {
+			pairStyle = cellStyleId1
+			dateStyle = cellStyleId3
+		} else {
+			pairStyle = cellStyleId2
+			dateStyle = cellStyleId4
+		}
+
+		cell, _ := excelize.CoordinatesToCellName(7, 4+al.PairsArray[i].Id)
+		f.SetCellValue(sheetName, cell, al.PairsArray[i].Id)
+		f.SetCellStyle(sheetName, cell, cell, pairStyle)
+
+		cell, _ = excelize.CoordinatesToCellName(8, 4+al.PairsArray[i].Id)
+		f.SetCellValue(sheetName, cell, departureString)
+		f.SetCellStyle(sheetName, cell, cell, dateStyle)
+
+		cell, _ = excelize.CoordinatesToCellName(9, 4+al.PairsArray[i].Id)
+		f.SetCellValue(sheetName, cell, arrivalString)
+		f.SetCellStyle(sheetName, cell, cell, dateStyle)
+
+		cell, _ = excelize.CoordinatesToCellName(10, 4+al.PairsArray[i].Id)
+		if pilot, assigned := pilots[al.PairsArray[i]]; assigned {
+			f.SetCellValue(sheetName, cell, pilot)
+		}
+		f.SetCellStyle(sheetName, cell, cell, pairStyle)
+	}
+
+	start, _ := excelize.CoordinatesToCellName(7, 4)
+	end, _ := excelize.CoordinatesToCellName(10, 4+rows)
+	enable := true
+	f.AddTable(sheetName, &excelize.Table{
+		Range:             start + ":" + end,
+		Name:              "Pairings",
+		StyleName:         "TableStyleMedium12",
+		ShowFirstColumn:   false,
+		ShowLastColumn:    false,
+		ShowRowStripes:    &enable,
+		ShowColumnStripes: false,
+	})
+
+}
+
+func drawParetoFrontSheets(f *excelize.File, m *metrics.Metrics, args *input.ArgumentCollection, al *airline.Airline) bool {
+	// create an excel sheet containing the objective values of each non dominated
+	// solution and a schedule sheet for each one of them
+	// returns true on success
+	sheetName := "Pareto Front"
+	if _, err := f.NewSheet(sheetName); err != nil {
+		fmt.Println(err)
+		return false
+	}
+	setView(f, sheetName, 100.0)
+
+	f.SetColWidth(sheetName, "B", "G", 16.62)
+	f.SetRowHeight(sheetName, 2, 40.0)
+	f.SetCellValue(sheetName, "B2", "Solution")
+	f.SetCellValue(sheetName, "C2", "Uncovered Pairs")
+	f.SetCellValue(sheetName, "D2", "Total Deviation")
+	f.SetCellValue(sheetName, "E2", "Excess Rest\n (in hours)")
+	f.SetCellValue(sheetName, "F2", "Unmet Preferences")
+	f.SetCellValue(sheetName, "G2", "Schedule Sheet")
+
+	styleId, _ := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Size: 12},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center", WrapText: true},
+	})
+	for i, objectives := range m.ParetoObjectives {
+		memberSheetName := fmt.Sprintf("Front Member %d", i+1)
+		if _, err := f.NewSheet(memberSheetName); err != nil {
+			fmt.Println(err)
+			return false
+		}
+		drawScheduleSheet(f, memberSheetName, al, m.ParetoFront[i], *args.Colouring)
+
+		f.SetRowHeight(sheetName, 3+i, 20.0)
+		cell, _ := excelize.CoordinatesToCellName(2, 3+i)
+		f.SetCellValue(sheetName, cell, i+1)
+		for j, objective := range objectives {
+			cell, _ = excelize.CoordinatesToCellName(3+j, 3+i)
+			f.SetCellValue(sheetName, cell, math.Round(objective))
+		}
+		cell, _ = excelize.CoordinatesToCellName(7, 3+i)
+		f.SetCellValue(sheetName, cell, memberSheetName)
+		f.SetCellHyperLink(sheetName, cell, "'"+memberSheetName+"'!A1", "Location")
+	}
+	end, _ := excelize.CoordinatesToCellName(7, 2+len(m.ParetoObjectives))
+	f.SetCellStyle(sheetName, "B2", end, styleId)
+	enable := true
+	f.AddTable(sheetName, &excelize.Table{
+		Range:             "B2:" + end,
+		Name:              "ParetoFront",
+		StyleName:         "TableStyleMedium20",
+		ShowFirstColumn:   false,
+		ShowLastColumn:    false,
+		ShowRowStripes:    &enable,
+		ShowColumnStripes: false,
+	})
+	return true
+}
+
+func drawUncoveredSheet(f *excelize.File, m *metrics.Metrics) bool {
+	// create an excel sheet containing the pairings that are not covered
+	// by the solution along with the rule that prevents their assignment
+	// returns true on success
+	sheetName := "Uncovered Pairings"
+	if _, err := f.NewSheet(sheetName); err != nil {
+		fmt.Println(err)
+		return false
+	}
+	setView(f, sheetName, 100.0)
+
+	f.SetColWidth(sheetName, "B", "E", 20)
+	f.SetRowHeight(sheetName, 2, 30.0)
+	f.SetCellValue(sheetName, "B2", "Pairing")
+	f.SetCellValue(sheetName, "C2", "Departure")
+	f.SetCellValue(sheetName, "D2", "Arrival")
+	f.SetCellValue(sheetName, "E2", "Binding Constraint")
+
+	styleId, _ := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Size: 12},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center", WrapText: true},
+	})
+	for i, uncovered := range m.Uncovered {
+		pair := uncovered.Pair
+		f.SetRowHeight(sheetName, 3+i, 20.0)
+		f.SetCellValue(sheetName, fmt.Sprintf("B%d", 3+i), fmt.Sprintf("%04d", pair.Id))
+		f.SetCellValue(sheetName, fmt.Sprintf("C%d", 3+i), fmt.Sprintf("%d/%d/%d %d:%02d",
+			pair.Start.Day(), pair.Start.Month(), pair.Start.Year(), pair.Start.Hour(), pair.Start.Minute()))
+		f.SetCellValue(sheetName, fmt.Sprintf("D%d", 3+i), fmt.Sprintf("%d/%d/%d %d:%02d",
+			pair.End.Day(), pair.End.Month(), pair.End.Year(), pair.End.Hour(), pair.End.Minute()))
+		f.SetCellValue(sheetName, fmt.Sprintf("E%d", 3+i), uncovered.Constraint)
+	}
+	end := fmt.Sprintf("E%d", 2+len(m.Uncovered))
+	f.SetCellStyle(sheetName, "B2", end, styleId)
+	enable := true
+	f.AddTable(sheetName, &excelize.Table{
+		Range:             "B2:" + end,
+		Name:              "Uncovered",
+		StyleName:         "TableStyleMedium12",
+		ShowFirstColumn:   false,
+		ShowLastColumn:    false,
+		ShowRowStripes:    &enable,
+		ShowColumnStripes: false,
+	})
+	return true
+}
+
+func drawManifestSheet(f *excelize.File, manifest *manifestRecord) {
+	// create an excel sheet containing the configuration and the provenance of the run
+	sheetName := "Manifest"
+	setView(f, sheetName, 100.0)
+
+	f.SetColWidth(sheetName, "B", "B", 32)
+	f.SetColWidth(sheetName, "C", "C", 80)
+	f.SetRowHeight(sheetName, 2, 30.0)
+	f.SetCellValue(sheetName, "B2", "Setting")
+	f.SetCellValue(sheetName, "C2", "Value")
+
+	styleId, _ := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Size: 12},
+		Alignment: &excelize.Alignment{Horizontal: "left", Vertical: "center", WrapText: true},
+	})
+	rows := manifest.rows()
+	for i, row := range rows {
+		f.SetCellValue(sheetName, fmt.Sprintf("B%d", 3+i), row[0])
+		f.SetCellValue(sheetName, fmt.Sprintf("C%d", 3+i), row[1])
+	}
+	end := fmt.Sprintf("C%d", 2+len(rows))
+	f.SetCellStyle(sheetName, "B2", end, styleId)
+	enable := true
+	f.AddTable(sheetName, &excelize.Table{
+		Range:             "B2:" + end,
+		Name:              "Manifest",
+		StyleName:         "TableStyleMedium16",
+		ShowFirstColumn:   false,
+		ShowLastColumn:    false,
+		ShowRowStripes:    &enable,
+		ShowColumnStripes: false,
+	})
+}
+
+func drawPilotsSheet(f *excelize.File, m *metrics.Metrics) bool {
+	// create an excel sheet containing the statistics of the schedule of each pilot,
+	// as a table that can be sorted and filtered by every column
+	// returns true on success
+	sheetName := "Pilot Statistics"
+	if _, err := f.NewSheet(sheetName); err != nil {
+		fmt.Println(err)
+		return false
+	}
+	setView(f, sheetName, 100.0)
+
+	f.SetColWidth(sheetName, "B", "K", 16.62)
+	f.SetRowHeight(sheetName, 2, 48.0)
+	f.SetCellValue(sheetName, "B2", "Pilot")
+	f.SetCellValue(sheetName, "C2", "Flight Time\n(in minutes)")
+	f.SetCellValue(sheetName, "D2", "Deviation from\naverage workload")
+	f.SetCellValue(sheetName, "E2", "Assigned Pairs")
+	f.SetCellValue(sheetName, "F2", "Min Days Off\nper Timespan")
+	f.SetCellValue(sheetName, "G2", "Average Days Off\nper Timespan")
+	f.SetCellValue(sheetName, "H2", "Excess Rest\n(in hours)")
+	f.SetCellValue(sheetName, "I2", "Longest Work\nStreak (days)")
+	f.SetCellValue(sheetName, "J2", "Days Off Slack")
+	f.SetCellValue(sheetName, "K2", "Rest Slack\n(in minutes)")
+
+	styleId, _ := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Size: 12},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center", WrapText: true},
+	})
+	for i, pilot := range m.Pilots {
+		row := 3 + i
+		f.SetRowHeight(sheetName, row, 20.0)
+		f.SetCellValue(sheetName, fmt.Sprintf("B%d", row), pilot.Pilot)
+		f.SetCellValue(sheetName, fmt.Sprintf("C%d", row), math.Round(pilot.FlightTime))
+		f.SetCellValue(sheetName, fmt.Sprintf("D%d", row), math.Round(pilot.Deviation))
+		f.SetCellValue(sheetName, fmt.Sprintf("E%d", row), pilot.Pairings)
+		f.SetCellValue(sheetName, fmt.Sprintf("F%d", row), pilot.MinDaysOff)
+		f.SetCellValue(sheetName, fmt.Sprintf("G%d", row), math.Round(pilot.AverageDaysOff*100)/100)
+		f.SetCellValue(sheetName, fmt.Sprintf("H%d", row), math.Round(pilot.ExcessRest/60*100)/100)
+		f.SetCellValue(sheetName, fmt.Sprintf("I%d", row), pilot.LongestStreak)
+		f.SetCellValue(sheetName, fmt.Sprintf("J%d", row), pilot.DaysOffSlack)
+		// the rest slack is left empty for the pilots with fewer than two pairings
+		// (a negative slack is a rest shorter than the minimum rest period)
+		if pilot.HasRest {
+			f.SetCellValue(sheetName, fmt.Sprintf("K%d", row), math.Round(pilot.RestSlack))
+		}
+	}
+	end := fmt.Sprintf("K%d", 2+len(m.Pilots))
+	f.SetCellStyle(sheetName, "B2", end, styleId)
+	enable := true
+	f.AddTable(sheetName, &excelize.Table{
+		Range:             "B2:" + end,
+		Name:              "Pilots",
+		StyleName:         "TableStyleMedium20",
+		ShowFirstColumn:   false,
+		ShowLastColumn:    false,
+		ShowRowStripes:    &enable,
+		ShowColumnStripes: false,
+	})
+	return true
+}
+
+func drawIslandsSheet(f *excelize.File, m *metrics.Metrics) bool {
+	// create an excel sheet containing the metrics of each island of the island model
+	// returns true on success
+	sheetName := "Islands"
+	if _, err := f.NewSheet(sheetName); err != nil {
+		fmt.Println(err)
+		return false
+	}
+	setView(f, sheetName, 100.0)
+
+	f.SetColWidth(sheetName, "B", "J", 14.62)
+	f.SetRowHeight(sheetName, 2, 40.0)
+	f.SetCellValue(sheetName, "B2", "Island")
+	f.SetCellValue(sheetName, "C2", "Algorithm")
+	f.SetCellValue(sheetName, "D2", "Seed")
+	f.SetCellValue(sheetName, "E2", "Best Cost")
+	f.SetCellValue(sheetName, "F2", "Valid Solutions")
+	f.SetCellValue(sheetName, "G2", "Unique Solutions")
+	f.SetCellValue(sheetName, "H2", "Jumps")
+	f.SetCellValue(sheetName, "I2", "Similarity")
+	f.SetCellValue(sheetName, "J2", "Immigrants")
+
+	styleId, _ := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Size: 12},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center", WrapText: true},
+	})
+	for i, island := range m.Islands {
+		row := 3 + i
+		f.SetRowHeight(sheetName, row, 20.0)
+		f.SetCellValue(sheetName, fmt.Sprintf("B%d", row), i+1)
+		f.SetCellValue(sheetName, fmt.Sprintf("C%d", row), island.Algorithm)
+		f.SetCellValue(sheetName, fmt.Sprintf("D%d", row), strconv.FormatInt(island.Mtr.Seed, 10))
+		f.SetCellValue(sheetName, fmt.Sprintf("E%d", row), math.Round(island.Mtr.GlobalBestSolutionCost))
+		f.SetCellValue(sheetName, fmt.Sprintf("F%d", row), island.Mtr.ValidSolutions)
+		f.SetCellValue(sheetName, fmt.Sprintf("G%d", row), island.Mtr.UniqueCount)
+		f.SetCellValue(sheetName, fmt.Sprintf("H%d", row), island.Mtr.Jumps)
+		f.SetCellValue(sheetName, fmt.Sprintf("I%d", row), fmt.Sprintf("%.2f%%", island.Mtr.AverageSimilarity))
+		f.SetCellValue(sheetName, fmt.Sprintf("J%d", row), island.Immigrants)
+	}
+	end := fmt.Sprintf("J%d", 2+len(m.Islands))
+	f.SetCellStyle(sheetName, "B2", end, styleId)
+	enable := true
+	f.AddTable(sheetName, &excelize.Table{
+		Range:             "B2:" + end,
+		Name:              "Islands",
+		StyleName:         "TableStyleMedium20",
+		ShowFirstColumn:   false,
+		ShowLastColumn:    false,
+		ShowRowStripes:    &enable,
+		ShowColumnStripes: false,
+	})
+	return true
+}
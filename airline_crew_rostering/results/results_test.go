@@ -0,0 +1,195 @@
+package results_test
+
+import (
+	"encoding/csv"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"go-airline-crew-rostering/airline"
+	"go-airline-crew-rostering/checkpoint"
+	"go-airline-crew-rostering/comparison"
+	"go-airline-crew-rostering/fitness"
+	"go-airline-crew-rostering/graph"
+	"go-airline-crew-rostering/input"
+	"go-airline-crew-rostering/metrics"
+	"go-airline-crew-rostering/problem"
+	"go-airline-crew-rostering/results"
+
+	"golang.org/x/exp/slices"
+)
+
+func run(t *testing.T, seed int64, pilots string) (*input.ArgumentCollection, *airline.Airline) {
+	// writes the results of a roster built with "seed" in every format that stores a roster
+	// returns the arguments of the run and its airline
+	arguments := os.Args
+	defer func() { os.Args = arguments }()
+	os.Args = []string{"main", "multiCSO", "-f", "../Pairings.csv", "--startDate", "2011-11-1", "--endDate", "2011-11-15", "-p", pilots,
+		"-o", t.TempDir(), "--format", "xlsx", "--format", "json", "--format", "csv", "--format", "ics", "--calendar", "combined"}
+	args := input.SetUpParser()
+	if args == nil {
+		t.Fatal("invalid arguments")
+	}
+	al := input.ReadInstance(*args.Filename, args.StartDate, args.EndDate, *args.Pilots)
+	pairGraph := new(graph.Graph)
+	pairGraph.Initialization(1)
+	pairGraph.Populate(al.PairsArray)
+	al.PilotsArray, _, _ = problem.ConstructSolution(al, pairGraph, 0, rand.New(rand.NewSource(seed)))
+
+	objective := fitness.DefaultObjective()
+	_, cost := objective.Evaluate(al.PilotsArray, al)
+	cost *= objective.UnitCost
+	m := new(metrics.Metrics)
+	m.Initialization(1, objective.UnitCost)
+	m.Seed = seed
+	m.GlobalBestSolutionCost = cost
+	m.IterBestCost = []float64{cost}
+	m.IterWorstCost = []float64{cost}
+	m.IterAverageCost = []float64{cost}
+	m.Fairness = metrics.FairnessIndicators(al.PilotsArray, al)
+	m.Pilots = metrics.PilotStatisticsOf(al.PilotsArray, al)
+	m.Uncovered = problem.UncoveredPairs(al, al.PilotsArray)
+	results.PrintResults(m, args, al, objective)
+	return args, al
+}
+
+func TestRosterRoundTrip(t *testing.T) {
+	// the roster read from the xlsx and the json files is the roster that was written
+	tests := []struct {
+		name   string
+		seed   int64
+		pilots string
+	}{
+		{"covered", 1, "45"},
+		{"uncovered pairings", 2, "20"},
+	}
+	for _, test := range tests {
+		args, al := run(t, test.seed, test.pilots)
+		expected := checkpoint.EncodeSolution(al.PilotsArray)
+		for _, extension := range []string{"xlsx", "json"} {
+			fileName := results.FileName(*args.ResultsFile, extension)
+			roster, violations := input.ValidateRoster(fileName, input.ReadInstance(*args.Filename, args.StartDate, args.EndDate, *args.Pilots))
+			if len(violations) > 0 {
+				t.Errorf("%s, %s: %d assignments are ignored, the first breaks %q", test.name, extension, len(violations), violations[0].Rule)
+			}
+			got := checkpoint.EncodeSolution(roster)
+			if len(got) != len(expected) {
+				t.Errorf("%s, %s: %d pilots, expected %d", test.name, extension, len(got), len(expected))
+				continue
+			}
+			for pilot := range expected {
+				if !slices.Equal(got[pilot], expected[pilot]) {
+					t.Errorf("%s, %s: pilot %d has the pairings %v, expected %v", test.name, extension, pilot, got[pilot], expected[pilot])
+				}
+			}
+		}
+	}
+}
+
+func TestCalendarLines(t *testing.T) {
+	// the lines of the calendar are folded at 75 octets without splitting a character
+	args, _ := run(t, 1, "45")
+	content, err := os.ReadFile(results.FileName(*args.ResultsFile, "ics"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := string(content)
+	if !strings.HasPrefix(text, "BEGIN:VCALENDAR\r\n") || !strings.HasSuffix(text, "END:VCALENDAR\r\n") {
+		t.Errorf("%s is not a calendar", filepath.Base(results.FileName(*args.ResultsFile, "ics")))
+	}
+	events := 0
+	for i, line := range strings.Split(strings.TrimSuffix(text, "\r\n"), "\r\n") {
+		if len(line) > 75 || !utf8.ValidString(line) || strings.Contains(line, "\n") {
+			t.Errorf("line %d is not a valid content line: %q", i+1, line)
+		}
+		if line == "BEGIN:VEVENT" {
+			events++
+		}
+	}
+	if events == 0 {
+		t.Error("the calendar has no events")
+	}
+}
+
+func TestFileName(t *testing.T) {
+	tests := []struct {
+		resultsFile string
+		extension   string
+		fileName    string
+	}{
+		{"output/Output.xlsx", "json", "output/Output.json"},
+		{"output/Output.xlsx", "xlsx", "output/Output.xlsx"},
+		{"output/run", "csv", "output/run.csv"},
+		{"output.d/run.v2.xlsx", "ics", "output.d/run.v2.ics"},
+	}
+	for _, test := range tests {
+		if fileName := results.FileName(test.resultsFile, test.extension); fileName != test.fileName {
+			t.Errorf("FileName(%q, %q) = %q, expected %q", test.resultsFile, test.extension, fileName, test.fileName)
+		}
+	}
+}
+
+func manifest(t *testing.T, resultsFile string) map[string]string {
+	// returns the settings of the csv manifest of "resultsFile"
+	// (every setting has a single row)
+	file, err := os.Open(strings.TrimSuffix(results.FileName(resultsFile, "csv"), ".csv") + "_manifest.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	settings := map[string]string{}
+	for _, record := range records[1:] {
+		if _, repeated := settings[record[0]]; repeated {
+			t.Errorf("the setting %s has several rows", record[0])
+		}
+		settings[record[0]] = record[1]
+	}
+	return settings
+}
+
+func TestManifest(t *testing.T) {
+	// the manifest stores the rules of the schedule
+	args, al := run(t, 1, "45")
+	settings := manifest(t, *args.ResultsFile)
+	rules := map[string]int{"rules.restPeriod": al.RestPeriod, "rules.timespan": al.Timespan(), "rules.minimumDaysOff": al.MinimumDaysOff()}
+	for setting, value := range rules {
+		if settings[setting] != strconv.Itoa(value) {
+			t.Errorf("%s = %q, expected %d", setting, settings[setting], value)
+		}
+	}
+
+	// every file of a repeated argument has its own setting
+	directory := t.TempDir()
+	experiments := []string{filepath.Join(directory, "a.json"), filepath.Join(directory, "b.json")}
+	for _, fileName := range experiments {
+		if err := os.WriteFile(fileName, []byte("{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	arguments := os.Args
+	defer func() { os.Args = arguments }()
+	os.Args = []string{"main", "compare", "--experiments", experiments[0], "--experiments", experiments[1], "-o", directory, "--format", "csv"}
+	args = input.SetUpParser()
+	if args == nil {
+		t.Fatal("invalid arguments")
+	}
+	results.PrintComparison(new(comparison.Comparison).Initialization("cost", 0.05), args)
+	settings = manifest(t, *args.ResultsFile)
+	for i, fileName := range experiments {
+		setting := "inputs.experiments[" + strconv.Itoa(i) + "]"
+		if settings[setting] != fileName || settings[setting+".sha256"] == "" {
+			t.Errorf("%s = %q with the hash %q, expected %q", setting, settings[setting], settings[setting+".sha256"], fileName)
+		}
+	}
+	if _, rules := settings["rules.timespan"]; rules {
+		t.Error("the comparison has the rules of a schedule")
+	}
+}
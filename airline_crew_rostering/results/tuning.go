@@ -0,0 +1,233 @@
+package results
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-airline-crew-rostering/input"
+	"go-airline-crew-rostering/tuning"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func (writer *XLSXWriter) WriteTuning(fileName string, tuner *tuning.Tuner, args *input.ArgumentCollection) {
+	// Creates an excel file to store the best configuration of the tuning,
+	// along with the results of every configuration raced
+	f := excelize.NewFile()
+	defer func() {
+		if err := f.Close(); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	docProperties, _ := f.GetDocProps()
+	docProperties.Language = "en-UK"
+	f.SetDocProps(docProperties)
+
+	f.SetDefaultFont("Arial")
+
+	sheetName := "Tuning"
+	manifestSheetName := "Manifest"
+	f.SetSheetName("Sheet1", sheetName)
+	if _, err := f.NewSheet(manifestSheetName); err != nil {
+		fmt.Println(err)
+		return
+	}
+	drawTuningSheet(f, sheetName, tuner, args)
+	drawManifestSheet(f, newManifest(tuner.Seed, args, nil))
+
+	if err := f.SaveAs(fileName); err != nil {
+		fmt.Println(err)
+	}
+}
+
+// Parameter tuning, as stored in the json file
+type tuningRecord struct {
+	Algorithm      string                 `json:"algorithm"` // algorithm whose parameters are tuned
+	Instances      []*instanceRecord      `json:"instances"`
+	Parameters     []string               `json:"parameters"` // names of the parameters, in the order of the values of the configurations
+	Runs           int                    `json:"runs"`       // runs of the algorithm executed
+	Budget         int                    `json:"budget"`     // maximum number of runs of the algorithm
+	Best           int                    `json:"best"`       // id of the best configuration
+	Blocks         int                    `json:"blocks"`     // blocks (instance and seed) of the last race
+	Statistic      float64                `json:"statistic"`  // Friedman statistic of the last race
+	PValue         float64                `json:"pValue"`     // p-value of the Friedman test of the last race
+	Configurations []*configurationResult `json:"configurations"`
+	Manifest       *manifestRecord        `json:"manifest"` // configuration and provenance of the tuning
+}
+
+// Instance of the tuning
+type instanceRecord struct {
+	Filename  string    `json:"filename"`
+	StartDate time.Time `json:"startDate"`
+	EndDate   time.Time `json:"endDate"`
+	Pilots    int       `json:"pilots"`
+}
+
+// Configuration raced by the tuning, along with its results in its last race
+type configurationResult struct {
+	Id        int       `json:"id"`
+	Iteration int       `json:"iteration"` // iteration of the tuning that created the configuration
+	Values    []float64 `json:"values"`    // value of each parameter
+	Costs     []float64 `json:"costs"`     // best cost on each block of the last race
+	MeanCost  float64   `json:"meanCost"`
+	MeanRank  float64   `json:"meanRank"`
+	PValue    float64   `json:"pValue"` // p-value of the difference from the best configuration
+	Status    string    `json:"status"` // "Best", "Elite", "Survived" or "Eliminated"
+}
+
+func newTuningRecord(tuner *tuning.Tuner, args *input.ArgumentCollection) *tuningRecord {
+	// returns the tuning, as stored by the json and the csv writers
+	record := &tuningRecord{
+		Algorithm:      *args.TuneType,
+		Instances:      []*instanceRecord{},
+		Parameters:     []string{},
+		Runs:           tuner.Runs,
+		Budget:         tuner.Budget,
+		Best:           tuner.Best.Id,
+		Blocks:         tuner.Blocks,
+		Statistic:      tuner.Statistic,
+		PValue:         tuner.PValue,
+		Configurations: []*configurationResult{},
+		Manifest:       newManifest(tuner.Seed, args, nil),
+	}
+	for _, instance := range args.Instances {
+		record.Instances = append(record.Instances, &instanceRecord{instance.Filename, instance.StartDate, instance.EndDate, instance.Pilots})
+	}
+	for _, parameter := range tuner.Parameters {
+		record.Parameters = append(record.Parameters, parameter.Name)
+	}
+	for _, c := range tuner.Configurations {
+		record.Configurations = append(record.Configurations, &configurationResult{c.Id, c.Iteration, c.Values, c.Costs,
+			c.MeanCost(), c.MeanRank, c.PValue, configurationStatus(tuner, c)})
+	}
+	return record
+}
+
+func configurationStatus(tuner *tuning.Tuner, c *tuning.Configuration) string {
+	// returns the status of a configuration at the end of the tuning
+	if c == tuner.Best {
+		return "Best"
+	} else if c.Eliminated {
+		return "Eliminated"
+	}
+	for _, elite := range tuner.Elites {
+		if c == elite {
+			return "Elite"
+		}
+	}
+	return "Survived"
+}
+
+func (writer *JSONWriter) WriteTuning(fileName string, tuner *tuning.Tuner, args *input.ArgumentCollection) {
+	// Create a json file with the tuning and every configuration raced
+	writeJSON(fileName, newTuningRecord(tuner, args))
+}
+
+func (writer *CSVWriter) WriteTuning(fileName string, tuner *tuning.Tuner, args *input.ArgumentCollection) {
+	// Create a csv file with every configuration raced (one row per configuration)
+	// and the manifest in a file with the same name and a suffix
+	record := newTuningRecord(tuner, args)
+	header := append([]string{"configuration", "iteration"}, record.Parameters...)
+	rows := [][]string{append(header, "blocks", "meanCost", "meanRank", "pValue", "status")}
+	for _, c := range record.Configurations {
+		row := []string{strconv.Itoa(c.Id), strconv.Itoa(c.Iteration)}
+		for _, value := range c.Values {
+			row = append(row, formatFloat(value))
+		}
+		rows = append(rows, append(row, strconv.Itoa(len(c.Costs)), formatFloat(c.MeanCost), formatFloat(c.MeanRank),
+			formatFloat(c.PValue), c.Status))
+	}
+	writeCSV(fileName, rows)
+	writeManifestCSV(strings.TrimSuffix(fileName, ".csv")+"_manifest.csv", record.Manifest)
+}
+
+func drawTuningSheet(f *excelize.File, sheetName string, tuner *tuning.Tuner, args *input.ArgumentCollection) {
+	// create an excel sheet containing a summary of the tuning
+	// and the results of every configuration in its last race
+	setView(f, sheetName, 100.0)
+
+	for i := 5; i <= 13; i++ {
+		f.SetRowHeight(sheetName, i, 30)
+	}
+	instances := ""
+	for i, instance := range args.Instances {
+		if i > 0 {
+			instances += "\n"
+		}
+		instances += fmt.Sprintf("%s (%s - %s, %d pilots)", instance.Filename, instance.StartDate.Format("02/01/2006"), instance.EndDate.Format("02/01/2006"), instance.Pilots)
+	}
+	best := tuner.Best
+
+	f.SetCellValue(sheetName, "B2", "Parameter Tuning")
+	f.SetCellValue(sheetName, "B5", "Algorithm")
+	f.SetCellValue(sheetName, "B6", "Instances")
+	f.SetCellValue(sheetName, "B7", "Runs")
+	f.SetCellValue(sheetName, "B8", "Configurations")
+	f.SetCellValue(sheetName, "B9", "Best Configuration")
+	f.SetCellValue(sheetName, "B10", "Mean Cost")
+	f.SetCellValue(sheetName, "B11", "Blocks")
+	f.SetCellValue(sheetName, "B12", "Friedman Statistic")
+	f.SetCellValue(sheetName, "B13", "Friedman p-value")
+	f.SetCellValue(sheetName, "D5", *args.TuneType)
+	f.SetCellValue(sheetName, "D6", instances)
+	f.SetCellValue(sheetName, "D7", fmt.Sprintf("%d of %d", tuner.Runs, tuner.Budget))
+	f.SetCellValue(sheetName, "D8", len(tuner.Configurations))
+	f.SetCellValue(sheetName, "D9", tuner.Format(best))
+	f.SetCellValue(sheetName, "D10", math.Round(best.MeanCost()))
+	f.SetCellValue(sheetName, "D11", tuner.Blocks)
+	f.SetCellValue(sheetName, "D12", math.Round(tuner.Statistic*1000)/1000)
+	f.SetCellValue(sheetName, "D13", math.Round(tuner.PValue*10000)/10000)
+	drawVerticalTable(f, sheetName, "B2", 9, "7266A4", "E5E0EC")
+	f.SetColWidth(sheetName, "D", "E", 25)
+	f.SetRowHeight(sheetName, 6, math.Max(30, float64(15*len(args.Instances))))
+
+	headerStyleId, _ := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Size: 12, Color: "FFFFFF", Bold: true},
+		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"7266A4"}},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center", WrapText: true},
+	})
+	cellStyleId, _ := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Size: 12},
+		Alignment: &excelize.Alignment{Horizontal: "center", Vertical: "center"},
+	})
+
+	// the configurations, along with their results in their last race
+	headers := []string{"Configuration", "Iteration"}
+	for _, parameter := range tuner.Parameters {
+		headers = append(headers, parameter.Name)
+	}
+	headers = append(headers, "Blocks", "Mean Cost", "Mean Rank", "p-value", "Status")
+	for i, header := range headers {
+		cell, _ := excelize.CoordinatesToCellName(7+i, 2)
+		f.SetCellValue(sheetName, cell, header)
+	}
+	last, _ := excelize.ColumnNumberToName(6 + len(headers))
+	f.SetColWidth(sheetName, "G", last, 15)
+	f.SetCellStyle(sheetName, "G2", last+"2", headerStyleId)
+
+	for i, c := range tuner.Configurations {
+		row := 3 + i
+		status := configurationStatus(tuner, c)
+		values := []interface{}{c.Id, c.Iteration}
+		for _, value := range c.Values {
+			values = append(values, value)
+		}
+		values = append(values, len(c.Costs), math.Round(c.MeanCost()), math.Round(c.MeanRank*100)/100, math.Round(c.PValue*10000)/10000, status)
+		for j, value := range values {
+			cell, _ := excelize.CoordinatesToCellName(7+j, row)
+			f.SetCellValue(sheetName, cell, value)
+		}
+		f.SetCellStyle(sheetName, fmt.Sprintf("G%d", row), fmt.Sprintf("%s%d", last, row), cellStyleId)
+	}
+	enable := true
+	f.AddTable(sheetName, &excelize.Table{
+		Range:          fmt.Sprintf("G2:%s%d", last, 2+len(tuner.Configurations)),
+		Name:           "Configurations",
+		StyleName:      "TableStyleMedium12",
+		ShowRowStripes: &enable,
+	})
+}
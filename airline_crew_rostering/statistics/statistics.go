@@ -0,0 +1,348 @@
+package statistics
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Error of the tests of a table of results without blocks or treatments
+var ErrNoResults = errors.New("statistics: no results to test")
+
+func Ranks(values []float64) []float64 {
+	// returns the rank of each value, from 1 for the smallest value
+	// to len(values) for the biggest (tied values share their average rank)
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i int, j int) bool {
+		return values[order[i]] < values[order[j]]
+	})
+	ranks := make([]float64, len(values))
+	for i := 0; i < len(order); {
+		j := i
+		for j+1 < len(order) && values[order[j+1]] == values[order[i]] {
+			j++
+		}
+		for k := i; k <= j; k++ {
+			ranks[order[k]] = float64(i+j)/2 + 1
+		}
+		i = j + 1
+	}
+	return ranks
+}
+
+func checkResults(results [][]float64) error {
+	// returns an error if "results" has no blocks, no treatments
+	// or blocks with a different number of treatments
+	if len(results) == 0 || len(results[0]) == 0 {
+		return ErrNoResults
+	}
+	for b, block := range results {
+		if len(block) != len(results[0]) {
+			return fmt.Errorf("statistics: block %d has %d results, expected %d", b, len(block), len(results[0]))
+		}
+	}
+	return nil
+}
+
+func Friedman(results [][]float64) (float64, float64, []float64, error) {
+	// Friedman test of "results", where results[b][j] is the result of treatment j
+	// on block b (every block is ranked separately)
+	// returns the statistic of the test, its p-value and the rank sum of each treatment
+	if err := checkResults(results); err != nil {
+		return 0, 1, nil, err
+	}
+	blocks := len(results)
+	treatments := len(results[0])
+	rankSums := make([]float64, treatments)
+	squaredRanks := 0.0
+	for _, block := range results {
+		for j, rank := range Ranks(block) {
+			rankSums[j] += rank
+			squaredRanks += rank * rank
+		}
+	}
+	statistic, pValue := friedmanStatistic(rankSums, squaredRanks, blocks, treatments)
+	return statistic, pValue, rankSums, nil
+}
+
+func friedmanStatistic(rankSums []float64, squaredRanks float64, blocks int, treatments int) (float64, float64) {
+	// returns the Friedman statistic (corrected for ties) and its p-value
+	b, k := float64(blocks), float64(treatments)
+	C := b * k * (k + 1) * (k + 1) / 4
+	if squaredRanks-C <= 0 || treatments < 2 {
+		// all treatments have the same rank in every block
+		return 0, 1
+	}
+	sum := 0.0
+	for _, R := range rankSums {
+		sum += (R - b*(k+1)/2) * (R - b*(k+1)/2)
+	}
+	statistic := (k - 1) * sum / (squaredRanks - C)
+	return statistic, 1 - ChiSquaredCDF(statistic, k-1)
+}
+
+func Conover(results [][]float64, treatment int) ([]float64, error) {
+	// Post-hoc test of the Friedman test (Conover, 1999)
+	// returns the p-value of the difference between "treatment" and every treatment
+	if err := checkResults(results); err != nil {
+		return nil, err
+	}
+	if treatment < 0 || treatment >= len(results[0]) {
+		return nil, fmt.Errorf("statistics: treatment %d out of %d", treatment, len(results[0]))
+	}
+	blocks := len(results)
+	treatments := len(results[0])
+	rankSums := make([]float64, treatments)
+	squaredRanks := 0.0
+	for _, block := range results {
+		for j, rank := range Ranks(block) {
+			rankSums[j] += rank
+			squaredRanks += rank * rank
+		}
+	}
+	pValues := make([]float64, treatments)
+	b, k := float64(blocks), float64(treatments)
+	C := b * k * (k + 1) * (k + 1) / 4
+	statistic, _ := friedmanStatistic(rankSums, squaredRanks, blocks, treatments)
+	denominator := 2 * b * (1 - statistic/(b*(k-1))) * (squaredRanks - C) / ((b - 1) * (k - 1))
+	for j := range pValues {
+		if j == treatment || blocks < 2 || denominator <= 0 {
+			pValues[j] = 1
+			continue
+		}
+		t := math.Abs(rankSums[j]-rankSums[treatment]) / math.Sqrt(denominator)
+		pValues[j] = 2 * (1 - StudentTCDF(t, (b-1)*(k-1)))
+	}
+	return pValues, nil
+}
+
+func ChiSquaredCDF(x float64, degrees float64) float64 {
+	// returns the cumulative distribution function of the chi-squared distribution
+	if x <= 0 {
+		return 0
+	}
+	return regularizedGamma(degrees/2, x/2)
+}
+
+func StudentTCDF(t float64, degrees float64) float64 {
+	// returns the cumulative distribution function of Student's t distribution
+	x := degrees / (degrees + t*t)
+	tail := 0.5 * regularizedBeta(x, degrees/2, 0.5)
+	if t > 0 {
+		return 1 - tail
+	}
+	return tail
+}
+
+func NormalCDF(z float64) float64 {
+	// returns the cumulative distribution function of the standard normal distribution
+	return 0.5 * math.Erfc(-z/math.Sqrt2)
+}
+
+func regularizedGamma(a float64, x float64) float64 {
+	// returns the regularized lower incomplete gamma function P(a, x)
+	// (series for x < a+1, continued fraction otherwise)
+	lgamma, _ := math.Lgamma(a)
+	if x < a+1 {
+		sum, term := 1/a, 1/a
+		for n := 1; n < 500; n++ {
+			term *= x / (a + float64(n))
+			sum += term
+			if math.Abs(term) < math.Abs(sum)*1e-15 {
+				break
+			}
+		}
+		return sum * math.Exp(-x+a*math.Log(x)-lgamma)
+	}
+	b := x + 1 - a
+	c := 1 / 1e-300
+	d := 1 / b
+	h := d
+	for n := 1; n < 500; n++ {
+		an := -float64(n) * (float64(n) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < 1e-300 {
+			d = 1e-300
+		}
+		c = b + an/c
+		if math.Abs(c) < 1e-300 {
+			c = 1e-300
+		}
+		d = 1 / d
+		h *= d * c
+		if math.Abs(d*c-1) < 1e-15 {
+			break
+		}
+	}
+	return 1 - math.Exp(-x+a*math.Log(x)-lgamma)*h
+}
+
+func regularizedBeta(x float64, a float64, b float64) float64 {
+	// returns the regularized incomplete beta function I_x(a, b)
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	lab, _ := math.Lgamma(a + b)
+	front := math.Exp(lab - la - lb + a*math.Log(x) + b*math.Log(1-x))
+	// the continued fraction converges quickly for x < (a+1)/(a+b+2)
+	if x > (a+1)/(a+b+2) {
+		return 1 - front*betaFraction(1-x, b, a)/b
+	}
+	return front * betaFraction(x, a, b) / a
+}
+
+func betaFraction(x float64, a float64, b float64) float64 {
+	// continued fraction of the incomplete beta function (modified Lentz's method)
+	c, d := 1.0, 1-(a+b)*x/(a+1)
+	if math.Abs(d) < 1e-300 {
+		d = 1e-300
+	}
+	d = 1 / d
+	h := d
+	for m := 1; m < 500; m++ {
+		fm := float64(m)
+		for _, numerator := range []float64{
+			fm * (b - fm) * x / ((a + 2*fm - 1) * (a + 2*fm)),
+			-(a + fm) * (a + b + fm) * x / ((a + 2*fm) * (a + 2*fm + 1)),
+		} {
+			d = 1 + numerator*d
+			if math.Abs(d) < 1e-300 {
+				d = 1e-300
+			}
+			c = 1 + numerator/c
+			if math.Abs(c) < 1e-300 {
+				c = 1e-300
+			}
+			d = 1 / d
+			h *= d * c
+		}
+		if math.Abs(d*c-1) < 1e-15 {
+			break
+		}
+	}
+	return h
+}
+
+func MannWhitney(a []float64, b []float64) (float64, float64) {
+	// Wilcoxon rank-sum (Mann-Whitney U) test of the samples "a" and "b"
+	// returns the U statistic of "a" and the two-sided p-value (exact for small
+	// samples without ties, normal approximation with tie correction otherwise)
+	n1, n2 := float64(len(a)), float64(len(b))
+	ranks := Ranks(append(append([]float64{}, a...), b...))
+	rankSum := 0.0
+	for _, rank := range ranks[:len(a)] {
+		rankSum += rank
+	}
+	U := rankSum - n1*(n1+1)/2
+
+	// count the groups of tied values
+	counts := map[float64]float64{}
+	for _, rank := range ranks {
+		counts[rank]++
+	}
+	ties := 0.0
+	for _, t := range counts {
+		ties += t*t*t - t
+	}
+	if ties == 0 && len(a)+len(b) <= 50 {
+		return U, exactMannWhitney(len(a), len(b), U)
+	}
+
+	n := n1 + n2
+	variance := n1 * n2 / 12 * ((n + 1) - ties/(n*(n-1)))
+	if variance <= 0 {
+		// all values are equal
+		return U, 1
+	}
+	z := math.Max(math.Abs(U-n1*n2/2)-0.5, 0) / math.Sqrt(variance)
+	return U, math.Min(1, 2*(1-NormalCDF(z)))
+}
+
+func exactMannWhitney(n1 int, n2 int, U float64) float64 {
+	// returns the two-sided p-value of U using the exact distribution of the
+	// statistic, where ways[i][j][u] is the number of orderings of i values of the
+	// first sample and j values of the second one whose statistic is u
+	ways := make([][][]float64, n1+1)
+	for i := range ways {
+		ways[i] = make([][]float64, n2+1)
+		for j := range ways[i] {
+			ways[i][j] = make([]float64, i*j+1)
+			for u := range ways[i][j] {
+				if i == 0 || j == 0 {
+					ways[i][j][u] = 1
+					continue
+				}
+				// the biggest value belongs either to the first sample (it beats the j values)
+				// or to the second one
+				if u-j >= 0 {
+					ways[i][j][u] += ways[i-1][j][u-j]
+				}
+				if u <= i*(j-1) {
+					ways[i][j][u] += ways[i][j-1][u]
+				}
+			}
+		}
+	}
+	total, below, above := 0.0, 0.0, 0.0
+	for u, count := range ways[n1][n2] {
+		total += count
+		if float64(u) <= U {
+			below += count
+		}
+		if float64(u) >= U {
+			above += count
+		}
+	}
+	return math.Min(1, 2*math.Min(below, above)/total)
+}
+
+func VarghaDelaney(a []float64, b []float64) float64 {
+	// returns the Vargha-Delaney A12 effect size, the probability that a value
+	// of "a" is bigger than a value of "b" (ties count as half)
+	wins := 0.0
+	for _, x := range a {
+		for _, y := range b {
+			if x > y {
+				wins++
+			} else if x == y {
+				wins += 0.5
+			}
+		}
+	}
+	return wins / float64(len(a)*len(b))
+}
+
+func CliffDelta(a []float64, b []float64) (float64, string) {
+	// returns Cliff's delta effect size of "a" over "b" and its magnitude
+	// (thresholds of Romano et al., 2006)
+	delta := 2*VarghaDelaney(a, b) - 1
+	magnitude := "large"
+	if math.Abs(delta) < 0.147 {
+		magnitude = "negligible"
+	} else if math.Abs(delta) < 0.33 {
+		magnitude = "small"
+	} else if math.Abs(delta) < 0.474 {
+		magnitude = "medium"
+	}
+	return delta, magnitude
+}
+
+func Median(values []float64) float64 {
+	// returns the median of "values"
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+	if len(sorted)%2 == 1 {
+		return sorted[len(sorted)/2]
+	}
+	return (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+}
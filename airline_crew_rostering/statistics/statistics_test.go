@@ -0,0 +1,220 @@
+package statistics_test
+
+import (
+	"math"
+	"testing"
+
+	"go-airline-crew-rostering/statistics"
+)
+
+// times of the RoundingTimes example of R's friedman.test (Hollander & Wolfe, 1973):
+// 22 players (blocks) running around first base with 3 methods (treatments)
+var roundingTimes = [][]float64{
+	{5.40, 5.50, 5.55}, {5.85, 5.70, 5.75}, {5.20, 5.60, 5.50}, {5.55, 5.50, 5.40},
+	{5.90, 5.85, 5.70}, {5.45, 5.55, 5.60}, {5.40, 5.40, 5.35}, {5.45, 5.50, 5.35},
+	{5.25, 5.15, 5.00}, {5.85, 5.80, 5.70}, {5.25, 5.20, 5.10}, {5.65, 5.55, 5.45},
+	{5.60, 5.35, 5.45}, {5.05, 5.00, 4.95}, {5.50, 5.50, 5.40}, {5.45, 5.55, 5.50},
+	{5.55, 5.55, 5.35}, {5.45, 5.50, 5.55}, {5.50, 5.45, 5.25}, {5.65, 5.60, 5.40},
+	{5.70, 5.65, 5.55}, {6.30, 6.30, 6.25},
+}
+
+func equal(a []float64, b []float64, tolerance float64) bool {
+	// returns true if the values of "a" and "b" differ by less than "tolerance"
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > tolerance {
+			return false
+		}
+	}
+	return true
+}
+
+func TestRanks(t *testing.T) {
+	// tied values share their average rank
+	tests := []struct {
+		values []float64
+		ranks  []float64
+	}{
+		{[]float64{}, []float64{}},
+		{[]float64{3, 1, 2}, []float64{3, 1, 2}},
+		{[]float64{2, 2, 1}, []float64{2.5, 2.5, 1}},
+		{[]float64{5, 5, 5, 5}, []float64{2.5, 2.5, 2.5, 2.5}},
+		{[]float64{1, 3, 3, 3, 0}, []float64{2, 4, 4, 4, 1}},
+	}
+	for _, test := range tests {
+		if ranks := statistics.Ranks(test.values); !equal(ranks, test.ranks, 0) {
+			t.Errorf("Ranks(%v) = %v, expected %v", test.values, ranks, test.ranks)
+		}
+	}
+}
+
+func TestFriedman(t *testing.T) {
+	tests := []struct {
+		name      string
+		results   [][]float64
+		statistic float64
+		pValue    float64
+		rankSums  []float64
+		tolerance float64
+	}{
+		// friedman.test(RoundingTimes): chi-squared = 11.143, df = 2, p-value = 0.003805
+		{"RoundingTimes", roundingTimes, 11.143, 0.003805, []float64{53, 47, 32}, 5e-4},
+		// T = 2*6/4 = 3 and P(chi-squared(2) > 3) = exp(-3/2)
+		{"two blocks", [][]float64{{1, 2, 3}, {1, 3, 2}}, 3, math.Exp(-1.5), []float64{2, 5, 5}, 1e-9},
+		// the same treatment is always best: T = b*(k-1) = 3 and P(chi-squared(1) > 3) = erfc(sqrt(3/2))
+		{"same order", [][]float64{{1, 2}, {3, 4}, {5, 6}}, 3, math.Erfc(math.Sqrt(1.5)), []float64{3, 6}, 1e-9},
+		{"all ties", [][]float64{{1, 1, 1}, {2, 2, 2}}, 0, 1, []float64{4, 4, 4}, 1e-9},
+	}
+	for _, test := range tests {
+		statistic, pValue, rankSums, err := statistics.Friedman(test.results)
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if !equal(rankSums, test.rankSums, 0) {
+			t.Errorf("%s: rank sums = %v, expected %v", test.name, rankSums, test.rankSums)
+		}
+		if math.Abs(statistic-test.statistic) > test.tolerance*test.statistic+1e-9 {
+			t.Errorf("%s: statistic = %v, expected %v", test.name, statistic, test.statistic)
+		}
+		if math.Abs(pValue-test.pValue) > test.tolerance*test.pValue+1e-9 {
+			t.Errorf("%s: p-value = %v, expected %v", test.name, pValue, test.pValue)
+		}
+	}
+}
+
+func TestConover(t *testing.T) {
+	// with two blocks and three treatments the t statistic has 2 degrees of freedom,
+	// whose distribution function is 1/2 + t/(2*sqrt(2+t^2)), so t = 3/sqrt(2)
+	// (rank sums 2 and 5, denominator 2) has the two-sided p-value 1 - 3/sqrt(13)
+	tests := []struct {
+		name      string
+		results   [][]float64
+		treatment int
+		pValues   []float64
+	}{
+		{"two blocks", [][]float64{{1, 2, 3}, {1, 3, 2}}, 0, []float64{1, 1 - 3/math.Sqrt(13), 1 - 3/math.Sqrt(13)}},
+		{"same rank sums", [][]float64{{1, 2, 3}, {1, 3, 2}}, 1, []float64{1 - 3/math.Sqrt(13), 1, 1}},
+		{"all ties", [][]float64{{1, 1, 1}, {2, 2, 2}}, 2, []float64{1, 1, 1}},
+		{"one block", [][]float64{{1, 2, 3}}, 0, []float64{1, 1, 1}},
+	}
+	for _, test := range tests {
+		if pValues, err := statistics.Conover(test.results, test.treatment); err != nil || !equal(pValues, test.pValues, 1e-9) {
+			t.Errorf("%s: Conover(%d) = %v, expected %v", test.name, test.treatment, pValues, test.pValues)
+		}
+	}
+
+	// the p-values agree with the order of the rank sums of RoundingTimes (53, 47, 32)
+	pValues, err := statistics.Conover(roundingTimes, 2)
+	if err != nil || pValues[0] >= pValues[1] || pValues[1] >= 0.05 || pValues[2] != 1 {
+		t.Errorf("RoundingTimes: Conover(2) = %v", pValues)
+	}
+}
+
+func TestInvalidResults(t *testing.T) {
+	// the tests reject tables without blocks or treatments and blocks of different lengths
+	tests := []struct {
+		name      string
+		results   [][]float64
+		treatment int
+	}{
+		{"no blocks", [][]float64{}, 0},
+		{"no treatments", [][]float64{{}, {}}, 0},
+		{"different lengths", [][]float64{{1, 2, 3}, {1, 2}}, 0},
+	}
+	for _, test := range tests {
+		if _, _, _, err := statistics.Friedman(test.results); err == nil {
+			t.Errorf("%s: Friedman accepted %v", test.name, test.results)
+		}
+		if _, err := statistics.Conover(test.results, test.treatment); err == nil {
+			t.Errorf("%s: Conover accepted %v", test.name, test.results)
+		}
+	}
+	if _, err := statistics.Conover([][]float64{{1, 2}}, 2); err == nil {
+		t.Errorf("Conover accepted the treatment 2 of 2")
+	}
+}
+
+func TestMannWhitney(t *testing.T) {
+	tests := []struct {
+		name      string
+		a         []float64
+		b         []float64
+		U         float64
+		pValue    float64
+		tolerance float64
+	}{
+		// wilcox.test(x, y) of R's documentation (Hollander & Wolfe, 1973):
+		// W = 35, p-value = 0.2544 (exact)
+		{"exact", []float64{0.80, 0.83, 1.89, 1.04, 1.45, 1.38, 1.91, 1.64, 0.73, 1.46},
+			[]float64{1.15, 0.88, 0.90, 0.74, 1.21}, 35, 0.2544, 5e-4},
+		// the only ordering with U = 0 out of C(6, 3) = 20
+		{"separated", []float64{1, 2, 3}, []float64{4, 5, 6}, 0, 0.1, 1e-9},
+		{"reversed", []float64{4, 5, 6}, []float64{1, 2, 3}, 9, 0.1, 1e-9},
+		// ties: normal approximation with continuity and tie correction, where the
+		// variance is 9/12*(7 - 30/30) = 4.5, so z = (3.5-0.5)/sqrt(4.5) = sqrt(2)
+		// and the p-value is 2*(1 - Phi(sqrt(2))) = erfc(1)
+		{"ties", []float64{1, 2, 2}, []float64{2, 3, 3}, 1, math.Erfc(1), 1e-9},
+		{"all ties", []float64{1, 1}, []float64{1, 1}, 2, 1, 1e-9},
+	}
+	for _, test := range tests {
+		U, pValue := statistics.MannWhitney(test.a, test.b)
+		if U != test.U {
+			t.Errorf("%s: U = %v, expected %v", test.name, U, test.U)
+		}
+		if math.Abs(pValue-test.pValue) > test.tolerance*test.pValue+1e-9 {
+			t.Errorf("%s: p-value = %v, expected %v", test.name, pValue, test.pValue)
+		}
+	}
+}
+
+func TestEffectSizes(t *testing.T) {
+	tests := []struct {
+		a         []float64
+		b         []float64
+		A12       float64
+		delta     float64
+		magnitude string
+	}{
+		{[]float64{4, 5, 6}, []float64{1, 2, 3}, 1, 1, "large"},
+		{[]float64{1, 2, 3}, []float64{4, 5, 6}, 0, -1, "large"},
+		{[]float64{1, 2}, []float64{1, 2}, 0.5, 0, "negligible"},
+		// 5 wins and a tie out of 9 comparisons
+		{[]float64{2, 3, 4}, []float64{1, 2, 5}, 5.5 / 9, 2*5.5/9 - 1, "small"},
+	}
+	for _, test := range tests {
+		if A12 := statistics.VarghaDelaney(test.a, test.b); math.Abs(A12-test.A12) > 1e-9 {
+			t.Errorf("VarghaDelaney(%v, %v) = %v, expected %v", test.a, test.b, A12, test.A12)
+		}
+		delta, magnitude := statistics.CliffDelta(test.a, test.b)
+		if math.Abs(delta-test.delta) > 1e-9 || magnitude != test.magnitude {
+			t.Errorf("CliffDelta(%v, %v) = %v %s, expected %v %s", test.a, test.b, delta, magnitude, test.delta, test.magnitude)
+		}
+	}
+}
+
+func TestDistributions(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    float64
+		expected float64
+	}{
+		{"chi-squared(2)", statistics.ChiSquaredCDF(3, 2), 1 - math.Exp(-1.5)},
+		{"chi-squared(1)", statistics.ChiSquaredCDF(3.841459, 1), 0.95},
+		{"chi-squared(10)", statistics.ChiSquaredCDF(18.307038, 10), 0.95},
+		{"chi-squared(3) at 0", statistics.ChiSquaredCDF(0, 3), 0},
+		{"t(1)", statistics.StudentTCDF(1, 1), 0.75},
+		{"t(2)", statistics.StudentTCDF(-2, 2), 0.5 - 2/(2*math.Sqrt(6))},
+		{"t(10)", statistics.StudentTCDF(2.228139, 10), 0.975},
+		{"normal", statistics.NormalCDF(1.959964), 0.975},
+		{"median odd", statistics.Median([]float64{3, 1, 2}), 2},
+		{"median even", statistics.Median([]float64{4, 1, 3, 2}), 2.5},
+	}
+	for _, test := range tests {
+		if math.Abs(test.value-test.expected) > 1e-6 {
+			t.Errorf("%s = %v, expected %v", test.name, test.value, test.expected)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package stopping
+
+import (
+	"context"
+	"time"
+
+	"go-airline-crew-rostering/airline"
+)
+
+// Reasons for stopping an optimization algorithm
+const (
+	MaxGenerations = "Maximum Generations"
+	TimeLimit      = "Time Limit"
+	TargetCost     = "Target Cost"
+	NoImprovement  = "No Improvement"
+	FullCoverage   = "Full Coverage"
+	Cancelled      = "Cancelled"
+)
+
+// Container for the functions related to the stopping criteria
+type CriteriaRepo interface {
+	Initialization() *Criteria
+	Context() (context.Context, context.CancelFunc)
+	Stop() string
+	State() (float64, int)
+	Restore()
+}
+
+// Stopping criteria of the optimization algorithms, checked after every generation
+type Criteria struct {
+	TimeLimit     time.Duration // maximum execution time of the algorithm (0 for no limit)
+	TargetCost    float64       // stop when the best cost is not bigger than this cost (negative for no target)
+	NoImprovement int           // stop after this number of generations without a better cost (0 for no limit)
+	FullCoverage  bool          // stop when the best solution covers all pairings
+	bestCost      float64       // best cost found so far
+	stagnation    int           // number of generations since the best cost was improved
+}
+
+func (criteria *Criteria) Initialization(timeLimit time.Duration, targetCost float64, noImprovement int, fullCoverage bool) *Criteria {
+	// Initialize a new instance of stopping criteria
+	criteria.TimeLimit = timeLimit
+	criteria.TargetCost = targetCost
+	criteria.NoImprovement = noImprovement
+	criteria.FullCoverage = fullCoverage
+	criteria.bestCost = -1
+	criteria.stagnation = 0
+	return criteria
+}
+
+func (criteria *Criteria) Context(parent context.Context) (context.Context, context.CancelFunc) {
+	// returns a context derived from "parent" that expires when the time limit is reached
+	if criteria.TimeLimit > 0 {
+		return context.WithTimeout(parent, criteria.TimeLimit)
+	}
+	return context.WithCancel(parent)
+}
+
+func (criteria *Criteria) Stop(ctx context.Context, bestCost float64, bestSolution []*airline.Pilot, al *airline.Airline) string {
+	// Check the stopping criteria using the best solution found so far
+	// returns the reason for stopping or an empty string if the algorithm must continue
+	if criteria.bestCost < 0 || bestCost < criteria.bestCost {
+		criteria.bestCost = bestCost
+		criteria.stagnation = 0
+	} else {
+		criteria.stagnation++
+	}
+
+	if ctx.Err() == context.DeadlineExceeded {
+		return TimeLimit
+	} else if ctx.Err() != nil {
+		return Cancelled
+	}
+	if criteria.TargetCost >= 0 && bestCost <= criteria.TargetCost {
+		return TargetCost
+	}
+	if criteria.NoImprovement > 0 && criteria.stagnation >= criteria.NoImprovement {
+		return NoImprovement
+	}
+	if criteria.FullCoverage {
+		coveredPairs := 0
+		for _, pilot := range bestSolution {
+			coveredPairs += pilot.AssignedLength
+		}
+		if coveredPairs == len(al.PairsArray)-1 {
+			return FullCoverage
+		}
+	}
+	return ""
+}
+
+func (criteria *Criteria) State() (float64, int) {
+	// returns the best cost found so far and the generations since it was found
+	return criteria.bestCost, criteria.stagnation
+}
+
+func (criteria *Criteria) Restore(bestCost float64, stagnation int) {
+	// Restore the state of the criteria returned by "State"
+	criteria.bestCost = bestCost
+	criteria.stagnation = stagnation
+}
@@ -0,0 +1,113 @@
+package stopping_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-airline-crew-rostering/airline"
+	"go-airline-crew-rostering/stopping"
+)
+
+func schedule(pairs int) (*airline.Airline, []*airline.Pilot) {
+	// returns an airline with "pairs" pairings and a pilot that flies the first one
+	start := time.Date(2011, 11, 1, 0, 0, 0, 0, time.UTC)
+	al := new(airline.Airline)
+	al.Initialization(660, 7, 2, start, start.AddDate(0, 0, 14), 1)
+	root := new(airline.Pair)
+	root.Initialization(0, start)
+	al.PairsArray = []*airline.Pair{root}
+	for i := 1; i <= pairs; i++ {
+		pair := new(airline.Pair)
+		pair.Initialization(i, start)
+		pair.Add(i, start.AddDate(0, 0, i).Add(8*time.Hour), start.AddDate(0, 0, i).Add(12*time.Hour), start)
+		al.PairsArray = append(al.PairsArray, pair)
+	}
+	pilot := new(airline.Pilot)
+	pilot.Initialization(0, al.ScheduleDuration, root)
+	pilot.Add(al.PairsArray[1], 1)
+	return al, []*airline.Pilot{pilot}
+}
+
+func TestStop(t *testing.T) {
+	expired, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-expired.Done()
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		targetCost    float64
+		noImprovement int
+		fullCoverage  bool
+		pairs         int
+		costs         []float64 // best cost after each generation
+		reasons       []string  // reason returned after each generation
+	}{
+		{"no criteria", context.Background(), -1, 0, false, 2, []float64{5, 4, 4}, []string{"", "", ""}},
+		{"time limit", expired, -1, 0, false, 2, []float64{5}, []string{stopping.TimeLimit}},
+		{"cancelled", cancelled, -1, 0, false, 2, []float64{5}, []string{stopping.Cancelled}},
+		{"target cost", context.Background(), 3, 0, false, 2, []float64{5, 3}, []string{"", stopping.TargetCost}},
+		{"zero target cost", context.Background(), 0, 0, false, 2, []float64{5, 1, 0}, []string{"", "", stopping.TargetCost}},
+		{"no improvement", context.Background(), -1, 2, false, 2, []float64{5, 5, 4, 4, 4}, []string{"", "", "", "", stopping.NoImprovement}},
+		{"uncovered pairings", context.Background(), -1, 0, true, 2, []float64{5}, []string{""}},
+		{"full coverage", context.Background(), -1, 0, true, 1, []float64{5}, []string{stopping.FullCoverage}},
+	}
+	for _, test := range tests {
+		al, solution := schedule(test.pairs)
+		criteria := new(stopping.Criteria)
+		criteria.Initialization(0, test.targetCost, test.noImprovement, test.fullCoverage)
+		for generation, cost := range test.costs {
+			if reason := criteria.Stop(test.ctx, cost, solution, al); reason != test.reasons[generation] {
+				t.Errorf("%s: generation %d stopped by %q, expected %q", test.name, generation, reason, test.reasons[generation])
+			}
+		}
+	}
+}
+
+func TestRestore(t *testing.T) {
+	// restored criteria continue counting the generations without improvement
+	al, solution := schedule(2)
+	criteria := new(stopping.Criteria)
+	criteria.Initialization(0, -1, 3, false)
+	for _, cost := range []float64{5, 4, 4} {
+		criteria.Stop(context.Background(), cost, solution, al)
+	}
+	bestCost, stagnation := criteria.State()
+	if bestCost != 4 || stagnation != 1 {
+		t.Fatalf("state = %v %d, expected 4 1", bestCost, stagnation)
+	}
+	restored := new(stopping.Criteria)
+	restored.Initialization(0, -1, 3, false)
+	restored.Restore(bestCost, stagnation)
+	if reason := restored.Stop(context.Background(), 4, solution, al); reason != "" {
+		t.Errorf("first generation after the restore stopped by %q", reason)
+	}
+	if reason := restored.Stop(context.Background(), 4, solution, al); reason != stopping.NoImprovement {
+		t.Errorf("second generation after the restore stopped by %q, expected %q", reason, stopping.NoImprovement)
+	}
+}
+
+func TestContext(t *testing.T) {
+	tests := []struct {
+		timeLimit time.Duration
+		deadline  bool
+	}{
+		{0, false},
+		{time.Hour, true},
+	}
+	for _, test := range tests {
+		criteria := new(stopping.Criteria)
+		criteria.Initialization(test.timeLimit, -1, 0, false)
+		ctx, cancel := criteria.Context(context.Background())
+		if _, deadline := ctx.Deadline(); deadline != test.deadline {
+			t.Errorf("time limit %v: deadline %v, expected %v", test.timeLimit, deadline, test.deadline)
+		}
+		cancel()
+		if ctx.Err() != context.Canceled {
+			t.Errorf("time limit %v: the context is not cancelled", test.timeLimit)
+		}
+	}
+}
@@ -0,0 +1,303 @@
+package tuning
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"go-airline-crew-rostering/randomness"
+	"go-airline-crew-rostering/statistics"
+	"go-airline-crew-rostering/workers"
+)
+
+// Container for the functions related to the tuning of the algorithm parameters
+type TunerRepo interface {
+	Initialization() *Tuner
+	Tune() *Configuration
+	Format() string
+	race() []*Configuration
+	sample() []*Configuration
+}
+
+// Range of values of an algorithm parameter
+type Parameter struct {
+	Name  string
+	Lower float64
+	Upper float64
+}
+
+// Parameters of multi-step CSO
+var MultiCSOParameters = []*Parameter{{"FL", 0, 2}}
+
+// Parameters of AOA
+var AOAParameters = []*Parameter{{"C1", 0.5, 3}, {"C2", 1, 8}, {"C3", 0.5, 3}, {"C4", 0.1, 1}}
+
+// Values of the algorithm parameters evaluated by the tuning
+type Configuration struct {
+	Id         int
+	Iteration  int       // iteration of the tuning that created the configuration
+	Values     []float64 // value of each parameter
+	Costs      []float64 // best cost found on each block of the last race of the configuration
+	MeanRank   float64   // mean rank of the configuration in the last race
+	PValue     float64   // p-value of the difference from the best configuration of the last race
+	Eliminated bool      // true if the configuration was discarded by the statistical test
+}
+
+func (c *Configuration) MeanCost() float64 {
+	// returns the mean best cost of the configuration in its last race
+	sum := 0.0
+	for _, cost := range c.Costs {
+		sum += cost
+	}
+	return sum / float64(len(c.Costs))
+}
+
+// Function that runs the algorithm with "configuration" on "instance"
+// and returns the cost of the best solution
+type Evaluation func(ctx context.Context, configuration *Configuration, instance int, seed int64) float64
+
+// Iterated racing procedure (iterated F-race) over the algorithm parameters
+type Tuner struct {
+	Parameters     []*Parameter
+	Configurations []*Configuration // all configurations evaluated so far
+	Best           *Configuration   // best configuration found by the tuning
+	Elites         []*Configuration // configurations that survived the last race, from best to worst
+	Statistic      float64          // Friedman statistic of the last race
+	PValue         float64          // p-value of the Friedman test of the last race
+	Blocks         int              // number of blocks (instance and seed) of the last race
+	Runs           int              // number of runs of the algorithm executed so far
+	Budget         int              // maximum number of runs of the algorithm
+	Seed           int64            // seed of the random number generator of the tuning
+	instances      int              // number of instances used by the races
+	candidates     int              // number of configurations of every race
+	elites         int              // maximum number of configurations that survive a race
+	firstTest      int              // number of blocks before the first statistical test
+	alpha          float64          // significance level of the statistical tests
+	workers        int              // maximum number of runs executed concurrently
+	evaluate       Evaluation
+	generator      *randomness.Generator
+}
+
+func (tuner *Tuner) Initialization(parameters []*Parameter, instances int, budget int, candidates int, workers int, evaluate Evaluation, generator *randomness.Generator) *Tuner {
+	// Initialize a tuning of "parameters" with "budget" runs of the algorithm
+	tuner.Parameters = parameters
+	tuner.Configurations = []*Configuration{}
+	tuner.Best = nil
+	tuner.Elites = []*Configuration{}
+	tuner.Runs = 0
+	tuner.Budget = budget
+	tuner.instances = instances
+	tuner.candidates = candidates
+	tuner.elites = 3
+	tuner.firstTest = 5
+	tuner.alpha = 0.05
+	tuner.workers = workers
+	tuner.evaluate = evaluate
+	tuner.generator = generator
+	tuner.Seed, _ = generator.State()
+	return tuner
+}
+
+func (tuner *Tuner) Tune(ctx context.Context) *Configuration {
+	// Main body of the tuning: every iteration samples new configurations around
+	// the elite configurations and races them along with the elites
+	// (the tuning stops early when "ctx" is cancelled)
+	// Returns the best configuration (nil if the budget is too small for a single block)
+	iterations := 2 + int(math.Log2(float64(len(tuner.Parameters))))
+	elites := []*Configuration{}
+	for iteration := 1; iteration <= iterations && tuner.Runs < tuner.Budget && ctx.Err() == nil; iteration++ {
+		budget := (tuner.Budget - tuner.Runs) / (iterations - iteration + 1)
+		// every configuration should be evaluated at least until the first test
+		candidates := int(math.Min(float64(tuner.candidates), float64(budget/tuner.firstTest)))
+		// (and every race has at least one new configuration)
+		candidates = int(math.Max(float64(candidates), 2))
+		if len(elites) >= candidates {
+			elites = elites[:candidates-1]
+		}
+		race := append(elites, tuner.sample(iteration, elites, candidates-len(elites))...)
+		survivors := tuner.race(ctx, race, budget)
+		if survivors == nil {
+			// no block of the race was evaluated (the next iteration has a bigger share of the budget)
+			continue
+		}
+		elites = survivors
+		tuner.Elites = elites
+		tuner.Best = elites[0]
+		fmt.Printf("Iteration %d: %d configurations, best %s (mean cost %.0f)\n", iteration, len(race), tuner.Format(tuner.Best), tuner.Best.MeanCost())
+	}
+	return tuner.Best
+}
+
+func (tuner *Tuner) Format(c *Configuration) string {
+	// returns the values of the parameters of the configuration
+	text := ""
+	for i, parameter := range tuner.Parameters {
+		if i > 0 {
+			text += " "
+		}
+		text += fmt.Sprintf("%s=%.2f", parameter.Name, c.Values[i])
+	}
+	return text
+}
+
+func (tuner *Tuner) sample(iteration int, elites []*Configuration, number int) []*Configuration {
+	// Create "number" new configurations: the first iteration samples the parameter
+	// ranges uniformly, later ones sample around an elite configuration with a spread
+	// that shrinks at every iteration (better elites are chosen more often)
+	configurations := []*Configuration{}
+	spread := math.Pow(1/float64(tuner.candidates), float64(iteration-1)/float64(len(tuner.Parameters)))
+	for n := 0; n < number; n++ {
+		c := &Configuration{Id: len(tuner.Configurations) + 1, Iteration: iteration}
+		var parent *Configuration
+		if len(elites) > 0 {
+			total := float64(len(elites) * (len(elites) + 1) / 2)
+			draw := tuner.generator.Float64() * total
+			for rank, elite := range elites {
+				draw -= float64(len(elites) - rank)
+				if draw < 0 {
+					parent = elite
+					break
+				}
+			}
+			if parent == nil {
+				parent = elites[len(elites)-1]
+			}
+		}
+		for i, parameter := range tuner.Parameters {
+			value := parameter.Lower + tuner.generator.Float64()*(parameter.Upper-parameter.Lower)
+			if parent != nil {
+				value = parent.Values[i] + tuner.generator.NormFloat64()*spread*(parameter.Upper-parameter.Lower)/2
+				value = math.Max(parameter.Lower, math.Min(parameter.Upper, value))
+			}
+			c.Values = append(c.Values, math.Round(value*100)/100)
+		}
+		tuner.Configurations = append(tuner.Configurations, c)
+		configurations = append(configurations, c)
+	}
+	return configurations
+}
+
+func (tuner *Tuner) race(ctx context.Context, configurations []*Configuration, budget int) []*Configuration {
+	// Evaluate the configurations on one block (instance and seed) at a time and discard
+	// the configurations that are significantly worse than the best one (Friedman test
+	// followed by Conover's post-hoc test), until one configuration is left
+	// or "budget" runs have been executed
+	// Returns the surviving configurations, from best to worst
+	// Returns nil if no block was evaluated (the budget is too small or the race was interrupted)
+	alive := configurations
+	results := [][]float64{}
+	runs := 0
+	for block := 0; runs+len(alive) <= budget && tuner.Runs+len(alive) <= tuner.Budget && (len(alive) > 1 || block == 0); block++ {
+		// every configuration of the block runs on the same instance with the same seed
+		instance := block % tuner.instances
+		seed := tuner.generator.Int63()
+		costs := make([]float64, len(alive))
+		workers.ForEach(tuner.workers, len(alive), func(i int) {
+			costs[i] = tuner.evaluate(ctx, alive[i], instance, seed)
+		})
+		if ctx.Err() != nil {
+			// the runs of the block were interrupted
+			break
+		}
+		if block == 0 {
+			// the results of the previous race are kept until the first block is evaluated
+			for _, c := range alive {
+				c.Costs = []float64{}
+				c.Eliminated = false
+				c.PValue = 1
+			}
+			tuner.Statistic, tuner.PValue = 0, 1
+		}
+		for i, c := range alive {
+			c.Costs = append(c.Costs, costs[i])
+		}
+		runs += len(alive)
+		tuner.Runs += len(alive)
+		results = append(results, costs)
+		tuner.Blocks = block + 1
+
+		if len(results) < tuner.firstTest || len(alive) < 2 {
+			continue
+		}
+		statistic, pValue, rankSums, err := statistics.Friedman(results)
+		if err != nil {
+			continue
+		}
+		tuner.Statistic, tuner.PValue = statistic, pValue
+		if tuner.PValue >= tuner.alpha {
+			continue
+		}
+		best := 0
+		for i := range rankSums {
+			if rankSums[i] < rankSums[best] {
+				best = i
+			}
+		}
+		pValues, err := statistics.Conover(results, best)
+		if err != nil {
+			continue
+		}
+		survivors := []*Configuration{}
+		keep := []int{}
+		for i, c := range alive {
+			c.PValue = pValues[i]
+			if pValues[i] < tuner.alpha && rankSums[i] > rankSums[best] {
+				c.Eliminated = true
+				c.MeanRank = rankSums[i] / float64(len(results))
+				continue
+			}
+			survivors = append(survivors, c)
+			keep = append(keep, i)
+		}
+		alive = survivors
+		for b := range results {
+			costs := []float64{}
+			for _, i := range keep {
+				costs = append(costs, results[b][i])
+			}
+			results[b] = costs
+		}
+	}
+
+	if len(results) == 0 {
+		return nil
+	}
+
+	// rank the survivors and compare them with the best one
+	rankSums := []float64{}
+	if statistic, pValue, sums, err := statistics.Friedman(results); err == nil && len(alive) > 1 {
+		tuner.Statistic, tuner.PValue, rankSums = statistic, pValue, sums
+	} else {
+		rankSums = make([]float64, len(alive))
+		for i := range rankSums {
+			rankSums[i] = float64(len(results))
+		}
+	}
+	for i, c := range alive {
+		c.MeanRank = rankSums[i] / float64(len(results))
+	}
+	sort.SliceStable(alive, func(i int, j int) bool {
+		if alive[i].MeanRank == alive[j].MeanRank {
+			return alive[i].MeanCost() < alive[j].MeanCost()
+		}
+		return alive[i].MeanRank < alive[j].MeanRank
+	})
+	if len(alive) > 1 {
+		sorted := make([][]float64, len(results))
+		for b := range results {
+			for _, c := range alive {
+				sorted[b] = append(sorted[b], c.Costs[b])
+			}
+		}
+		if pValues, err := statistics.Conover(sorted, 0); err == nil {
+			for i, pValue := range pValues {
+				alive[i].PValue = pValue
+			}
+		}
+	}
+	if len(alive) > tuner.elites {
+		alive = alive[:tuner.elites]
+	}
+	return alive
+}
@@ -0,0 +1,121 @@
+package tuning_test
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"testing"
+
+	"go-airline-crew-rostering/randomness"
+	"go-airline-crew-rostering/tuning"
+
+	"golang.org/x/exp/slices"
+)
+
+func quadratic(targets []float64) tuning.Evaluation {
+	// returns an evaluation whose cost is the squared distance from "targets"
+	// plus a small noise given by the seed (the instance shifts the cost)
+	return func(ctx context.Context, configuration *tuning.Configuration, instance int, seed int64) float64 {
+		cost := float64(instance) + rand.New(rand.NewSource(seed)).Float64()
+		for i, value := range configuration.Values {
+			cost += 1000 * (value - targets[i]) * (value - targets[i])
+		}
+		return cost
+	}
+}
+
+func TestTune(t *testing.T) {
+	tests := []struct {
+		name       string
+		parameters []*tuning.Parameter
+		targets    []float64
+		instances  int
+		budget     int
+		candidates int
+		tolerance  float64 // largest distance of the best configuration from the targets
+	}{
+		{"multiCSO", tuning.MultiCSOParameters, []float64{1.3}, 1, 200, 8, 0.3},
+		{"two instances", tuning.MultiCSOParameters, []float64{0.4}, 2, 200, 8, 0.3},
+		{"AOA", tuning.AOAParameters, []float64{2, 6, 1, 0.5}, 1, 600, 10, 2},
+		{"small budget", tuning.MultiCSOParameters, []float64{1.3}, 1, 12, 8, 2},
+	}
+	for _, test := range tests {
+		tuner := new(tuning.Tuner)
+		tuner.Initialization(test.parameters, test.instances, test.budget, test.candidates, 1, quadratic(test.targets), randomness.New(11))
+		best := tuner.Tune(context.Background())
+		if best == nil {
+			t.Errorf("%s: no configuration", test.name)
+			continue
+		}
+		if tuner.Runs > test.budget || tuner.Runs == 0 {
+			t.Errorf("%s: %d runs with a budget of %d", test.name, tuner.Runs, test.budget)
+		}
+		distance := 0.0
+		for i, parameter := range test.parameters {
+			if best.Values[i] < parameter.Lower || best.Values[i] > parameter.Upper {
+				t.Errorf("%s: %s = %v is out of range", test.name, parameter.Name, best.Values[i])
+			}
+			distance += (best.Values[i] - test.targets[i]) * (best.Values[i] - test.targets[i])
+		}
+		if math.Sqrt(distance) > test.tolerance {
+			t.Errorf("%s: best configuration %s is far from %v", test.name, tuner.Format(best), test.targets)
+		}
+		if tuner.Elites[0] != best || slices.ContainsFunc(tuner.Elites, func(c *tuning.Configuration) bool { return c.Eliminated }) {
+			t.Errorf("%s: the elites do not start with the best configuration or contain eliminated ones", test.name)
+		}
+	}
+}
+
+func TestTuneWorkers(t *testing.T) {
+	// the tuning does not depend on the number of concurrent runs
+	tune := func(workers int) *tuning.Tuner {
+		tuner := new(tuning.Tuner)
+		tuner.Initialization(tuning.AOAParameters, 2, 300, 8, workers, quadratic([]float64{2, 6, 1, 0.5}), randomness.New(5))
+		tuner.Tune(context.Background())
+		return tuner
+	}
+	sequential, concurrent := tune(1), tune(4)
+	if sequential.Runs != concurrent.Runs || len(sequential.Configurations) != len(concurrent.Configurations) ||
+		!slices.Equal(sequential.Best.Values, concurrent.Best.Values) || !slices.Equal(sequential.Best.Costs, concurrent.Best.Costs) {
+		t.Errorf("best configuration %s after %d runs, expected %s after %d runs",
+			concurrent.Format(concurrent.Best), concurrent.Runs, sequential.Format(sequential.Best), sequential.Runs)
+	}
+	if sequential.Seed != 5 {
+		t.Errorf("seed = %d, expected 5", sequential.Seed)
+	}
+}
+
+func TestTuneSmallBudget(t *testing.T) {
+	// a race that cannot evaluate a single block leaves the tuning without a best
+	// configuration, and its share of the budget goes to the next iteration
+	tests := []struct {
+		budget int
+		runs   int
+	}{
+		{0, 0},
+		{1, 0},
+		{3, 2}, // the first race has a budget of 1 for 2 configurations
+	}
+	for _, test := range tests {
+		tuner := new(tuning.Tuner)
+		tuner.Initialization(tuning.MultiCSOParameters, 1, test.budget, 8, 1, quadratic([]float64{1}), randomness.New(1))
+		best := tuner.Tune(context.Background())
+		if tuner.Runs != test.runs || (best == nil) != (test.runs == 0) {
+			t.Errorf("budget %d: best configuration %v after %d runs, expected %d runs", test.budget, best, tuner.Runs, test.runs)
+		}
+		if best != nil && (len(best.Costs) != 1 || tuner.Elites[0] != best) {
+			t.Errorf("budget %d: best configuration with the costs %v", test.budget, best.Costs)
+		}
+	}
+}
+
+func TestTuneCancelled(t *testing.T) {
+	// a cancelled tuning does not run the algorithm
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	tuner := new(tuning.Tuner)
+	tuner.Initialization(tuning.MultiCSOParameters, 1, 100, 8, 1, quadratic([]float64{1}), randomness.New(1))
+	if best := tuner.Tune(ctx); best != nil || tuner.Runs != 0 {
+		t.Errorf("cancelled tuning executed %d runs", tuner.Runs)
+	}
+}
@@ -0,0 +1,34 @@
+package workers
+
+import "sync"
+
+func ForEach(workers int, jobs int, job func(int)) {
+	// Execute "job" for every index in [0, jobs) with a pool of at most
+	// "workers" goroutines and wait until all of them are finished
+	if workers > jobs {
+		workers = jobs
+	}
+	if workers <= 1 {
+		// no need for goroutines
+		for i := 0; i < jobs; i++ {
+			job(i)
+		}
+		return
+	}
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				job(i)
+			}
+		}()
+	}
+	for i := 0; i < jobs; i++ {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+}
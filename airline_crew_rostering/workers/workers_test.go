@@ -0,0 +1,55 @@
+package workers_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go-airline-crew-rostering/workers"
+)
+
+func TestForEach(t *testing.T) {
+	// every job is executed once by at most "workers" goroutines
+	tests := []struct {
+		workers int
+		jobs    int
+		maximum int32 // maximum number of jobs running at the same time
+	}{
+		{0, 5, 1},
+		{1, 5, 1},
+		{4, 20, 4},
+		{8, 3, 3},
+		{4, 0, 0},
+	}
+	for _, test := range tests {
+		var mutex sync.Mutex
+		executed := make(map[int]int)
+		var running, maximum int32
+		workers.ForEach(test.workers, test.jobs, func(job int) {
+			current := atomic.AddInt32(&running, 1)
+			for {
+				previous := atomic.LoadInt32(&maximum)
+				if current <= previous || atomic.CompareAndSwapInt32(&maximum, previous, current) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			mutex.Lock()
+			executed[job]++
+			mutex.Unlock()
+			atomic.AddInt32(&running, -1)
+		})
+		if len(executed) != test.jobs {
+			t.Errorf("%d workers, %d jobs: %d jobs executed", test.workers, test.jobs, len(executed))
+		}
+		for job, times := range executed {
+			if job < 0 || job >= test.jobs || times != 1 {
+				t.Errorf("%d workers, %d jobs: job %d executed %d times", test.workers, test.jobs, job, times)
+			}
+		}
+		if maximum > test.maximum {
+			t.Errorf("%d workers, %d jobs: %d jobs at the same time, expected at most %d", test.workers, test.jobs, maximum, test.maximum)
+		}
+	}
+}